@@ -0,0 +1,186 @@
+// Copyright 2024 Adam Chalkley
+//
+// https://github.com/atc0005/go-teams-notify
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package adaptivecard
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// nestedShowCard returns an Action.ShowCard action whose Card contains
+// Action.ShowCard actions nested to the given depth.
+func nestedShowCard(t *testing.T, depth int) Action {
+	t.Helper()
+
+	card := NewCard()
+	card.Body = []Element{NewTextBlock("innermost", false)}
+
+	action := Action{Type: TypeActionShowCard, Title: "Level 1", Card: &card}
+
+	for i := 1; i < depth; i++ {
+		parent := NewCard()
+		parent.Actions = []Action{action}
+
+		action = Action{Type: TypeActionShowCard, Title: "Level", Card: &parent}
+	}
+
+	return action
+}
+
+func TestNewActionShowCard(t *testing.T) {
+	card := NewCard()
+	card.Body = []Element{NewTextBlock("Details", true)}
+
+	action, err := NewActionShowCard("Show details", card)
+	if assert.NoError(t, err) {
+		assert.Equal(t, TypeActionShowCard, action.Type)
+		assert.Equal(t, "Show details", action.Title)
+		assert.Equal(t, card, *action.Card)
+	}
+
+	// Nested cards are validated, including any Input elements.
+	card.Body = append(card.Body, Element{Type: TypeElementInputText})
+	_, err = NewActionShowCard("Show details", card)
+	assert.True(t, errors.Is(err, ErrMissingValue))
+
+	_, err = NewActionShowCard("Show details", Card{})
+	assert.True(t, errors.Is(err, ErrInvalidType))
+}
+
+func TestActionShowCardValidation(t *testing.T) {
+	assert.True(t, errors.Is(Action{Type: TypeActionShowCard, Title: "a"}.Validate(), ErrMissingValue))
+
+	card := NewCard()
+	assert.True(t, errors.Is(
+		Action{Type: TypeActionOpenURL, Title: "a", URL: "https://example.com", Card: &card}.Validate(),
+		ErrInvalidFieldValue,
+	))
+
+	// A Fallback value does not prevent validation of the remaining fields
+	// (e.g., the TargetElements required by Action.ToggleVisibility).
+	toggle := NewActionToggleVisibility("Toggle")
+	toggle.Fallback = TypeFallbackOptionDrop
+	assert.Error(t, toggle.Validate())
+}
+
+func TestActionShowCardNestingDepth(t *testing.T) {
+	assert.NoError(t, nestedShowCard(t, ActionShowCardMaxNestingDepth).Validate())
+
+	err := nestedShowCard(t, ActionShowCardMaxNestingDepth+1).Validate()
+	assert.True(t, errors.Is(err, ErrInvalidFieldValue))
+
+	// Actions within ActionSet elements count towards the depth.
+	card := NewCard()
+	card.Body = []Element{
+		{Type: TypeElementActionSet, Actions: []Action{nestedShowCard(t, ActionShowCardMaxNestingDepth)}},
+	}
+	_, err = NewActionShowCard("Show details", card)
+	assert.True(t, errors.Is(err, ErrInvalidFieldValue))
+}
+
+func TestActionShowCardSelfReference(t *testing.T) {
+	card := NewCard()
+	action := Action{Type: TypeActionShowCard, Title: "Loop", Card: &card}
+	card.Actions = []Action{action}
+
+	assert.True(t, errors.Is(action.Validate(), ErrInvalidFieldValue))
+	assert.True(t, errors.Is(TopLevelCard{card}.Validate(), ErrInvalidFieldValue))
+}
+
+func TestNewActionExecuteAndSubmit(t *testing.T) {
+	type payload struct {
+		ID string `json:"id"`
+	}
+
+	tests := map[string]struct {
+		data    interface{}
+		wantErr error
+	}{
+		"nil data":             {data: nil},
+		"typed nil map":        {data: map[string]interface{}(nil)},
+		"typed nil pointer":    {data: (*payload)(nil)},
+		"map":                  {data: map[string]interface{}{"id": "1"}},
+		"struct":               {data: payload{ID: "1"}},
+		"pointer to struct":    {data: &payload{ID: "1"}},
+		"string":               {data: "refresh"},
+		"number":               {data: 42, wantErr: ErrInvalidFieldValue},
+		"slice":                {data: []string{"a"}, wantErr: ErrInvalidFieldValue},
+		"unsupported encoding": {data: map[string]interface{}{"ch": make(chan int)}, wantErr: ErrInvalidFieldValue},
+	}
+
+	for name, tt := range tests {
+		name, tt := name, tt
+
+		t.Run(name, func(t *testing.T) {
+			execute, err := NewActionExecute("Approve", "approve", tt.data)
+			submit, submitErr := NewActionSubmit("Approve", tt.data)
+
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "got error: %v", err)
+				assert.True(t, errors.Is(submitErr, tt.wantErr), "got error: %v", submitErr)
+				return
+			}
+
+			if assert.NoError(t, err) {
+				assert.Equal(t, TypeActionExecute, execute.Type)
+				assert.Equal(t, "approve", execute.Verb)
+			}
+
+			if assert.NoError(t, submitErr) {
+				assert.Equal(t, TypeActionSubmit, submit.Type)
+			}
+		})
+	}
+}
+
+func TestActionDataFieldsValidation(t *testing.T) {
+	tests := map[string]struct {
+		action  Action
+		wantErr error
+	}{
+		"verb on Action.Submit": {
+			action:  Action{Type: TypeActionSubmit, Verb: "approve"},
+			wantErr: ErrInvalidFieldValue,
+		},
+		"data on Action.OpenUrl": {
+			action:  Action{Type: TypeActionOpenURL, URL: "https://example.com", Data: "a"},
+			wantErr: ErrInvalidFieldValue,
+		},
+		"typed nil data on Action.OpenUrl": {
+			action: Action{Type: TypeActionOpenURL, URL: "https://example.com", Data: map[string]interface{}(nil)},
+		},
+		"associated inputs on Action.Execute": {
+			action: Action{Type: TypeActionExecute, AssociatedInputs: "none"},
+		},
+		"unknown associated inputs value": {
+			action:  Action{Type: TypeActionExecute, AssociatedInputs: "some"},
+			wantErr: ErrInvalidFieldValue,
+		},
+		"associated inputs on Action.OpenUrl": {
+			action:  Action{Type: TypeActionOpenURL, URL: "https://example.com", AssociatedInputs: "auto"},
+			wantErr: ErrInvalidFieldValue,
+		},
+	}
+
+	for name, tt := range tests {
+		name, tt := name, tt
+
+		t.Run(name, func(t *testing.T) {
+			err := tt.action.Validate()
+
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.True(t, errors.Is(err, tt.wantErr), "got error: %v", err)
+		})
+	}
+}
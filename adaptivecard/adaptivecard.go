@@ -9,14 +9,18 @@ package adaptivecard
 
 import (
 	"bytes"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"math"
+	"net/url"
+	"reflect"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	goteamsnotify "github.com/flashcatcloud/go-teams-notify/v2"
 	"github.com/flashcatcloud/go-teams-notify/v2/internal/validator"
@@ -68,6 +72,27 @@ const (
 	defaultMentionTextSeparator string = " "
 )
 
+// Supported Mentioned.Type values. An empty Type indicates a user mention.
+//
+// Tag, channel and team mentions are supported by Microsoft Teams for
+// messages sent by bots or via the Microsoft Graph API.
+//
+//   - https://learn.microsoft.com/en-us/microsoftteams/platform/bots/how-to/conversations/channel-and-group-conversations#tag-mention
+const (
+	// MentionedTypeTag indicates a mention of a tag (e.g., "oncall-db"),
+	// notifying each member of the tag. The ID is the tag ID.
+	MentionedTypeTag string = "tag"
+
+	// MentionedTypeChannel indicates a mention of a channel, notifying
+	// members who follow the channel. The ID is the channel ID (e.g.,
+	// 19:abc123@thread.tacv2).
+	MentionedTypeChannel string = "channel"
+
+	// MentionedTypeTeam indicates a mention of a team, notifying all members
+	// of the team. The ID is the team ID.
+	MentionedTypeTeam string = "team"
+)
+
 // Attachment constants.
 //
 //   - https://docs.microsoft.com/en-us/microsoftteams/platform/task-modules-and-cards/cards/cards-reference
@@ -142,6 +167,12 @@ const (
 	WeightDefault string = "default"
 )
 
+// Font types for TextBlock or TextRun elements.
+const (
+	FontTypeDefault   string = "default"
+	FontTypeMonospace string = "monospace"
+)
+
 // Supported colors for TextBlock or TextRun elements.
 const (
 	ColorDefault   string = "default"
@@ -156,8 +187,102 @@ const (
 // Image specific constants.
 // https://adaptivecards.io/explorer/Image.html
 const (
-	ImageStyleDefault string = ""
-	ImageStylePerson  string = ""
+	ImageStyleDefault string = "default"
+	ImageStylePerson  string = "person"
+)
+
+// Media element specific constants.
+//
+//   - https://adaptivecards.io/explorer/Media.html
+//   - https://adaptivecards.io/explorer/MediaSource.html
+//   - https://adaptivecards.io/explorer/CaptionSource.html
+const (
+	// MediaClientSupportWarning notes the limited support for the Media
+	// element in Microsoft Teams clients. It is included in the results of
+	// Element.Warnings for every Media element.
+	MediaClientSupportWarning string = "Media element playback is only" +
+		" supported by recent Microsoft Teams desktop and web clients (and" +
+		" only for supported sources); other clients (e.g., mobile) may" +
+		" display the poster image or nothing at all"
+
+	// MediaCaptionSourcesMinCardVersionRequired is the minimum version of
+	// the Adaptive Card schema required to support the CaptionSources field
+	// of a Media element. This is later than AdaptiveCardMaxVersion (the
+	// Version set by NewCard).
+	MediaCaptionSourcesMinCardVersionRequired float64 = 1.6
+)
+
+// Image sizes for Image and ImageSet elements.
+//
+//   - https://adaptivecards.io/explorer/Image.html
+//   - https://adaptivecards.io/explorer/ImageSet.html
+const (
+	ImageSizeAuto    string = "auto"
+	ImageSizeStretch string = "stretch"
+	ImageSizeSmall   string = "small"
+	ImageSizeMedium  string = "medium"
+	ImageSizeLarge   string = "large"
+)
+
+// Image height values for Image elements. A specific pixel height (e.g.,
+// "50px") may also be used.
+const (
+	ImageHeightAuto    string = HeightAuto
+	ImageHeightStretch string = HeightStretch
+)
+
+// Image URL specific constants.
+const (
+	// ImageDataURIPrefix is the prefix used for an Image URL which embeds
+	// image data directly in the payload instead of referencing a remote
+	// image.
+	//
+	// https://developer.mozilla.org/en-US/docs/Web/HTTP/Basics_of_HTTP/Data_URLs
+	ImageDataURIPrefix string = "data:"
+
+	// ImageDataURIRegex is a regular expression pattern intended to match
+	// the base64 encoded image data URI format supported by Image elements
+	// (e.g., "data:image/png;base64,iVBORw0KGgo...").
+	ImageDataURIRegex string = "^data:image/[a-zA-Z0-9.+-]+;base64,[A-Za-z0-9+/]+={0,2}$"
+
+	// ImageBackgroundColorRegex is a regular expression pattern intended to
+	// match the hex color values (e.g., "#DDDDDD") supported by the
+	// BackgroundColor field of Image elements.
+	ImageBackgroundColorRegex string = "^#([0-9a-fA-F]{3,4}|[0-9a-fA-F]{6}|[0-9a-fA-F]{8})$"
+)
+
+// Input element specific constants.
+//
+//   - https://adaptivecards.io/explorer/Input.Text.html
+//   - https://adaptivecards.io/explorer/Input.Number.html
+//   - https://adaptivecards.io/explorer/Input.Date.html
+//   - https://adaptivecards.io/explorer/Input.Time.html
+//   - https://adaptivecards.io/explorer/Input.Toggle.html
+//   - https://adaptivecards.io/explorer/Input.ChoiceSet.html
+const (
+	// TypeInputChoice is the type for a choice in an Input.ChoiceSet
+	// element.
+	TypeInputChoice string = "Input.Choice"
+
+	// InputDateFormat is the format (as used by the time package) of values
+	// for the Input.Date element type.
+	InputDateFormat string = "2006-01-02"
+
+	// InputTimeFormat is the format (as used by the time package) of values
+	// for the Input.Time element type.
+	InputTimeFormat string = "15:04"
+
+	// InputToggleValueOn is the default value of an Input.Toggle element
+	// when toggled on.
+	InputToggleValueOn string = "true"
+
+	// InputToggleValueOff is the default value of an Input.Toggle element
+	// when toggled off.
+	InputToggleValueOff string = "false"
+
+	// InputChoiceSetValueSeparator separates the selected values of a
+	// multi-select Input.ChoiceSet element.
+	InputChoiceSetValueSeparator string = ","
 )
 
 // ChoiceInput specific constants.
@@ -213,6 +338,24 @@ const (
 	VerticalAlignmentBottom string = "bottom"
 )
 
+// Supported height values for Element types. Image elements additionally
+// support a specific pixel height (e.g., "50px").
+const (
+	HeightAuto    string = "auto"
+	HeightStretch string = "stretch"
+)
+
+// Supported fill modes for the BackgroundImage of a Card, Container, Column
+// or ColumnSet.
+//
+// https://adaptivecards.io/explorer/BackgroundImage.html
+const (
+	FillModeCover              string = "cover"
+	FillModeRepeatHorizontally string = "repeatHorizontally"
+	FillModeRepeatVertically   string = "repeatVertically"
+	FillModeRepeat             string = "repeat"
+)
+
 // Supported width values for the msteams property used in in Adaptive Card
 // messages sent via Microsoft Teams.
 const (
@@ -239,6 +382,12 @@ const (
 	// https://docs.microsoft.com/en-us/outlook/actionable-messages/message-card-reference#actions
 	TeamsActionsDisplayLimit int = 6
 
+	// ActionShowCardMaxNestingDepth is the maximum number of Action.ShowCard
+	// actions which may be nested within each other (e.g., an Action.ShowCard
+	// whose Card contains another Action.ShowCard). Deeply nested cards are
+	// difficult to navigate and poorly supported by Microsoft Teams clients.
+	ActionShowCardMaxNestingDepth int = 3
+
 	// TypeActionExecute is an action that gathers input fields, merges with
 	// optional data field, and sends an event to the client. Clients process
 	// the event by sending an Invoke activity of type adaptiveCard/action to
@@ -275,6 +424,61 @@ const (
 	TypeActionToggleVisibility string = "Action.ToggleVisibility"
 )
 
+// Action specific constants.
+//
+//   - https://adaptivecards.io/explorer/Action.Execute.html
+//   - https://adaptivecards.io/explorer/Action.Submit.html
+const (
+	// ActionStyleDefault indicates that the action uses the default style.
+	ActionStyleDefault string = "default"
+
+	// ActionStylePositive indicates that the action is displayed with a
+	// positive style (typically the button becomes accent color).
+	ActionStylePositive string = "positive"
+
+	// ActionStyleDestructive indicates that the action is displayed with a
+	// destructive style (typically the button becomes red).
+	ActionStyleDestructive string = "destructive"
+
+	// ActionModePrimary indicates that the action is displayed as a button.
+	ActionModePrimary string = "primary"
+
+	// ActionModeSecondary indicates that the action is placed in an overflow
+	// menu (typically a popup menu under a "..." button).
+	ActionModeSecondary string = "secondary"
+
+	// AssociatedInputsAuto indicates that the inputs on the current card and
+	// any parent cards are validated and submitted with the action.
+	AssociatedInputsAuto string = "auto"
+
+	// AssociatedInputsNone indicates that no inputs are validated or
+	// submitted with the action.
+	AssociatedInputsNone string = "none"
+)
+
+// Universal Action Model specific constants.
+//
+//   - https://adaptivecards.io/explorer/Refresh.html
+//   - https://adaptivecards.io/explorer/Authentication.html
+//   - https://docs.microsoft.com/en-us/adaptive-cards/authoring-cards/universal-action-model
+const (
+	// RefreshUserIDsLimit is the maximum number of user IDs which may be
+	// specified for a Card's Refresh value. Microsoft Teams only
+	// automatically refreshes a card for the listed users.
+	//
+	// https://docs.microsoft.com/en-us/microsoftteams/platform/task-modules-and-cards/cards/universal-actions-for-adaptive-cards/work-with-universal-actions-for-adaptive-cards
+	RefreshUserIDsLimit int = 60
+
+	// UniversalActionsMinCardVersionRequired is the minimum version of the
+	// Adaptive Card schema required to support the Refresh and
+	// Authentication Card properties.
+	UniversalActionsMinCardVersionRequired float64 = 1.4
+
+	// AuthCardButtonTypeSignIn is the type of an AuthCardButton used to sign
+	// in.
+	AuthCardButtonTypeSignIn string = "signin"
+)
+
 // Supported Fallback options.
 const (
 	TypeFallbackActionExecute          string = TypeActionExecute
@@ -289,6 +493,21 @@ const (
 	TypeFallbackOptionDrop string = "drop"
 )
 
+// FallbackMaxNestingDepth is the maximum number of fallback elements (or
+// Columns) which may be chained together (e.g., a fallback Element which
+// specifies its own fallback Element). This also limits the evaluation of
+// fallback values which (directly or indirectly) reference themselves.
+const FallbackMaxNestingDepth int = 3
+
+// Requires specific constants.
+//
+// https://docs.microsoft.com/en-us/adaptive-cards/authoring-cards/fallback
+const (
+	// RequiresAnyVersion indicates that any version of a required host
+	// feature is acceptable.
+	RequiresAnyVersion string = "*"
+)
+
 // Valid types for an Adaptive Card element. Not all types are supported by
 // Microsoft Teams.
 //
@@ -310,7 +529,7 @@ const (
 	TypeElementInputText      string = "Input.Text"
 	TypeElementInputTime      string = "Input.Time"
 	TypeElementInputToggle    string = "Input.Toggle"
-	TypeElementMedia          string = "Media"         // Introduced in version 1.1 (TODO: Is this supported in Teams message?)
+	TypeElementMedia          string = "Media"         // Introduced in version 1.1 (see Element.Warnings for Teams client support)
 	TypeElementRichTextBlock  string = "RichTextBlock" // Introduced in version 1.2
 	TypeElementTable          string = "Table"         // Introduced in version 1.5
 	TypeElementTextBlock      string = "TextBlock"
@@ -357,6 +576,9 @@ type Message struct {
 	// payload is a prepared Message in JSON format for submission or pretty
 	// printing.
 	payload *bytes.Buffer `json:"-"`
+
+	// UnknownFields holds unmodeled message properties; see ParseMessage.
+	UnknownFields map[string]json.RawMessage `json:"-"`
 }
 
 // Attachments is a collection of Adaptive Cards for a Microsoft Teams
@@ -381,6 +603,9 @@ type Attachment struct {
 	//
 	// TODO: Should this be a pointer?
 	Content TopLevelCard `json:"content"`
+
+	// UnknownFields holds unmodeled attachment properties; see ParseMessage.
+	UnknownFields map[string]json.RawMessage `json:"-"`
 }
 
 // TopLevelCard represents the outer or top-level Card for a Microsoft Teams
@@ -453,6 +678,147 @@ type Card struct {
 	// or cards with a minHeight specified. If MinHeight field is specified,
 	// this field is required.
 	VerticalContentAlignment string `json:"verticalContentAlignment,omitempty"`
+
+	// BackgroundImage specifies the background image of the card.
+	BackgroundImage *BackgroundImage `json:"backgroundImage,omitempty"`
+
+	// RTL controls whether the content of the card should be presented
+	// right-to-left. When not set, the default behavior of the host is used.
+	//
+	// NOTE: We define this field as a pointer type so that omitting a value
+	// for the pointer leaves the field out of the generated JSON payload.
+	RTL *bool `json:"rtl,omitempty"`
+
+	// Requires is a collection of host features (and the minimum version of
+	// each) which the card requires. Versions use the "major.minor" format or
+	// RequiresAnyVersion.
+	//
+	// https://docs.microsoft.com/en-us/adaptive-cards/authoring-cards/fallback
+	Requires map[string]string `json:"requires,omitempty"`
+
+	// Refresh defines how the card can be refreshed by making a request to
+	// the target Bot. Refresh is supported by cards delivered by bots
+	// (Universal Action Model) and requires Version 1.4 or later.
+	//
+	// https://adaptivecards.io/explorer/Refresh.html
+	Refresh *Refresh `json:"refresh,omitempty"`
+
+	// Authentication defines authentication information to enable on-behalf-
+	// of single sign on or just-in-time OAuth for the card. Authentication
+	// requires Version 1.4 or later.
+	//
+	// https://adaptivecards.io/explorer/Authentication.html
+	Authentication *Authentication `json:"authentication,omitempty"`
+
+	// UnknownFields holds unmodeled card properties; see ParseCard.
+	UnknownFields map[string]json.RawMessage `json:"-"`
+}
+
+// Refresh defines how a card can be refreshed by making a request to the
+// target Bot.
+//
+// https://adaptivecards.io/explorer/Refresh.html
+type Refresh struct {
+	// Action is required; the action that should be invoked to refresh the
+	// card. Only Action.Execute is supported.
+	Action Action `json:"action"`
+
+	// UserIDs is a list of user IDs (limited to RefreshUserIDsLimit) for
+	// which the card will be automatically refreshed. Other users can
+	// refresh the card manually.
+	UserIDs []string `json:"userIds,omitempty"`
+
+	// UnknownFields holds unmodeled refresh properties; see ParseCard.
+	UnknownFields map[string]json.RawMessage `json:"-"`
+}
+
+// Authentication defines authentication information associated with a card.
+//
+// https://adaptivecards.io/explorer/Authentication.html
+type Authentication struct {
+	// Text is the text that can be displayed to the end user when prompting
+	// them to authenticate.
+	Text string `json:"text,omitempty"`
+
+	// ConnectionName is the identifier for registered OAuth connection setting
+	// information.
+	ConnectionName string `json:"connectionName,omitempty"`
+
+	// TokenExchangeResource provides information required to enable
+	// on-behalf-of single sign-on user authentication.
+	TokenExchangeResource *TokenExchangeResource `json:"tokenExchangeResource,omitempty"`
+
+	// Buttons is the collection of buttons that should be displayed to the
+	// user when prompting for authentication.
+	Buttons []AuthCardButton `json:"buttons,omitempty"`
+
+	// UnknownFields holds unmodeled authentication properties; see ParseCard.
+	UnknownFields map[string]json.RawMessage `json:"-"`
+}
+
+// TokenExchangeResource defines information required to enable on-behalf-of
+// single sign-on user authentication.
+//
+// https://adaptivecards.io/explorer/TokenExchangeResource.html
+type TokenExchangeResource struct {
+	// ID is required; the unique identified of this token exchange instance.
+	ID string `json:"id"`
+
+	// URI is required; an application ID or resource identifier with which to
+	// exchange a token on behalf of.
+	URI string `json:"uri"`
+
+	// ProviderID is required; an identifier for the identity provider with
+	// which to attempt a token exchange.
+	ProviderID string `json:"providerId"`
+
+	// UnknownFields holds unmodeled token exchange resource properties; see ParseCard.
+	UnknownFields map[string]json.RawMessage `json:"-"`
+}
+
+// AuthCardButton defines a button as displayed when prompting a user to
+// authenticate.
+//
+// https://adaptivecards.io/explorer/AuthCardButton.html
+type AuthCardButton struct {
+	// Type is required; the type of the button (e.g., "signin").
+	Type string `json:"type"`
+
+	// Value is required; the value associated with the button (e.g., a sign
+	// in URL).
+	Value string `json:"value"`
+
+	// Title is the caption of the button.
+	Title string `json:"title,omitempty"`
+
+	// Image is a URL to an image to display alongside the button's caption.
+	Image string `json:"image,omitempty"`
+
+	// UnknownFields holds unmodeled authentication button properties; see ParseCard.
+	UnknownFields map[string]json.RawMessage `json:"-"`
+}
+
+// BackgroundImage specifies a background image for a Card, Container,
+// Column or ColumnSet.
+//
+// https://adaptivecards.io/explorer/BackgroundImage.html
+type BackgroundImage struct {
+	// URL is required; the URL (or data URI) of the image.
+	URL string `json:"url"`
+
+	// FillMode describes how the image should fill the area.
+	FillMode string `json:"fillMode,omitempty"`
+
+	// HorizontalAlignment describes how the image should be aligned if it
+	// must be cropped or if using repeat fill mode.
+	HorizontalAlignment string `json:"horizontalAlignment,omitempty"`
+
+	// VerticalAlignment describes how the image should be aligned if it must
+	// be cropped or if using repeat fill mode.
+	VerticalAlignment string `json:"verticalAlignment,omitempty"`
+
+	// UnknownFields holds unmodeled background image properties; see ParseCard.
+	UnknownFields map[string]json.RawMessage `json:"-"`
 }
 
 // Elements is a collection of Element values.
@@ -483,13 +849,63 @@ type Element struct {
 	Text string `json:"text,omitempty"`
 
 	// URL is required for the Image element type. URL is the URL to an Image
-	// in an ImageSet element type.
+	// in an ImageSet element type. Both http(s) URLs and base64 encoded data
+	// URIs (see NewImageDataURI) are supported.
 	//
 	// https://adaptivecards.io/explorer/Image.html
 	// https://adaptivecards.io/explorer/ImageSet.html
 	URL string `json:"url,omitempty"`
 
-	// Size controls the size of text within a TextBlock element.
+	// AltText is alternate text describing the image for an Image element or
+	// the media for a Media element.
+	AltText string `json:"altText,omitempty"`
+
+	// BackgroundColor applies a background to a transparent image for an
+	// Image element. This field respects the image style. Hex color values
+	// (e.g., "#DDDDDD") are supported.
+	BackgroundColor string `json:"backgroundColor,omitempty"`
+
+	// Width is the desired width of an Image element in pixels (e.g.,
+	// "50px"). If set, this field takes precedence over the Size field.
+	Width string `json:"width,omitempty"`
+
+	// Height is the desired height of the element. Valid values are "auto"
+	// or "stretch". Image elements also support a specific pixel height
+	// (e.g., "50px") which takes precedence over the Size field.
+	Height string `json:"height,omitempty"`
+
+	// Images is the collection of Image elements to display for an ImageSet
+	// element.
+	//
+	// https://adaptivecards.io/explorer/ImageSet.html
+	Images []Element `json:"images,omitempty"`
+
+	// ImageSize controls the approximate size of each image within an
+	// ImageSet element. The physical dimensions vary per host.
+	ImageSize string `json:"imageSize,omitempty"`
+
+	// Sources is required for the Media element type. Sources is the
+	// collection of media sources to attempt to play.
+	//
+	// https://adaptivecards.io/explorer/Media.html
+	Sources []MediaSource `json:"sources,omitempty"`
+
+	// Poster is the URL of an image to display before playing a Media
+	// element. Supports data URI in version 1.2+.
+	Poster string `json:"poster,omitempty"`
+
+	// CaptionSources is the collection of caption sources for a Media
+	// element.
+	//
+	// NOTE: This field requires a Card Version of at least
+	// MediaCaptionSourcesMinCardVersionRequired. Cards created by NewCard
+	// default to an earlier Version; set the Version explicitly (e.g., using
+	// Card.SetMinimumVersion) when specifying caption sources.
+	CaptionSources []CaptionSource `json:"captionSources,omitempty"`
+
+	// Size controls the size of text within a TextBlock element or the
+	// approximate size of an Image element. Valid values differ based on the
+	// element type.
 	Size string `json:"size,omitempty"`
 
 	// Weight controls the weight of text in TextBlock or TextRun elements.
@@ -499,6 +915,31 @@ type Element struct {
 	// elements.
 	Color string `json:"color,omitempty"`
 
+	// FontType controls the type of font used for TextBlock elements or text
+	// used in TextRun elements.
+	FontType string `json:"fontType,omitempty"`
+
+	// Inlines is required for the RichTextBlock element type. Inlines is the
+	// collection of TextRun elements displayed by the RichTextBlock.
+	//
+	// https://adaptivecards.io/explorer/RichTextBlock.html
+	Inlines []Element `json:"inlines,omitempty"`
+
+	// Italic specifies whether text used in a TextRun element is italicized.
+	Italic bool `json:"italic,omitempty"`
+
+	// Strikethrough specifies whether text used in a TextRun element is
+	// struck through.
+	Strikethrough bool `json:"strikethrough,omitempty"`
+
+	// Underline specifies whether text used in a TextRun element is
+	// underlined.
+	Underline bool `json:"underline,omitempty"`
+
+	// Highlight specifies whether text used in a TextRun element is
+	// highlighted.
+	Highlight bool `json:"highlight,omitempty"`
+
 	// Spacing controls the amount of spacing between this element and the
 	// preceding element.
 	Spacing string `json:"spacing,omitempty"`
@@ -552,6 +993,22 @@ type Element struct {
 	// "defaults to true" behavior as defined by the schema.
 	Visible *bool `json:"isVisible,omitempty"`
 
+	// Fallback describes what to do when an unknown element is encountered
+	// or the requirements of this element (or any children) can't be met.
+	// Valid values are the string "drop" (TypeFallbackOptionDrop) or an
+	// Element (or *Element) value to render instead.
+	//
+	// Elements introduced in a later Adaptive Card schema version than the
+	// card Version should specify a fallback.
+	//
+	// https://docs.microsoft.com/en-us/adaptive-cards/authoring-cards/fallback
+	Fallback interface{} `json:"fallback,omitempty"`
+
+	// Requires is a collection of host features (and the minimum version of
+	// each) which this element requires. If the host does not support the
+	// features, the Fallback is used.
+	Requires map[string]string `json:"requires,omitempty"`
+
 	// ShowGridLines specified whether grid lines should be displayed.  This
 	// field is used by a Table element type.
 	//
@@ -573,7 +1030,7 @@ type Element struct {
 	// element is tapped or selected. Action.ShowCard is not supported.
 	//
 	// This field is used by supported Container element types (Column,
-	// ColumnSet, Container).
+	// ColumnSet, Container) and by the Image and TextRun element types.
 	//
 	SelectAction *ISelectAction `json:"selectAction,omitempty"`
 
@@ -595,6 +1052,161 @@ type Element struct {
 	// Separator, when true, indicates that a separating line shown should be
 	// drawn at the top of the element.
 	Separator bool `json:"separator,omitempty"`
+
+	// Bleed determines whether the element should bleed through its parent's
+	// padding. This field is used by the Container and ColumnSet element
+	// types.
+	Bleed bool `json:"bleed,omitempty"`
+
+	// MinHeight specifies the minimum height of the element in pixels (e.g.,
+	// "80px"). This field is used by the Container and ColumnSet element
+	// types.
+	MinHeight string `json:"minHeight,omitempty"`
+
+	// VerticalContentAlignment defines how the content should be aligned
+	// vertically within the container. This field is used by the Container
+	// element type and is required if MinHeight is set.
+	VerticalContentAlignment string `json:"verticalContentAlignment,omitempty"`
+
+	// BackgroundImage specifies the background image of a Container element.
+	BackgroundImage *BackgroundImage `json:"backgroundImage,omitempty"`
+
+	// RTL controls whether the content of a Container element should be
+	// presented right-to-left. When not set, the layout direction is
+	// inherited from the parent.
+	//
+	// NOTE: We define this field as a pointer type so that omitting a value
+	// for the pointer leaves the field out of the generated JSON payload.
+	RTL *bool `json:"rtl,omitempty"`
+
+	// Title is required by the Input.Toggle element type and is displayed
+	// next to the toggle.
+	Title string `json:"title,omitempty"`
+
+	// Label is the label for an Input element. Labels are recommended for
+	// accessibility and are required when IsRequired is set.
+	//
+	// https://docs.microsoft.com/en-us/adaptive-cards/authoring-cards/input-validation
+	Label string `json:"label,omitempty"`
+
+	// Placeholder is a description of the input desired, displayed when no
+	// value has been entered or selected. This field is used by the
+	// Input.Text, Input.Number, Input.Date, Input.Time and Input.ChoiceSet
+	// element types.
+	Placeholder string `json:"placeholder,omitempty"`
+
+	// Value is the initial value of an Input element. The expected type
+	// differs based on the element type:
+	//
+	//   - Input.Number: a number (e.g., int or float64)
+	//   - Input.Date: a string in the InputDateFormat format
+	//   - Input.Time: a string in the InputTimeFormat format
+	//   - Input.ChoiceSet: a string; multiple values (if IsMultiSelect is
+	//     set) are separated by InputChoiceSetValueSeparator
+	//   - Input.Text, Input.Toggle: a string
+	Value interface{} `json:"value,omitempty"`
+
+	// IsRequired specifies whether a value is required for an Input element
+	// before the associated action (e.g., Action.Execute) is allowed.
+	IsRequired bool `json:"isRequired,omitempty"`
+
+	// ErrorMessage is displayed when the value entered for an Input element
+	// fails validation (e.g., a missing required value or a value which does
+	// not match Regex).
+	ErrorMessage string `json:"errorMessage,omitempty"`
+
+	// Min is the minimum value permitted for an Input element. Like Value,
+	// the expected type differs based on the element type (a number for
+	// Input.Number, a formatted string for Input.Date and Input.Time).
+	Min interface{} `json:"min,omitempty"`
+
+	// Max is the maximum value permitted for an Input element. See Min for
+	// the expected type.
+	Max interface{} `json:"max,omitempty"`
+
+	// MaxLength is a hint of the maximum length characters to collect for an
+	// Input.Text element.
+	MaxLength int `json:"maxLength,omitempty"`
+
+	// Regex is a regular expression indicating the required format of an
+	// Input.Text element value.
+	Regex string `json:"regex,omitempty"`
+
+	// IsMultiline specifies whether an Input.Text element allows multiple
+	// lines of input.
+	IsMultiline bool `json:"isMultiline,omitempty"`
+
+	// Choices is the collection of choices for an Input.ChoiceSet element.
+	Choices []Choice `json:"choices,omitempty"`
+
+	// IsMultiSelect specifies whether multiple choices may be selected for an
+	// Input.ChoiceSet element.
+	IsMultiSelect bool `json:"isMultiSelect,omitempty"`
+
+	// ValueOn is the value of an Input.Toggle element when toggled on. If not
+	// specified, defaults to "true".
+	ValueOn string `json:"valueOn,omitempty"`
+
+	// ValueOff is the value of an Input.Toggle element when toggled off. If
+	// not specified, defaults to "false".
+	ValueOff string `json:"valueOff,omitempty"`
+
+	// UnknownFields holds unmodeled element properties; see ParseCard.
+	UnknownFields map[string]json.RawMessage `json:"-"`
+}
+
+// MediaSources is a collection of MediaSource values.
+type MediaSources []MediaSource
+
+// MediaSource defines a source for a Media element.
+//
+// https://adaptivecards.io/explorer/MediaSource.html
+type MediaSource struct {
+	// MIMEType is the MIME type of the associated media (e.g., "video/mp4").
+	MIMEType string `json:"mimeType,omitempty"`
+
+	// URL is required; the URL to the media.
+	URL string `json:"url"`
+
+	// UnknownFields holds unmodeled media source properties; see ParseCard.
+	UnknownFields map[string]json.RawMessage `json:"-"`
+}
+
+// CaptionSources is a collection of CaptionSource values.
+type CaptionSources []CaptionSource
+
+// CaptionSource defines a source for captions for a Media element.
+//
+// https://adaptivecards.io/explorer/CaptionSource.html
+type CaptionSource struct {
+	// MIMEType is required; the MIME type of the captions (e.g., "vtt").
+	MIMEType string `json:"mimeType"`
+
+	// URL is required; the URL to the captions.
+	URL string `json:"url"`
+
+	// Label is required; the label of this caption source.
+	Label string `json:"label"`
+
+	// UnknownFields holds unmodeled caption source properties; see ParseCard.
+	UnknownFields map[string]json.RawMessage `json:"-"`
+}
+
+// Choices is a collection of Choice values.
+type Choices []Choice
+
+// Choice describes a choice for use in an Input.ChoiceSet element.
+//
+// https://adaptivecards.io/explorer/Input.Choice.html
+type Choice struct {
+	// Title is required; the text to display.
+	Title string `json:"title"`
+
+	// Value is required; the raw value for the choice.
+	Value string `json:"value"`
+
+	// UnknownFields holds unmodeled choice properties; see ParseCard.
+	UnknownFields map[string]json.RawMessage `json:"-"`
 }
 
 // Container is an Element type that allows grouping items together.
@@ -644,6 +1256,58 @@ type Column struct {
 	// tapped or selected. Action.ShowCard is not supported.
 	SelectAction *ISelectAction `json:"selectAction,omitempty"`
 
+	// Style is a style hint for the Column. Container style values are
+	// supported.
+	Style string `json:"style,omitempty"`
+
+	// Separator, when true, indicates that a separating line should be drawn
+	// at the left of the Column.
+	Separator bool `json:"separator,omitempty"`
+
+	// Spacing controls the amount of spacing between this Column and the
+	// preceding Column.
+	Spacing string `json:"spacing,omitempty"`
+
+	// Visible specifies whether this Column will be removed from the visual
+	// tree.
+	//
+	// If not specified defaults to true.
+	//
+	// NOTE: We define this field as a pointer type so that omitting a value
+	// for the pointer leaves the field out of the generated JSON payload (due
+	// to 'omitempty' behavior of the JSON encoder and results in the
+	// "defaults to true" behavior as defined by the schema.
+	Visible *bool `json:"isVisible,omitempty"`
+
+	// Fallback describes what to do when an unknown element is encountered or
+	// the requirements of this Column can't be met. Valid values are the
+	// string "drop" (TypeFallbackOptionDrop) or a Column (or *Column) value.
+	Fallback interface{} `json:"fallback,omitempty"`
+
+	// Bleed determines whether the Column should bleed through its parent's
+	// padding.
+	Bleed bool `json:"bleed,omitempty"`
+
+	// MinHeight specifies the minimum height of the Column in pixels (e.g.,
+	// "80px").
+	MinHeight string `json:"minHeight,omitempty"`
+
+	// VerticalContentAlignment defines how the content should be aligned
+	// vertically within the Column. This field is required if MinHeight is
+	// set.
+	VerticalContentAlignment string `json:"verticalContentAlignment,omitempty"`
+
+	// BackgroundImage specifies the background image of the Column.
+	BackgroundImage *BackgroundImage `json:"backgroundImage,omitempty"`
+
+	// RTL controls whether the content of the Column should be presented
+	// right-to-left. When not set, the layout direction is inherited from
+	// the parent.
+	//
+	// NOTE: We define this field as a pointer type so that omitting a value
+	// for the pointer leaves the field out of the generated JSON payload.
+	RTL *bool `json:"rtl,omitempty"`
+
 	// HorizontalCellContentAlignment is a property of the Table element type.
 	//
 	// This field controls how the content of all cells in the column is
@@ -659,6 +1323,9 @@ type Column struct {
 	// setting at the table level. When not specified, vertical alignment is
 	// defined at the table, row or cell level.
 	VerticalCellContentAlignment string `json:"verticalCellContentAlignment,omitempty"`
+
+	// UnknownFields holds unmodeled column properties; see ParseCard.
+	UnknownFields map[string]json.RawMessage `json:"-"`
 }
 
 // Facts is a collection of Fact values.
@@ -671,6 +1338,9 @@ type Fact struct {
 
 	// Value is required; the value of the fact.
 	Value string `json:"value"`
+
+	// UnknownFields holds unmodeled fact properties; see ParseCard.
+	UnknownFields map[string]json.RawMessage `json:"-"`
 }
 
 // TableColumnDefinition defines the characteristics of a column in a Table
@@ -714,6 +1384,9 @@ type TableCell struct {
 	// Items are the card elements that should be rendered inside of the
 	// cell.
 	Items []*Element `json:"items,omitempty"`
+
+	// UnknownFields holds unmodeled table cell properties; see ParseCard.
+	UnknownFields map[string]json.RawMessage `json:"-"`
 }
 
 // TableCells is a collection of TableCell values.
@@ -751,6 +1424,9 @@ type TableRow struct {
 	// there are columns defined on the Table element, the extra cells are
 	// ignored.
 	Cells []TableCell `json:"cells"`
+
+	// UnknownFields holds unmodeled table row properties; see ParseCard.
+	UnknownFields map[string]json.RawMessage `json:"-"`
 }
 
 // TableRows is a collection of TableRow values.
@@ -781,17 +1457,53 @@ type Action struct {
 	//
 	// https://docs.microsoft.com/en-us/microsoftteams/platform/task-modules-and-cards/cards/cards-reference#support-for-adaptive-cards
 	// https://docs.microsoft.com/en-us/adaptive-cards/authoring-cards/universal-action-model#schema
-	Verb string `json:"verb"`
-	Data string `json:"data"`
-
 	Type string `json:"type"`
 
+	// Verb is a card author defined verb associated with this action. This
+	// field is used by the Action.Execute type.
+	Verb string `json:"verb,omitempty"`
+
+	// Data is initial data that input fields will be combined with. These are
+	// essentially "hidden" properties. This field is used by the
+	// Action.Execute and Action.Submit types.
+	//
+	// The value must encode to either a JSON object (e.g., a struct or a
+	// map[string]interface{}) or a JSON string.
+	Data interface{} `json:"data,omitempty"`
+
+	// AssociatedInputs controls which inputs are validated and submitted
+	// with the action. This field is used by the Action.Execute and
+	// Action.Submit types.
+	AssociatedInputs string `json:"associatedInputs,omitempty"`
+
 	// ID is a unique identifier associated with this Action.
 	ID string `json:"id,omitempty"`
 
 	// Title is a label for the button or link that represents this action.
 	Title string `json:"title,omitempty"`
 
+	// Tooltip is text displayed when the user hovers the mouse over the
+	// action and is read when using narration software.
+	Tooltip string `json:"tooltip,omitempty"`
+
+	// Style controls the style of an action, which may influence how the
+	// action is displayed, spoken, etc.
+	Style string `json:"style,omitempty"`
+
+	// Mode determines whether the action is displayed with a button or is
+	// moved to an overflow menu.
+	Mode string `json:"mode,omitempty"`
+
+	// IsEnabled determines whether the action is displayed as enabled.
+	//
+	// If not specified defaults to true.
+	//
+	// NOTE: We define this field as a pointer type so that omitting a value
+	// for the pointer leaves the field out of the generated JSON payload (due
+	// to 'omitempty' behavior of the JSON encoder and results in the
+	// "defaults to true" behavior as defined by the schema.
+	IsEnabled *bool `json:"isEnabled,omitempty"`
+
 	// URL to open; required for the Action.OpenUrl type, optional for other
 	// action types.
 	URL string `json:"url,omitempty"`
@@ -816,6 +1528,9 @@ type Action struct {
 	//
 	// https://docs.microsoft.com/en-us/adaptive-cards/authoring-cards/input-validation
 	TargetElements []TargetElement `json:"targetElements,omitempty"`
+
+	// UnknownFields holds unmodeled action properties; see ParseCard.
+	UnknownFields map[string]json.RawMessage `json:"-"`
 }
 
 // TargetElement represents an entry for Action.ToggleVisibility's
@@ -838,6 +1553,9 @@ type TargetElement struct {
 	// to 'omitempty' behavior of the JSON encoder. If leaving this field out,
 	// visibility can be toggled for target Elements.
 	Visible *bool `json:"isVisible,omitempty"`
+
+	// UnknownFields holds unmodeled target element properties; see ParseCard.
+	UnknownFields map[string]json.RawMessage `json:"-"`
 }
 
 /*
@@ -874,7 +1592,6 @@ we support this instead:
 		}
 	]
 
-
 A Container type has a selectAction field. That slice contains TargetElement
 entries.
 
@@ -909,6 +1626,23 @@ type ISelectAction struct {
 	// types.
 	URL string `json:"url,omitempty"`
 
+	// Verb is a card author defined verb associated with this action. This
+	// field is specific to the Action.Execute Action type.
+	Verb string `json:"verb,omitempty"`
+
+	// Data is initial data that input fields will be combined with. This
+	// field is used by the Action.Execute and Action.Submit Action types.
+	Data interface{} `json:"data,omitempty"`
+
+	// AssociatedInputs controls which inputs are validated and submitted
+	// with the action. This field is used by the Action.Execute and
+	// Action.Submit Action types.
+	AssociatedInputs string `json:"associatedInputs,omitempty"`
+
+	// Tooltip is text displayed when the user hovers the mouse over the
+	// action and is read when using narration software.
+	Tooltip string `json:"tooltip,omitempty"`
+
 	// Fallback describes what to do when an unknown element is encountered or
 	// the requirements of this or any children can't be met.
 	Fallback string `json:"fallback,omitempty"`
@@ -923,6 +1657,9 @@ type ISelectAction struct {
 	//
 	// https://docs.microsoft.com/en-us/adaptive-cards/authoring-cards/input-validation
 	TargetElements []TargetElement `json:"targetElements,omitempty"`
+
+	// UnknownFields holds unmodeled select action properties; see ParseCard.
+	UnknownFields map[string]json.RawMessage `json:"-"`
 }
 
 // MSTeams represents a container for properties specific to Microsoft Teams
@@ -943,12 +1680,16 @@ type MSTeams struct {
 	// Entities is a collection of user mentions.
 	// TODO: Should this be a slice of pointers?
 	Entities []Mention `json:"entities,omitempty"`
+
+	// UnknownFields holds unmodeled msteams properties; see ParseCard.
+	UnknownFields map[string]json.RawMessage `json:"-"`
 }
 
 // Mentions is a collection of Mention values.
 type Mentions []Mention
 
-// Mention represents a mention in the message for a specific user.
+// Mention represents a mention in the message for a specific user, tag,
+// channel or team.
 type Mention struct {
 	// Type is required; must be set to "mention".
 	Type string `json:"type"`
@@ -960,19 +1701,36 @@ type Mention struct {
 	// HERE</at> tags.
 	Text string `json:"text"`
 
-	// Mentioned represents a user that is mentioned.
+	// Mentioned represents the user, tag, channel or team that is
+	// mentioned.
 	Mentioned Mentioned `json:"mentioned"`
+
+	// UnknownFields holds unmodeled mention properties; see ParseCard.
+	UnknownFields map[string]json.RawMessage `json:"-"`
 }
 
-// Mentioned represents the user id and name of a user that is mentioned.
+// Mentioned represents the id and name of a user, tag, channel or team that
+// is mentioned.
 type Mentioned struct {
 	// ID is the unique identifier for a user that is mentioned. This value
 	// can be an object ID (e.g., 5e8b0f4d-2cd4-4e17-9467-b0f6a5c0c4d0) or a
 	// UserPrincipalName (e.g., NewUser@contoso.onmicrosoft.com).
+	//
+	// For tag, channel or team mentions this is the ID of the tag, channel or
+	// team.
 	ID string `json:"id"`
 
-	// Name is the DisplayName of the user mentioned.
+	// Name is the DisplayName of the user mentioned, or the name of the tag,
+	// channel or team mentioned.
 	Name string `json:"name"`
+
+	// Type indicates the type of entity mentioned. Valid values are
+	// MentionedTypeTag, MentionedTypeChannel and MentionedTypeTeam. If empty,
+	// a user is mentioned.
+	Type string `json:"type,omitempty"`
+
+	// UnknownFields holds unmodeled mentioned properties; see ParseCard.
+	UnknownFields map[string]json.RawMessage `json:"-"`
 }
 
 // NewMessage creates a new Message with required fields predefined.
@@ -1244,15 +2002,153 @@ func (c Card) Validate() error {
 	)
 
 	v.SuccessfulFuncCall(
-		func() error {
-			return assertCardBodyHasMention(c.Body, c.MSTeams.Entities)
-		},
+		func() error { return assertValidPixelSizeOrEmptyValue(c.MinHeight) },
 	)
 
-	v.SelfValidate(Elements(c.Body))
-	v.SelfValidate(Actions(c.Actions))
+	v.InListIfFieldValNotEmpty(
+		c.VerticalContentAlignment,
+		"VerticalContentAlignment",
+		"card",
+		supportedVerticalContentAlignmentValues(),
+		ErrInvalidFieldValue,
+	)
 
-	return v.Err()
+	if c.BackgroundImage != nil {
+		v.SelfValidate(c.BackgroundImage)
+	}
+
+	v.SuccessfulFuncCall(
+		func() error { return assertRequiresValidValues(c.Requires) },
+	)
+
+	v.SuccessfulFuncCall(
+		func() error {
+			return assertCardBodyHasMention(c.Body, c.MSTeams.Entities)
+		},
+	)
+
+	v.SelfValidate(Elements(c.Body))
+	v.SelfValidate(Actions(c.Actions))
+
+	if c.Refresh != nil {
+		v.SelfValidate(c.Refresh)
+	}
+
+	if c.Authentication != nil {
+		v.SelfValidate(c.Authentication)
+	}
+
+	return v.Err()
+}
+
+// Validate asserts that fields have valid values.
+func (r Refresh) Validate() error {
+	v := validator.Validator{}
+
+	v.FieldHasSpecificValue(
+		r.Action.Type,
+		"Action type",
+		TypeActionExecute,
+		"refresh",
+		ErrInvalidType,
+	)
+
+	v.SelfValidate(r.Action)
+
+	v.SuccessfulFuncCall(
+		func() error {
+			if len(r.UserIDs) > RefreshUserIDsLimit {
+				return fmt.Errorf(
+					"%d UserIDs specified for refresh; limit is %d: %w",
+					len(r.UserIDs),
+					RefreshUserIDsLimit,
+					ErrInvalidFieldValue,
+				)
+			}
+
+			for _, userID := range r.UserIDs {
+				if strings.TrimSpace(userID) == "" {
+					return fmt.Errorf(
+						"empty user ID in UserIDs for refresh: %w",
+						ErrMissingValue,
+					)
+				}
+			}
+
+			return nil
+		},
+	)
+
+	return v.Err()
+}
+
+// Validate asserts that fields have valid values.
+func (a Authentication) Validate() error {
+	v := validator.Validator{}
+
+	if a.TokenExchangeResource != nil {
+		v.SelfValidate(a.TokenExchangeResource)
+	}
+
+	for _, button := range a.Buttons {
+		v.SelfValidate(button)
+	}
+
+	return v.Err()
+}
+
+// Validate asserts that fields have valid values.
+func (t TokenExchangeResource) Validate() error {
+	v := validator.Validator{}
+
+	v.NotEmptyValue(t.ID, "ID", "TokenExchangeResource", ErrMissingValue)
+	v.NotEmptyValue(t.URI, "URI", "TokenExchangeResource", ErrMissingValue)
+	v.NotEmptyValue(t.ProviderID, "ProviderID", "TokenExchangeResource", ErrMissingValue)
+
+	return v.Err()
+}
+
+// Validate asserts that fields have valid values.
+func (b AuthCardButton) Validate() error {
+	v := validator.Validator{}
+
+	v.NotEmptyValue(b.Type, "Type", "AuthCardButton", ErrMissingValue)
+	v.NotEmptyValue(b.Value, "Value", "AuthCardButton", ErrMissingValue)
+
+	return v.Err()
+}
+
+// Validate asserts that fields have valid values.
+func (bi BackgroundImage) Validate() error {
+	v := validator.Validator{}
+
+	v.NotEmptyValue(bi.URL, "URL", "BackgroundImage", ErrMissingValue)
+	v.SuccessfulFuncCall(
+		func() error { return assertImageURLValidValue(bi.URL) },
+	)
+	v.InListIfFieldValNotEmpty(
+		bi.FillMode,
+		"FillMode",
+		"BackgroundImage",
+		supportedFillModeValues(),
+		ErrInvalidFieldValue,
+	)
+	v.InListIfFieldValNotEmpty(
+		bi.HorizontalAlignment,
+		"HorizontalAlignment",
+		"BackgroundImage",
+		supportedHorizontalAlignmentValues(),
+		ErrInvalidFieldValue,
+	)
+	v.InListIfFieldValNotEmpty(
+		bi.VerticalAlignment,
+		"VerticalAlignment",
+		"BackgroundImage",
+		supportedVerticalContentAlignmentValues(),
+		ErrInvalidFieldValue,
+	)
+
+	return v.Err()
 }
 
 // Validate asserts that fields have valid values.
@@ -1269,12 +2165,23 @@ func (tc TopLevelCard) Validate() error {
 		func() error { return assertValidVersionFieldValue(tc.Version) },
 	)
 
+	// Elements, fields and actions introduced in a later Adaptive Card schema
+	// version than the card Version are not supported. Elements (and
+	// actions) which specify a fallback are exempt.
+	v.SuccessfulFuncCall(
+		func() error { return assertCardSupportedByVersion(tc.Card, tc.Version) },
+	)
+
 	return v.Err()
 }
 
 // Validate asserts that the collection of Element values are all valid.
 func (e Elements) Validate() error {
 	for _, element := range e {
+		if err := assertNotInlineElement(element); err != nil {
+			return err
+		}
+
 		if err := element.Validate(); err != nil {
 			return err
 		}
@@ -1288,7 +2195,7 @@ func (e Element) Validate() error {
 	v := validator.Validator{}
 
 	supportedElementTypes := supportedElementTypes()
-	supportedSizeValues := supportedSizeValues()
+	supportedSizeValues := supportedSizeValues(e.Type)
 	supportedWeightValues := supportedWeightValues()
 	supportedColorValues := supportedColorValues()
 	supportedSpacingValues := supportedSpacingValues()
@@ -1308,10 +2215,40 @@ func (e Element) Validate() error {
 	v.InListIfFieldValNotEmpty(e.Size, "Size", "element", supportedSizeValues, ErrInvalidFieldValue)
 	v.InListIfFieldValNotEmpty(e.Weight, "Weight", "element", supportedWeightValues, ErrInvalidFieldValue)
 	v.InListIfFieldValNotEmpty(e.Color, "Color", "element", supportedColorValues, ErrInvalidFieldValue)
+	v.InListIfFieldValNotEmpty(e.FontType, "FontType", "element", supportedFontTypeValues(), ErrInvalidFieldValue)
 	v.InListIfFieldValNotEmpty(e.Spacing, "Spacing", "element", supportedSpacingValues, ErrInvalidFieldValue)
 	v.InListIfFieldValNotEmpty(e.HorizontalAlignment, "HorizontalAlignment", "element", supportedHorizontalAlignmentValues, ErrInvalidFieldValue)
 	v.InListIfFieldValNotEmpty(e.Style, "Style", "element", supportedStyleValues, ErrInvalidFieldValue)
 
+	// Image elements also support pixel height values; see Image specific
+	// requirements below.
+	if e.Type != TypeElementImage {
+		v.InListIfFieldValNotEmpty(e.Height, "Height", e.Type, supportedHeightValues(), ErrInvalidFieldValue)
+	}
+
+	// Layout fields used by container element types.
+	v.SuccessfulFuncCall(
+		func() error { return assertValidPixelSizeOrEmptyValue(e.MinHeight) },
+	)
+	v.InListIfFieldValNotEmpty(
+		e.VerticalContentAlignment,
+		"VerticalContentAlignment",
+		e.Type,
+		supportedVerticalContentAlignmentValues(),
+		ErrInvalidFieldValue,
+	)
+
+	if e.BackgroundImage != nil {
+		v.SelfValidate(e.BackgroundImage)
+	}
+
+	v.SuccessfulFuncCall(
+		func() error { return assertElementFallbackValidValue(e) },
+	)
+	v.SuccessfulFuncCall(
+		func() error { return assertRequiresValidValues(e.Requires) },
+	)
+
 	/******************************************************************
 		Requirements for specific Element types.
 	******************************************************************/
@@ -1323,6 +2260,21 @@ func (e Element) Validate() error {
 	// case e.Type == TypeElementTextBlock:
 	// case e.Type == TypeElementTextRun:
 
+	// Inlines collection is required for RichTextBlock element type and may
+	// only contain TextRun elements.
+	// https://adaptivecards.io/explorer/RichTextBlock.html
+	case e.Type == TypeElementRichTextBlock:
+		v.NotEmptyCollection("Inlines", e.Type, ErrMissingValue, e.Inlines)
+		v.SuccessfulFuncCall(
+			func() error { return assertRichTextBlockValidValues(e) },
+		)
+
+	// https://adaptivecards.io/explorer/TextRun.html
+	case e.Type == TypeElementTextRun:
+		if e.SelectAction != nil {
+			v.SelfValidate(e.SelectAction)
+		}
+
 	// Columns collection is used by the ColumnSet type. While not required,
 	// the collection should be checked.
 	case e.Type == TypeElementColumnSet:
@@ -1344,6 +2296,16 @@ func (e Element) Validate() error {
 		v.NotEmptyCollection("Items", e.Type, ErrMissingValue, e.Items)
 		v.SelfValidate(Elements(e.Items))
 
+		// Both are optional fields, unless MinHeight is set in which case
+		// VerticalContentAlignment is required.
+		v.SuccessfulFuncCall(
+			func() error {
+				return assertHeightAlignmentFieldsSetWhenRequired(
+					e.MinHeight, e.VerticalContentAlignment,
+				)
+			},
+		)
+
 		if e.SelectAction != nil {
 			v.SelfValidate(e.SelectAction)
 		}
@@ -1352,6 +2314,38 @@ func (e Element) Validate() error {
 	// https://adaptivecards.io/explorer/Image.html
 	case e.Type == TypeElementImage:
 		v.NotEmptyValue(e.URL, "URL", e.Type, ErrMissingValue)
+		v.SuccessfulFuncCall(
+			func() error { return assertImageValidValues(e) },
+		)
+
+		if e.SelectAction != nil {
+			v.SelfValidate(e.SelectAction)
+		}
+
+	// Images collection is required for ImageSet element type.
+	// https://adaptivecards.io/explorer/ImageSet.html
+	case e.Type == TypeElementImageSet:
+		v.NotEmptyCollection("Images", e.Type, ErrMissingValue, e.Images)
+		v.InListIfFieldValNotEmpty(
+			e.ImageSize,
+			"ImageSize",
+			e.Type,
+			supportedImageSizeValues(),
+			ErrInvalidFieldValue,
+		)
+		v.SuccessfulFuncCall(
+			func() error { return assertImageSetValidValues(e) },
+		)
+
+	// Sources collection is required for Media element type.
+	// https://adaptivecards.io/explorer/Media.html
+	case e.Type == TypeElementMedia:
+		v.NotEmptyCollection("Sources", e.Type, ErrMissingValue, e.Sources)
+		v.SelfValidate(MediaSources(e.Sources))
+		v.SelfValidate(CaptionSources(e.CaptionSources))
+		v.SuccessfulFuncCall(
+			func() error { return assertImageURLValidValue(e.Poster) },
+		)
 
 	// Facts collection is required for FactSet element type.
 	// https://adaptivecards.io/explorer/FactSet.html
@@ -1371,6 +2365,13 @@ func (e Element) Validate() error {
 		v.SelfValidate(TableRows(e.Rows))
 
 		v.SelfValidate(TableColumnDefinitions(e.Columns))
+
+	// Input elements share a common set of requirements along with those
+	// specific to each type.
+	case isInputElementType(e.Type):
+		v.SuccessfulFuncCall(
+			func() error { return assertInputElementValidValues(e) },
+		)
 	}
 
 	// Return the last recorded validation error, or nil if no validation
@@ -1378,6 +2379,78 @@ func (e Element) Validate() error {
 	return v.Err()
 }
 
+// Warnings returns a list of non-fatal issues for this Element. Unlike
+// validation errors, warnings do not prevent delivery of a Card, but note
+// content which may not display as expected in some Microsoft Teams clients.
+// Child elements are not evaluated; see Card.Warnings for that.
+func (e Element) Warnings() []string {
+	var warnings []string
+
+	switch e.Type {
+	case TypeElementMedia:
+		warnings = append(warnings, MediaClientSupportWarning)
+
+		if e.Poster == "" {
+			warnings = append(warnings, fmt.Sprintf(
+				"%s element has no Poster; clients unable to play media display nothing",
+				e.Type,
+			))
+		}
+
+		if e.AltText == "" {
+			warnings = append(warnings, fmt.Sprintf(
+				"%s element has no AltText for accessibility software",
+				e.Type,
+			))
+		}
+
+		for _, source := range e.Sources {
+			if source.MIMEType == "" {
+				warnings = append(warnings, fmt.Sprintf(
+					"%s source %q has no MIMEType; clients may be unable to play it",
+					e.Type,
+					source.URL,
+				))
+			}
+		}
+	}
+
+	return warnings
+}
+
+// Warnings returns a list of non-fatal issues for all elements within this
+// Card, including those within nested Action.ShowCard Cards. See
+// Element.Warnings for details.
+func (c Card) Warnings() []string {
+	var warnings []string
+
+	walkElements(c.Body, func(e Element) {
+		warnings = append(warnings, e.Warnings()...)
+	})
+
+	for _, action := range cardActions(c) {
+		if action.Type == TypeActionShowCard && action.Card != nil &&
+			showCardNestingDepth(action, ActionShowCardMaxNestingDepth+1) <= ActionShowCardMaxNestingDepth {
+
+			warnings = append(warnings, action.Card.Warnings()...)
+		}
+	}
+
+	return warnings
+}
+
+// Warnings returns a list of non-fatal issues for all Cards within this
+// Message. See Element.Warnings for details.
+func (m Message) Warnings() []string {
+	var warnings []string
+
+	for _, attachment := range m.Attachments {
+		warnings = append(warnings, attachment.Content.Warnings()...)
+	}
+
+	return warnings
+}
+
 // Validate asserts that the collection of Column values are all valid.
 func (c Columns) Validate() error {
 	for _, column := range c {
@@ -1401,6 +2474,10 @@ func (ci ColumnItems) Validate() error {
 			)
 		}
 
+		if err := assertNotInlineElement(*item); err != nil {
+			return err
+		}
+
 		if err := item.Validate(); err != nil {
 			return err
 		}
@@ -1624,13 +2701,19 @@ func (tr TableCell) Validate() error {
 		ErrInvalidFieldValue,
 	)
 
-	v.NotEmptyCollection(
-		"TableCellItems",
-		TypeTableCell,
-		ErrMissingValue,
-		tr.Items,
+	// Both are optional fields, unless MinHeight is set in which case
+	// VerticalContentAlignment is required.
+	v.SuccessfulFuncCall(
+		func() error {
+			return assertHeightAlignmentFieldsSetWhenRequired(
+				tr.MinHeight, tr.VerticalContentAlignment,
+			)
+		},
 	)
 
+	// An empty Items collection is permitted; NewTableCellsWithTextBlock
+	// inserts empty cells as placeholders for nil input values.
+
 	v.NoNilValuesInCollection(
 		"TableCellItems",
 		TypeTableCell,
@@ -1639,6 +2722,11 @@ func (tr TableCell) Validate() error {
 	)
 
 	for _, item := range tr.Items {
+		if item != nil {
+			v.SuccessfulFuncCall(
+				func() error { return assertNotInlineElement(*item) },
+			)
+		}
 		v.SelfValidate(item)
 	}
 
@@ -1656,11 +2744,15 @@ func (c *Column) AddSelectAction(action interface{}) error {
 	case Action:
 		// Perform manual conversion to the supported type.
 		selectAction := ISelectAction{
-			Type:     v.Type,
-			ID:       v.ID,
-			Title:    v.Title,
-			URL:      v.URL,
-			Fallback: v.Fallback,
+			Type:             v.Type,
+			ID:               v.ID,
+			Title:            v.Title,
+			URL:              v.URL,
+			Verb:             v.Verb,
+			Data:             v.Data,
+			AssociatedInputs: v.AssociatedInputs,
+			Tooltip:          v.Tooltip,
+			Fallback:         v.Fallback,
 		}
 
 		// Don't touch the new TargetElements field unless the provided Action
@@ -1705,6 +2797,39 @@ func (c Column) Validate() error {
 		func() error { return assertColumnWidthValidValues(c) },
 	)
 
+	v.InListIfFieldValNotEmpty(c.Style, "Style", c.Type, supportedContainerStyleValues(), ErrInvalidFieldValue)
+	v.InListIfFieldValNotEmpty(c.Spacing, "Spacing", c.Type, supportedSpacingValues(), ErrInvalidFieldValue)
+
+	v.SuccessfulFuncCall(
+		func() error { return assertValidPixelSizeOrEmptyValue(c.MinHeight) },
+	)
+
+	v.InListIfFieldValNotEmpty(
+		c.VerticalContentAlignment,
+		"VerticalContentAlignment",
+		c.Type,
+		supportedVerticalContentAlignmentValues(),
+		ErrInvalidFieldValue,
+	)
+
+	// Both are optional fields, unless MinHeight is set in which case
+	// VerticalContentAlignment is required.
+	v.SuccessfulFuncCall(
+		func() error {
+			return assertHeightAlignmentFieldsSetWhenRequired(
+				c.MinHeight, c.VerticalContentAlignment,
+			)
+		},
+	)
+
+	if c.BackgroundImage != nil {
+		v.SelfValidate(c.BackgroundImage)
+	}
+
+	v.SuccessfulFuncCall(
+		func() error { return assertColumnFallbackValidValue(c) },
+	)
+
 	// Assert that the collection does not contain nil items.
 	v.NoNilValuesInCollection("Items", c.Type, ErrMissingValue, c.Items)
 
@@ -1741,6 +2866,70 @@ func (f Fact) Validate() error {
 	return v.Err()
 }
 
+// Validate asserts that the collection of MediaSource values are all valid.
+func (ms MediaSources) Validate() error {
+	for _, source := range ms {
+		if err := source.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Validate asserts that fields have valid values.
+func (ms MediaSource) Validate() error {
+	v := validator.Validator{}
+
+	v.NotEmptyValue(ms.URL, "URL", "MediaSource", ErrMissingValue)
+
+	return v.Err()
+}
+
+// Validate asserts that the collection of CaptionSource values are all
+// valid.
+func (cs CaptionSources) Validate() error {
+	for _, source := range cs {
+		if err := source.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Validate asserts that fields have valid values.
+func (cs CaptionSource) Validate() error {
+	v := validator.Validator{}
+
+	v.NotEmptyValue(cs.MIMEType, "MIMEType", "CaptionSource", ErrMissingValue)
+	v.NotEmptyValue(cs.URL, "URL", "CaptionSource", ErrMissingValue)
+	v.NotEmptyValue(cs.Label, "Label", "CaptionSource", ErrMissingValue)
+
+	return v.Err()
+}
+
+// Validate asserts that the collection of Choice values are all valid.
+func (c Choices) Validate() error {
+	for _, choice := range c {
+		if err := choice.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Validate asserts that fields have valid values.
+func (c Choice) Validate() error {
+	v := validator.Validator{}
+
+	v.NotEmptyValue(c.Title, "Title", TypeInputChoice, ErrMissingValue)
+	v.NotEmptyValue(c.Value, "Value", TypeInputChoice, ErrMissingValue)
+
+	return v.Err()
+}
+
 // Validate asserts that fields have valid values.
 func (m MSTeams) Validate() error {
 	v := validator.Validator{}
@@ -1761,6 +2950,8 @@ func (m MSTeams) Validate() error {
 
 // Validate asserts that fields have valid values.
 func (i ISelectAction) Validate() error {
+	// See Action.Validate regarding the declared Version of the enclosing
+	// Card.
 	supportedISelectActionValues := supportedISelectActionValues(AdaptiveCardMaxVersion)
 	fallbackValues := supportedActionFallbackValues(AdaptiveCardMaxVersion)
 
@@ -1796,6 +2987,14 @@ func (i ISelectAction) Validate() error {
 		v.NotEmptyCollection("TargetElements", i.Type, ErrMissingValue, i.TargetElements)
 	}
 
+	v.SuccessfulFuncCall(
+		func() error {
+			return assertActionDataFieldsValidValues(
+				i.Type, i.Verb, i.Data, i.AssociatedInputs,
+			)
+		},
+	)
+
 	return v.Err()
 }
 
@@ -1910,6 +3109,9 @@ func (a *Action) AddTargetElementID(visible *bool, elementIDs ...string) error {
 
 // Validate asserts that fields have valid values.
 func (a Action) Validate() error {
+	// Actions are validated against all supported types here; the declared
+	// Version of the enclosing Card is asserted by TopLevelCard validation
+	// (see Card.MinimumVersion).
 	actionValues := supportedActionValues(AdaptiveCardMaxVersion)
 	fallbackValues := supportedActionFallbackValues(AdaptiveCardMaxVersion)
 
@@ -1918,24 +3120,46 @@ func (a Action) Validate() error {
 	// Some Actions are restricted to later Adaptive Card schema versions.
 	v.InList(a.Type, "Type", "action", actionValues, ErrInvalidType)
 
-	switch {
-	case a.Type == TypeActionOpenURL:
-		v.NotEmptyValue(a.URL, "URL", a.Type, ErrMissingValue)
-
-	case a.Fallback != "":
+	if a.Fallback != "" {
 		v.InList(a.Fallback, "Fallback", "action", fallbackValues, ErrInvalidFieldValue)
+	}
 
-	case a.Type == TypeActionToggleVisibility:
-		v.NotEmptyCollection("TargetElements", a.Type, ErrMissingValue, a.TargetElements)
-
-	// Optional, but only supported by the Action.ShowCard type.
-	case a.Card != nil:
-		v.FieldHasSpecificValue(a.Type, "type", TypeActionShowCard, "type", ErrInvalidType)
+	v.InListIfFieldValNotEmpty(a.Style, "Style", a.Type, supportedActionStyleValues(), ErrInvalidFieldValue)
+	v.InListIfFieldValNotEmpty(a.Mode, "Mode", a.Type, supportedActionModeValues(), ErrInvalidFieldValue)
 
-		return fmt.Errorf(
-			"error: specifying a Card is unsupported for Action type %q: %w",
-			a.Type,
-			ErrInvalidFieldValue,
+	v.SuccessfulFuncCall(
+		func() error {
+			return assertActionDataFieldsValidValues(
+				a.Type, a.Verb, a.Data, a.AssociatedInputs,
+			)
+		},
+	)
+
+	switch {
+	case a.Type == TypeActionOpenURL:
+		v.NotEmptyValue(a.URL, "URL", a.Type, ErrMissingValue)
+
+	case a.Type == TypeActionToggleVisibility:
+		v.NotEmptyCollection("TargetElements", a.Type, ErrMissingValue, a.TargetElements)
+
+	// The Card field is required for the Action.ShowCard type. The nested
+	// Card (and any Actions it contains) is validated recursively.
+	case a.Type == TypeActionShowCard:
+		v.SuccessfulFuncCall(
+			func() error { return assertShowCardValidValues(a) },
+		)
+	}
+
+	// The Card field is only supported by the Action.ShowCard type.
+	if a.Type != TypeActionShowCard && a.Card != nil {
+		v.SuccessfulFuncCall(
+			func() error {
+				return fmt.Errorf(
+					"error: specifying a Card is unsupported for Action type %q: %w",
+					a.Type,
+					ErrInvalidFieldValue,
+				)
+			},
 		)
 	}
 
@@ -1976,7 +3200,7 @@ func (m Mention) Validate() error {
 		)
 	}
 
-	return nil
+	return m.Mentioned.Validate()
 }
 
 // Validate asserts that fields have valid values.
@@ -1995,6 +3219,15 @@ func (m Mentioned) Validate() error {
 		)
 	}
 
+	if m.Type != "" && !goteamsnotify.InList(m.Type, supportedMentionedTypeValues(), false) {
+		return fmt.Errorf(
+			"invalid Mentioned type %q; expected one of %v: %w",
+			m.Type,
+			supportedMentionedTypeValues(),
+			ErrInvalidType,
+		)
+	}
+
 	return nil
 }
 
@@ -2005,6 +3238,10 @@ func (m Mentioned) Validate() error {
 // the Mention and TextBlock element. If specified, the new TextBlock element
 // is added as the first element of the Card, otherwise it is added last. An
 // error is returned if insufficient values are provided.
+//
+// Only user mentions are supported. Use AddMention along with NewTagMention,
+// NewChannelMention or NewTeamMention to add tag, channel or team mentions
+// (or multiple mentions within the same TextBlock).
 func (m *Message) Mention(prependElement bool, displayName string, id string, msgText string) error {
 	// NOTE: Rely on called functions to validate given arguments.
 
@@ -2068,6 +3305,52 @@ func (m *Message) Mention(prependElement bool, displayName string, id string, ms
 	return nil
 }
 
+// AddMention adds one or more provided mentions (e.g., user, tag, channel or
+// team mentions) to the first Card in the Message along with a new TextBlock
+// element containing the Mention Text of each followed by the given message
+// text (if any).
+//
+// If no Cards are yet attached to the Message, a new Card is created. If
+// specified, the new TextBlock element is added as the first element of the
+// Card, otherwise it is added last. An error is returned if specified Mention
+// values fail validation.
+func (m *Message) AddMention(prependElement bool, msgText string, mentions ...Mention) error {
+	if len(m.Attachments) == 0 {
+		if err := m.Attach(NewCard()); err != nil {
+			return err
+		}
+	}
+
+	textBlock := NewTextBlock(msgText, true)
+
+	// NOTE: Rely on this function to validate the given mentions.
+	err := AddMention(&m.Attachments[0].Content.Card, &textBlock, true, defaultMentionTextSeparator, mentions...)
+	if err != nil {
+		return fmt.Errorf(
+			"add new Mention to Message: %w",
+			err,
+		)
+	}
+
+	// Trim the separator if no message text was given.
+	textBlock.Text = strings.TrimSpace(textBlock.Text)
+
+	switch {
+	case prependElement:
+		m.Attachments[0].Content.Body = append(
+			[]Element{textBlock},
+			m.Attachments[0].Content.Body...,
+		)
+	default:
+		m.Attachments[0].Content.Body = append(
+			m.Attachments[0].Content.Body,
+			textBlock,
+		)
+	}
+
+	return nil
+}
+
 // Mention uses the given display name, ID and message text to add a new user
 // Mention and TextBlock element to the Card. If specified, the new TextBlock
 // element is added as the first element of the Card, otherwise it is added
@@ -2107,9 +3390,10 @@ func (c *Card) Mention(displayName string, id string, msgText string, prependEle
 	return nil
 }
 
-// AddMention adds one or more provided user mentions to the associated Card
-// along with a new TextBlock element. The Text field for the new TextBlock
-// element is updated with the Mention Text.
+// AddMention adds one or more provided mentions (e.g., user, tag, channel or
+// team mentions) to the associated Card along with a new TextBlock element.
+// The Text field for the new TextBlock element is updated with the Mention
+// Text.
 //
 // If specified, the new TextBlock element is inserted as the first element in
 // the Card body. This effectively creates a dedicated TextBlock that acts as
@@ -2132,7 +3416,7 @@ func (c *Card) AddMention(prepend bool, mentions ...Mention) error {
 	// chosen doesn't really matter either as there isn't any existing text
 	// that we need to separate from the mention text.
 	//
-	// NOTE: WE rely on this function to apply validation of user mention
+	// NOTE: WE rely on this function to apply validation of mention
 	// values instead of duplicating that logic here.
 	err := AddMention(c, &textBlock, true, defaultMentionTextSeparator, mentions...)
 	if err != nil {
@@ -2297,12 +3581,132 @@ func (c *Card) SetFullWidth() {
 	c.MSTeams.Width = MSTeamsWidthFull
 }
 
+// SetRefresh sets the Refresh value for the Card, enabling automatic refresh
+// of the Card for the specified users. An error is returned if the given
+// Refresh value fails validation.
+//
+// NOTE: The Card Version is raised to UniversalActionsMinCardVersionRequired
+// if set to an earlier version.
+func (c *Card) SetRefresh(refresh Refresh) error {
+	if err := refresh.Validate(); err != nil {
+		return err
+	}
+
+	c.Refresh = &refresh
+	c.raiseVersion(UniversalActionsMinCardVersionRequired)
+
+	return nil
+}
+
+// SetAuthentication sets the Authentication value for the Card. An error is
+// returned if the given Authentication value fails validation.
+//
+// NOTE: The Card Version is raised to UniversalActionsMinCardVersionRequired
+// if set to an earlier version.
+func (c *Card) SetAuthentication(auth Authentication) error {
+	if err := auth.Validate(); err != nil {
+		return err
+	}
+
+	c.Authentication = &auth
+	c.raiseVersion(UniversalActionsMinCardVersionRequired)
+
+	return nil
+}
+
+// raiseVersion sets the Card Version to the given version if the current
+// Version is empty or set to an earlier version.
+func (c *Card) raiseVersion(version float64) {
+	current, err := strconv.ParseFloat(c.Version, 64)
+	if err != nil || current < version {
+		c.Version = fmt.Sprintf(AdaptiveCardVersionTmpl, version)
+	}
+}
+
+// NewRefresh creates a new Refresh value using the given verb, optional data
+// and user IDs. The card is automatically refreshed (via an Action.Execute
+// action using the given verb and data) when viewed by any of the listed
+// users. An error is returned if more than RefreshUserIDsLimit user IDs are
+// given or if other invalid values are supplied.
+func NewRefresh(verb string, data interface{}, userIDs ...string) (Refresh, error) {
+	action, err := NewActionExecute("", verb, data)
+	if err != nil {
+		return Refresh{}, err
+	}
+
+	refresh := Refresh{
+		Action:  action,
+		UserIDs: userIDs,
+	}
+
+	if err := refresh.Validate(); err != nil {
+		return Refresh{}, err
+	}
+
+	return refresh, nil
+}
+
+// NewAuthentication creates a new Authentication value using the given
+// prompt text, OAuth connection name and (optional) buttons. An error is
+// returned if invalid values are supplied.
+func NewAuthentication(text string, connectionName string, buttons ...AuthCardButton) (Authentication, error) {
+	auth := Authentication{
+		Text:           text,
+		ConnectionName: connectionName,
+		Buttons:        buttons,
+	}
+
+	if err := auth.Validate(); err != nil {
+		return Authentication{}, err
+	}
+
+	return auth, nil
+}
+
+// NewSignInButton creates a new AuthCardButton used to sign in using the
+// given title and sign in URL.
+func NewSignInButton(title string, signInURL string) AuthCardButton {
+	return AuthCardButton{
+		Type:  AuthCardButtonTypeSignIn,
+		Title: title,
+		Value: signInURL,
+	}
+}
+
 // NewMention uses the given display name and ID to create a user Mention
 // value for inclusion in a Card. An error is returned if provided values are
 // insufficient to create the user mention.
 func NewMention(displayName string, id string) (Mention, error) {
+	return newMention(displayName, id, "")
+}
+
+// NewTagMention uses the given tag name and tag ID to create a tag Mention
+// value for inclusion in a Card. Each member of the tag is notified. An error
+// is returned if provided values are insufficient to create the tag mention.
+func NewTagMention(tagName string, tagID string) (Mention, error) {
+	return newMention(tagName, tagID, MentionedTypeTag)
+}
+
+// NewChannelMention uses the given channel name and channel ID to create a
+// channel Mention value for inclusion in a Card. An error is returned if
+// provided values are insufficient to create the channel mention.
+func NewChannelMention(channelName string, channelID string) (Mention, error) {
+	return newMention(channelName, channelID, MentionedTypeChannel)
+}
+
+// NewTeamMention uses the given team name and team ID to create a team
+// Mention value for inclusion in a Card. All members of the team are
+// notified. An error is returned if provided values are insufficient to
+// create the team mention.
+func NewTeamMention(teamName string, teamID string) (Mention, error) {
+	return newMention(teamName, teamID, MentionedTypeTeam)
+}
+
+// newMention is a helper function used to create a Mention of the given
+// Mentioned type (empty for a user mention) using the given name and ID.
+func newMention(name string, id string, mentionedType string) (Mention, error) {
 	switch {
-	case displayName == "":
+	case name == "":
 		return Mention{}, fmt.Errorf(
 			"required name argument is empty: %w",
 			ErrMissingValue,
@@ -2319,18 +3723,24 @@ func NewMention(displayName string, id string) (Mention, error) {
 		// Build mention.
 		mention := Mention{
 			Type: TypeMention,
-			Text: fmt.Sprintf(MentionTextFormatTemplate, displayName),
+			Text: fmt.Sprintf(MentionTextFormatTemplate, name),
 			Mentioned: Mentioned{
 				ID:   id,
-				Name: displayName,
+				Name: name,
+				Type: mentionedType,
 			},
 		}
 
+		if err := mention.Validate(); err != nil {
+			return Mention{}, err
+		}
+
 		return mention, nil
 	}
 }
 
-// AddMention adds one or more provided user mentions to the specified Card.
+// AddMention adds one or more provided mentions (e.g., user, tag, channel or
+// team mentions) to the specified Card.
 // The Text field for the specified TextBlock element is updated with the
 // Mention Text. If specified, the Mention Text is prepended, otherwise
 // appended. If specified, a custom separator is used between the Mention Text
@@ -2374,7 +3784,7 @@ func AddMention(card *Card, textBlock *Element, prependText bool, separator stri
 		)
 	}
 
-	// Validate all user mentions before modifying Card or Element.
+	// Validate all mentions before modifying Card or Element.
 	for _, mention := range mentions {
 		if err := mention.Validate(); err != nil {
 			return err
@@ -2387,14 +3797,14 @@ func AddMention(card *Card, textBlock *Element, prependText bool, separator stri
 
 	mentionsText := make([]string, 0, len(mentions))
 
-	// Record user mentions in the Card and collect all required user mention
+	// Record mentions in the Card and collect all required mention
 	// text values.
 	for _, mention := range mentions {
 		mentionsText = append(mentionsText, mention.Text)
 		card.MSTeams.Entities = append(card.MSTeams.Entities, mention)
 	}
 
-	// Update TextBlock element text with required user mention text string.
+	// Update TextBlock element text with required mention text string.
 	switch prependText {
 	case true:
 		textBlock.Text = strings.Join(mentionsText, " ") + separator + textBlock.Text
@@ -2493,6 +3903,46 @@ func NewContainer() Container {
 	return container
 }
 
+// SetFallback sets the given Element as the fallback for this Element. The
+// fallback is rendered instead of this Element by clients which do not
+// support it (e.g., a FactSet in place of a Table for older clients). An
+// error is returned if the fallback Element fails validation.
+func (e *Element) SetFallback(fallback Element) error {
+	if err := assertNotInlineElement(fallback); err != nil {
+		return err
+	}
+
+	if err := fallback.Validate(); err != nil {
+		return fmt.Errorf("invalid fallback for %s: %w", e.Type, err)
+	}
+
+	e.Fallback = &fallback
+
+	return nil
+}
+
+// SetFallbackDrop indicates that this Element should be dropped by clients
+// which do not support it.
+func (e *Element) SetFallbackDrop() {
+	e.Fallback = TypeFallbackOptionDrop
+}
+
+// NewBackgroundImage creates a new BackgroundImage value using the given
+// image URL (or data URI) and fill mode. An error is returned if invalid
+// values are supplied.
+func NewBackgroundImage(imageURL string, fillMode string) (BackgroundImage, error) {
+	backgroundImage := BackgroundImage{
+		URL:      imageURL,
+		FillMode: fillMode,
+	}
+
+	if err := backgroundImage.Validate(); err != nil {
+		return BackgroundImage{}, err
+	}
+
+	return backgroundImage, nil
+}
+
 // NewHiddenContainer creates an empty Container whose initial state is
 // set as hidden from view.
 func NewHiddenContainer() Container {
@@ -2700,8 +4150,12 @@ func NewTableWithGridFromTableCells(cells []TableCell, perRow int) (Element, err
 	case len(cells) == 0:
 		return Element{}, fmt.Errorf("no data provided: %w", ErrMissingValue)
 
-	case perRow < 0:
-		return Element{}, fmt.Errorf("invalid per row value %d provided", perRow)
+	case perRow <= 0:
+		return Element{}, fmt.Errorf(
+			"invalid per row value %d provided; expected positive value: %w",
+			perRow,
+			ErrInvalidFieldValue,
+		)
 	}
 
 	if err := TableCells(cells).Validate(); err != nil {
@@ -2770,7 +4224,7 @@ func NewTableWithGridFromTableCells(cells []TableCell, perRow int) (Element, err
 	var cellCtr int
 	for i := 0; i < neededRows(); i++ {
 		tableCells := make([]TableCell, 0, perRow)
-		for j := 0; j < perRow; j++ {
+		for j := 0; j < perRow && cellCtr < len(cells); j++ {
 			cell := cells[cellCtr]
 			cellCtr++
 
@@ -2965,145 +4419,475 @@ func (e *Element) AddTableRow(rows ...TableRow) error {
 	return nil
 }
 
-// NewActionOpenURL creates a new Action.OpenURL value using the provided URL
-// and title. An error is returned if invalid values are supplied.
-func NewActionOpenURL(url string, title string) (Action, error) {
-	// Accept the user-specified values as-is, use Validate() method to do the
-	// heavy lifting.
-	action := Action{
-		Type:  TypeActionOpenURL,
-		Title: title,
-		URL:   url,
+// NewTextRun creates a new TextRun element using the given text. TextRun
+// elements may only be used within the Inlines collection of a RichTextBlock
+// element.
+//
+// See also NewRichText for a more convenient way to assemble a RichTextBlock
+// element from formatted TextRun elements.
+func NewTextRun(text string) Element {
+	return Element{
+		Type: TypeElementTextRun,
+		Text: text,
 	}
+}
 
-	err := action.Validate()
-	if err != nil {
-		return Action{}, err
+// NewRichTextBlock creates a new RichTextBlock element using the given
+// TextRun elements. An error is returned if the given elements fail
+// validation.
+func NewRichTextBlock(inlines ...Element) (Element, error) {
+	richTextBlock := Element{
+		Type:    TypeElementRichTextBlock,
+		Inlines: inlines,
 	}
 
-	return action, nil
+	if err := richTextBlock.Validate(); err != nil {
+		return Element{}, err
+	}
+
+	return richTextBlock, nil
 }
 
-// NewActionToggleVisibility creates a new Action.ToggleVisibility value using
-// the (optionally) provided title text.
+// NewMedia creates a new Media element using the given alternate text and
+// media sources. An error is returned if no sources are given or if invalid
+// values are supplied.
 //
-// NOTE: The caller is responsible for adding required TargetElement values to
-// meet validation requirements.
-func NewActionToggleVisibility(title string) Action {
-	return Action{
-		Type:  TypeActionToggleVisibility,
-		Title: title,
+// NOTE: Media element support varies by Microsoft Teams client. See
+// MediaClientSupportWarning and Element.Warnings for details.
+func NewMedia(altText string, sources ...MediaSource) (Element, error) {
+	media := Element{
+		Type:    TypeElementMedia,
+		AltText: altText,
+		Sources: sources,
 	}
-}
 
-// NewActionSetsFromActions creates a new ActionSet for every
-// TeamsActionsDisplayLimit count of Actions given. An error is returned if
-// the specified Actions do not pass validation.
-func NewActionSetsFromActions(actions ...Action) ([]Element, error) {
-	if len(actions) == 0 {
-		return nil, fmt.Errorf(
-			"received empty collection of actions to create ActionSet: %w",
-			ErrMissingValue,
-		)
+	if err := media.Validate(); err != nil {
+		return Element{}, err
 	}
 
-	for _, action := range actions {
-		if err := action.Validate(); err != nil {
-			return nil, err
-		}
+	return media, nil
+}
+
+// NewMediaSource creates a new MediaSource for use in a Media element using
+// the given MIME type (e.g., "video/mp4") and URL.
+func NewMediaSource(mimeType string, mediaURL string) MediaSource {
+	return MediaSource{
+		MIMEType: mimeType,
+		URL:      mediaURL,
 	}
+}
 
-	// Create a new ActionSet for every TeamsActionsDisplayLimit count of
-	// Actions given.
-	actionSetsNeeded := int(math.Ceil(float64(len(actions)) / float64(TeamsActionsDisplayLimit)))
-	actionSets := make([]Element, 0, actionSetsNeeded)
+// NewImage creates a new Image element using the given URL and alternate
+// text. The URL may reference a remote image or embed image data directly
+// (see NewImageDataURI). An error is returned if invalid values are supplied.
+func NewImage(imageURL string, altText string) (Element, error) {
+	image := Element{
+		Type:    TypeElementImage,
+		URL:     imageURL,
+		AltText: altText,
+	}
 
-	stride := TeamsActionsDisplayLimit
-	for i := 0; i < len(actions); i += stride {
-		// Ensure that we don't stride past the end of the actions slice.
-		if stride > len(actions)-i {
-			stride = len(actions) - i
-		}
+	if err := image.Validate(); err != nil {
+		return Element{}, err
+	}
 
-		actionSetItems := actions[i : i+stride]
-		actionSet := Element{
-			Type:    TypeElementActionSet,
-			Actions: actionSetItems,
-		}
+	return image, nil
+}
 
-		actionSets = append(actionSets, actionSet)
+// NewImageFromData creates a new Image element which embeds the given image
+// data using a base64 encoded data URI. This is useful for displaying images
+// such as graph snapshots which are not reachable by the Microsoft Teams
+// client. An error is returned if invalid values are supplied.
+//
+// NOTE: Embedded image data counts towards the webhook payload size limit.
+func NewImageFromData(mediaType string, data []byte, altText string) (Element, error) {
+	if len(data) == 0 {
+		return Element{}, fmt.Errorf(
+			"no image data provided: %w",
+			ErrMissingValue,
+		)
 	}
 
-	return actionSets, nil
+	return NewImage(NewImageDataURI(mediaType, data), altText)
 }
 
-// AddElement adds the given Element to the collection of Element values in
-// the container. If specified, the Element is inserted at the beginning of
-// the collection, otherwise appended to the end.
-func (c *Container) AddElement(prepend bool, element Element) error {
-	if err := element.Validate(); err != nil {
-		return err
+// NewImageDataURI returns a base64 encoded data URI for the given image media
+// type (e.g., "image/png") and data suitable for use as an Image element URL.
+func NewImageDataURI(mediaType string, data []byte) string {
+	return ImageDataURIPrefix + mediaType + ";base64," +
+		base64.StdEncoding.EncodeToString(data)
+}
+
+// NewImageSet creates a new ImageSet element using the given (optional) image
+// size and Image elements. An error is returned if invalid values are
+// supplied.
+func NewImageSet(imageSize string, images ...Element) (Element, error) {
+	imageSet := Element{
+		Type:      TypeElementImageSet,
+		ImageSize: imageSize,
+		Images:    images,
 	}
 
-	switch prepend {
-	case true:
-		c.Items = append([]Element{element}, c.Items...)
-	case false:
-		c.Items = append(c.Items, element)
+	if err := imageSet.Validate(); err != nil {
+		return Element{}, err
 	}
 
-	return nil
+	return imageSet, nil
 }
 
-// AddAction adds one or more provided Action values to the associated
-// Container as one or more new ActionSets. The number of actions in each
-// newly created ActionSet is limited to the number specified by
-// TeamsActionsDisplayLimit.
-//
-// If specified, the newly created ActionSets are inserted before other
-// Elements in the Container, otherwise appended.
-//
-// If adding an action to be used when the Container is tapped or selected use
-// AddSelectAction() instead.
-//
-// An error is returned if specified Action values fail validation.
-func (c *Container) AddAction(prepend bool, actions ...Action) error {
-	// Rely on function to apply validation instead of duplicating it here.
-	actionSets, err := NewActionSetsFromActions(actions...)
-	if err != nil {
-		return err
+// AddImage adds one or many Image elements to an ImageSet element. An error
+// is returned if an Image element fails validation or if AddImage is called
+// on any Element type other than an ImageSet.
+func (e *Element) AddImage(images ...Element) error {
+	if e.Type != TypeElementImageSet {
+		return fmt.Errorf(
+			"unsupported element type %s; expected %s: %w",
+			e.Type,
+			TypeElementImageSet,
+			ErrInvalidType,
+		)
 	}
 
-	switch prepend {
-	case true:
-		c.Items = append(actionSets, c.Items...)
-	case false:
-		c.Items = append(c.Items, actionSets...)
+	if len(images) == 0 {
+		return fmt.Errorf("no data provided: %w", ErrMissingValue)
+	}
+
+	for _, image := range images {
+		if image.Type != TypeElementImage {
+			return fmt.Errorf(
+				"unsupported element type %s; expected %s: %w",
+				image.Type,
+				TypeElementImage,
+				ErrInvalidType,
+			)
+		}
+
+		if err := image.Validate(); err != nil {
+			return err
+		}
 	}
 
+	e.Images = append(e.Images, images...)
+
 	return nil
 }
 
-// AddSelectAction adds a given Action or ISelectAction value to the
-// associated Container. This action will be invoked when the Container is
-// tapped or selected.
-//
-// An error is returned if the given Action or ISelectAction value fails
-// validation or if a value other than an Action or ISelectAction is provided.
-func (c *Container) AddSelectAction(action interface{}) error {
-	switch v := action.(type) {
-	case Action:
-		// Perform manual conversion to the supported type.
-		selectAction := ISelectAction{
-			Type:     v.Type,
-			ID:       v.ID,
-			Title:    v.Title,
-			URL:      v.URL,
-			Fallback: v.Fallback,
-		}
+// NewInputText creates a new Input.Text element using the given ID and
+// optional label. An error is returned if an empty ID is given.
+func NewInputText(id string, label string) (Element, error) {
+	return newInputElement(TypeElementInputText, id, label)
+}
 
-		// Don't touch the new TargetElements field unless the provided Action
-		// has specified values.
+// NewInputNumber creates a new Input.Number element using the given ID and
+// optional label. An error is returned if an empty ID is given.
+func NewInputNumber(id string, label string) (Element, error) {
+	return newInputElement(TypeElementInputNumber, id, label)
+}
+
+// NewInputDate creates a new Input.Date element using the given ID and
+// optional label. An error is returned if an empty ID is given.
+func NewInputDate(id string, label string) (Element, error) {
+	return newInputElement(TypeElementInputDate, id, label)
+}
+
+// NewInputTime creates a new Input.Time element using the given ID and
+// optional label. An error is returned if an empty ID is given.
+func NewInputTime(id string, label string) (Element, error) {
+	return newInputElement(TypeElementInputTime, id, label)
+}
+
+// NewInputToggle creates a new Input.Toggle element using the given ID and
+// title. The title is displayed next to the toggle. An error is returned if
+// an empty ID or title is given.
+func NewInputToggle(id string, title string) (Element, error) {
+	toggle := Element{
+		Type:  TypeElementInputToggle,
+		ID:    id,
+		Title: title,
+	}
+
+	if err := toggle.Validate(); err != nil {
+		return Element{}, err
+	}
+
+	return toggle, nil
+}
+
+// NewInputChoiceSet creates a new Input.ChoiceSet element using the given
+// ID, optional label and choices. An error is returned if an empty ID is
+// given or if the given choices fail validation.
+func NewInputChoiceSet(id string, label string, choices ...Choice) (Element, error) {
+	choiceSet := Element{
+		Type:    TypeElementInputChoiceSet,
+		ID:      id,
+		Label:   label,
+		Choices: choices,
+	}
+
+	if err := choiceSet.Validate(); err != nil {
+		return Element{}, err
+	}
+
+	return choiceSet, nil
+}
+
+// NewChoice creates a new Choice for use in an Input.ChoiceSet element using
+// the given title and value.
+func NewChoice(title string, value string) Choice {
+	return Choice{
+		Title: title,
+		Value: value,
+	}
+}
+
+// newInputElement is a helper function used to create a new Input element
+// of the given type.
+func newInputElement(inputType string, id string, label string) (Element, error) {
+	input := Element{
+		Type:  inputType,
+		ID:    id,
+		Label: label,
+	}
+
+	if err := input.Validate(); err != nil {
+		return Element{}, err
+	}
+
+	return input, nil
+}
+
+// AddChoice adds one or many Choice values to an Input.ChoiceSet element. An
+// error is returned if a Choice value fails validation or if AddChoice is
+// called on any Element type other than an Input.ChoiceSet.
+func (e *Element) AddChoice(choices ...Choice) error {
+	if e.Type != TypeElementInputChoiceSet {
+		return fmt.Errorf(
+			"unsupported element type %s; expected %s: %w",
+			e.Type,
+			TypeElementInputChoiceSet,
+			ErrInvalidType,
+		)
+	}
+
+	if len(choices) == 0 {
+		return fmt.Errorf("no data provided: %w", ErrMissingValue)
+	}
+
+	if err := Choices(choices).Validate(); err != nil {
+		return err
+	}
+
+	e.Choices = append(e.Choices, choices...)
+
+	return nil
+}
+
+// NewActionOpenURL creates a new Action.OpenURL value using the provided URL
+// and title. An error is returned if invalid values are supplied.
+func NewActionOpenURL(url string, title string) (Action, error) {
+	// Accept the user-specified values as-is, use Validate() method to do the
+	// heavy lifting.
+	action := Action{
+		Type:  TypeActionOpenURL,
+		Title: title,
+		URL:   url,
+	}
+
+	err := action.Validate()
+	if err != nil {
+		return Action{}, err
+	}
+
+	return action, nil
+}
+
+// NewActionExecute creates a new Action.Execute value using the provided
+// title, verb and (optional) data. Input values on the card are combined with
+// the given data when the action is invoked. The data value must encode to
+// either a JSON object (e.g., a struct or a map[string]interface{}) or a JSON
+// string. An error is returned if invalid values are supplied.
+//
+// NOTE: Action.Execute requires a Card Version of at least
+// ActionExecuteMinCardVersionRequired.
+func NewActionExecute(title string, verb string, data interface{}) (Action, error) {
+	action := Action{
+		Type:  TypeActionExecute,
+		Title: title,
+		Verb:  verb,
+		Data:  data,
+	}
+
+	if err := action.Validate(); err != nil {
+		return Action{}, err
+	}
+
+	return action, nil
+}
+
+// NewActionSubmit creates a new Action.Submit value using the provided title
+// and (optional) data. Input values on the card are combined with the given
+// data when the action is invoked. The data value must encode to either a
+// JSON object (e.g., a struct or a map[string]interface{}) or a JSON string.
+// An error is returned if invalid values are supplied.
+//
+// NOTE: Action.Submit is not supported for Adaptive Cards sent via Incoming
+// Webhooks; use NewActionExecute instead where possible.
+func NewActionSubmit(title string, data interface{}) (Action, error) {
+	action := Action{
+		Type:  TypeActionSubmit,
+		Title: title,
+		Data:  data,
+	}
+
+	if err := action.Validate(); err != nil {
+		return Action{}, err
+	}
+
+	return action, nil
+}
+
+// NewActionShowCard creates a new Action.ShowCard value using the provided
+// title and Card. The Card is shown to the user when the button or link is
+// clicked (e.g., a "Show details" drawer). An error is returned if the Card
+// or Action fails validation.
+func NewActionShowCard(title string, card Card) (Action, error) {
+	action := Action{
+		Type:  TypeActionShowCard,
+		Title: title,
+		Card:  &card,
+	}
+
+	if err := action.Validate(); err != nil {
+		return Action{}, err
+	}
+
+	return action, nil
+}
+
+// NewActionToggleVisibility creates a new Action.ToggleVisibility value using
+// the (optionally) provided title text.
+//
+// NOTE: The caller is responsible for adding required TargetElement values to
+// meet validation requirements.
+func NewActionToggleVisibility(title string) Action {
+	return Action{
+		Type:  TypeActionToggleVisibility,
+		Title: title,
+	}
+}
+
+// NewActionSetsFromActions creates a new ActionSet for every
+// TeamsActionsDisplayLimit count of Actions given. An error is returned if
+// the specified Actions do not pass validation.
+func NewActionSetsFromActions(actions ...Action) ([]Element, error) {
+	if len(actions) == 0 {
+		return nil, fmt.Errorf(
+			"received empty collection of actions to create ActionSet: %w",
+			ErrMissingValue,
+		)
+	}
+
+	for _, action := range actions {
+		if err := action.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	// Create a new ActionSet for every TeamsActionsDisplayLimit count of
+	// Actions given.
+	actionSetsNeeded := int(math.Ceil(float64(len(actions)) / float64(TeamsActionsDisplayLimit)))
+	actionSets := make([]Element, 0, actionSetsNeeded)
+
+	stride := TeamsActionsDisplayLimit
+	for i := 0; i < len(actions); i += stride {
+		// Ensure that we don't stride past the end of the actions slice.
+		if stride > len(actions)-i {
+			stride = len(actions) - i
+		}
+
+		actionSetItems := actions[i : i+stride]
+		actionSet := Element{
+			Type:    TypeElementActionSet,
+			Actions: actionSetItems,
+		}
+
+		actionSets = append(actionSets, actionSet)
+	}
+
+	return actionSets, nil
+}
+
+// AddElement adds the given Element to the collection of Element values in
+// the container. If specified, the Element is inserted at the beginning of
+// the collection, otherwise appended to the end.
+func (c *Container) AddElement(prepend bool, element Element) error {
+	if err := element.Validate(); err != nil {
+		return err
+	}
+
+	switch prepend {
+	case true:
+		c.Items = append([]Element{element}, c.Items...)
+	case false:
+		c.Items = append(c.Items, element)
+	}
+
+	return nil
+}
+
+// AddAction adds one or more provided Action values to the associated
+// Container as one or more new ActionSets. The number of actions in each
+// newly created ActionSet is limited to the number specified by
+// TeamsActionsDisplayLimit.
+//
+// If specified, the newly created ActionSets are inserted before other
+// Elements in the Container, otherwise appended.
+//
+// If adding an action to be used when the Container is tapped or selected use
+// AddSelectAction() instead.
+//
+// An error is returned if specified Action values fail validation.
+func (c *Container) AddAction(prepend bool, actions ...Action) error {
+	// Rely on function to apply validation instead of duplicating it here.
+	actionSets, err := NewActionSetsFromActions(actions...)
+	if err != nil {
+		return err
+	}
+
+	switch prepend {
+	case true:
+		c.Items = append(actionSets, c.Items...)
+	case false:
+		c.Items = append(c.Items, actionSets...)
+	}
+
+	return nil
+}
+
+// AddSelectAction adds a given Action or ISelectAction value to the
+// associated Container. This action will be invoked when the Container is
+// tapped or selected.
+//
+// An error is returned if the given Action or ISelectAction value fails
+// validation or if a value other than an Action or ISelectAction is provided.
+func (c *Container) AddSelectAction(action interface{}) error {
+	switch v := action.(type) {
+	case Action:
+		// Perform manual conversion to the supported type.
+		selectAction := ISelectAction{
+			Type:             v.Type,
+			ID:               v.ID,
+			Title:            v.Title,
+			URL:              v.URL,
+			Verb:             v.Verb,
+			Data:             v.Data,
+			AssociatedInputs: v.AssociatedInputs,
+			Tooltip:          v.Tooltip,
+			Fallback:         v.Fallback,
+		}
+
+		// Don't touch the new TargetElements field unless the provided Action
+		// has specified values.
 		if len(v.TargetElements) > 0 {
 			selectAction.TargetElements = append(
 				selectAction.TargetElements,
@@ -3243,6 +5027,17 @@ func assertColumnWidthValidValues(c Column) error {
 	// Number representing relative width of the column.
 	case int:
 
+	// Numbers decoded from JSON (e.g., via ParseCard) are float64 values;
+	// only whole numbers are valid.
+	case float64:
+		if v != math.Trunc(v) {
+			return fmt.Errorf(
+				"invalid relative width %v; expected whole number: %w",
+				v,
+				ErrInvalidFieldValue,
+			)
+		}
+
 	// Unsupported value.
 	default:
 		return fmt.Errorf(
@@ -3277,6 +5072,17 @@ func assertTableColumnDefinitionWidthValidValues(tcd TableColumnDefinition) erro
 	// Number representing relative width of the column.
 	case int:
 
+	// Numbers decoded from JSON (e.g., via ParseCard) are float64 values;
+	// only whole numbers are valid.
+	case float64:
+		if v != math.Trunc(v) {
+			return fmt.Errorf(
+				"invalid relative width %v; expected whole number: %w",
+				v,
+				ErrInvalidFieldValue,
+			)
+		}
+
 	// Unsupported value.
 	default:
 		return fmt.Errorf(
@@ -3367,3 +5173,930 @@ func assertValidVersionFieldValue(val string) error {
 
 	return nil
 }
+
+// isInputElementType indicates whether the given element type is one of the
+// Input element types.
+func isInputElementType(elementType string) bool {
+	switch elementType {
+	case TypeElementInputText,
+		TypeElementInputNumber,
+		TypeElementInputDate,
+		TypeElementInputTime,
+		TypeElementInputToggle,
+		TypeElementInputChoiceSet:
+		return true
+	default:
+		return false
+	}
+}
+
+// assertInputElementValidValues asserts that the fields used by Input
+// element types have valid values for the specific Input element type.
+func assertInputElementValidValues(e Element) error {
+	v := validator.Validator{}
+
+	// Input values are collected using the ID as the key.
+	v.NotEmptyValue(e.ID, "ID", e.Type, ErrMissingValue)
+
+	// A label is required to describe required inputs.
+	if e.IsRequired {
+		v.NotEmptyValue(e.Label, "Label", e.Type, ErrMissingValue)
+	}
+
+	switch e.Type {
+	case TypeElementInputText:
+		v.SuccessfulFuncCall(func() error { return assertInputTextValidValues(e) })
+
+	case TypeElementInputNumber:
+		v.SuccessfulFuncCall(func() error { return assertInputNumberValidValues(e) })
+
+	case TypeElementInputDate:
+		v.SuccessfulFuncCall(func() error { return assertInputTimeValidValues(e, InputDateFormat) })
+
+	case TypeElementInputTime:
+		v.SuccessfulFuncCall(func() error { return assertInputTimeValidValues(e, InputTimeFormat) })
+
+	case TypeElementInputToggle:
+		v.NotEmptyValue(e.Title, "Title", e.Type, ErrMissingValue)
+		v.SuccessfulFuncCall(func() error { return assertInputToggleValidValues(e) })
+
+	case TypeElementInputChoiceSet:
+		v.SelfValidate(Choices(e.Choices))
+		v.SuccessfulFuncCall(func() error { return assertInputChoiceSetValidValues(e) })
+	}
+
+	return v.Err()
+}
+
+// assertInputTextValidValues asserts that an Input.Text element has valid
+// field values.
+func assertInputTextValidValues(e Element) error {
+	if _, err := inputStringValue(e, "Value", e.Value); err != nil {
+		return err
+	}
+
+	if e.MaxLength < 0 {
+		return fmt.Errorf(
+			"invalid MaxLength %d for %s; expected positive value: %w",
+			e.MaxLength,
+			e.Type,
+			ErrInvalidFieldValue,
+		)
+	}
+
+	if e.Regex != "" {
+		if _, err := regexp.Compile(e.Regex); err != nil {
+			return fmt.Errorf(
+				"invalid Regex %q for %s: %v: %w",
+				e.Regex,
+				e.Type,
+				err,
+				ErrInvalidFieldValue,
+			)
+		}
+	}
+
+	return nil
+}
+
+// assertInputNumberValidValues asserts that the Value, Min and Max fields of
+// an Input.Number element are numbers and that Value falls within the range
+// given by Min and Max.
+func assertInputNumberValidValues(e Element) error {
+	fields := []struct {
+		name string
+		val  interface{}
+	}{
+		{name: "Value", val: e.Value},
+		{name: "Min", val: e.Min},
+		{name: "Max", val: e.Max},
+	}
+
+	nums := make(map[string]float64, len(fields))
+	for _, field := range fields {
+		if field.val == nil {
+			continue
+		}
+
+		num, ok := toFloat64(field.val)
+		if !ok {
+			return fmt.Errorf(
+				"invalid %s %v for %s; expected number: %w",
+				field.name,
+				field.val,
+				e.Type,
+				ErrInvalidFieldValue,
+			)
+		}
+		nums[field.name] = num
+	}
+
+	min, hasMin := nums["Min"]
+	max, hasMax := nums["Max"]
+	val, hasVal := nums["Value"]
+
+	switch {
+	case hasMin && hasMax && min > max:
+		return fmt.Errorf(
+			"invalid Min %v for %s; greater than Max %v: %w",
+			min, e.Type, max, ErrInvalidFieldValue,
+		)
+
+	case hasVal && hasMin && val < min:
+		return fmt.Errorf(
+			"invalid Value %v for %s; less than Min %v: %w",
+			val, e.Type, min, ErrInvalidFieldValue,
+		)
+
+	case hasVal && hasMax && val > max:
+		return fmt.Errorf(
+			"invalid Value %v for %s; greater than Max %v: %w",
+			val, e.Type, max, ErrInvalidFieldValue,
+		)
+	}
+
+	return nil
+}
+
+// assertInputTimeValidValues asserts that the Value, Min and Max fields of
+// an Input.Date or Input.Time element are strings in the given layout and
+// that Value falls within the range given by Min and Max.
+func assertInputTimeValidValues(e Element, layout string) error {
+	fields := []struct {
+		name string
+		val  interface{}
+	}{
+		{name: "Value", val: e.Value},
+		{name: "Min", val: e.Min},
+		{name: "Max", val: e.Max},
+	}
+
+	times := make(map[string]time.Time, len(fields))
+	for _, field := range fields {
+		str, err := inputStringValue(e, field.name, field.val)
+		if err != nil {
+			return err
+		}
+
+		if str == "" {
+			continue
+		}
+
+		t, err := time.Parse(layout, str)
+		if err != nil {
+			return fmt.Errorf(
+				"invalid %s %q for %s; expected value in format %s: %w",
+				field.name,
+				str,
+				e.Type,
+				layout,
+				ErrInvalidFieldValue,
+			)
+		}
+		times[field.name] = t
+	}
+
+	min, hasMin := times["Min"]
+	max, hasMax := times["Max"]
+	val, hasVal := times["Value"]
+
+	switch {
+	case hasMin && hasMax && min.After(max):
+		return fmt.Errorf(
+			"invalid Min %v for %s; after Max %v: %w",
+			e.Min, e.Type, e.Max, ErrInvalidFieldValue,
+		)
+
+	case hasVal && hasMin && val.Before(min):
+		return fmt.Errorf(
+			"invalid Value %v for %s; before Min %v: %w",
+			e.Value, e.Type, e.Min, ErrInvalidFieldValue,
+		)
+
+	case hasVal && hasMax && val.After(max):
+		return fmt.Errorf(
+			"invalid Value %v for %s; after Max %v: %w",
+			e.Value, e.Type, e.Max, ErrInvalidFieldValue,
+		)
+	}
+
+	return nil
+}
+
+// assertInputToggleValidValues asserts that the Value field of an
+// Input.Toggle element (if set) matches either the on or off value.
+func assertInputToggleValidValues(e Element) error {
+	val, err := inputStringValue(e, "Value", e.Value)
+	if err != nil || val == "" {
+		return err
+	}
+
+	valueOn := InputToggleValueOn
+	if e.ValueOn != "" {
+		valueOn = e.ValueOn
+	}
+
+	valueOff := InputToggleValueOff
+	if e.ValueOff != "" {
+		valueOff = e.ValueOff
+	}
+
+	if val != valueOn && val != valueOff {
+		return fmt.Errorf(
+			"invalid Value %q for %s; expected %q or %q: %w",
+			val,
+			e.Type,
+			valueOn,
+			valueOff,
+			ErrInvalidFieldValue,
+		)
+	}
+
+	return nil
+}
+
+// assertInputChoiceSetValidValues asserts that the Value field of an
+// Input.ChoiceSet element (if set) references valid choices.
+func assertInputChoiceSetValidValues(e Element) error {
+	val, err := inputStringValue(e, "Value", e.Value)
+	if err != nil || val == "" {
+		return err
+	}
+
+	selected := strings.Split(val, InputChoiceSetValueSeparator)
+	if len(selected) > 1 && !e.IsMultiSelect {
+		return fmt.Errorf(
+			"invalid Value %q for %s; multiple values given but IsMultiSelect is not set: %w",
+			val,
+			e.Type,
+			ErrInvalidFieldValue,
+		)
+	}
+
+	// Choices may be omitted (e.g., when choices are loaded dynamically), in
+	// which case we cannot assert that selected values are valid.
+	if len(e.Choices) == 0 {
+		return nil
+	}
+
+	choiceValues := make([]string, 0, len(e.Choices))
+	for _, choice := range e.Choices {
+		choiceValues = append(choiceValues, choice.Value)
+	}
+
+	for _, s := range selected {
+		if !goteamsnotify.InList(strings.TrimSpace(s), choiceValues, false) {
+			return fmt.Errorf(
+				"invalid Value %q for %s; not one of the Choices values %q: %w",
+				s,
+				e.Type,
+				strings.Join(choiceValues, ","),
+				ErrInvalidFieldValue,
+			)
+		}
+	}
+
+	return nil
+}
+
+// inputStringValue asserts that the given Input element field value is
+// either unset or a string and returns the string value.
+func inputStringValue(e Element, fieldName string, val interface{}) (string, error) {
+	switch v := val.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	default:
+		return "", fmt.Errorf(
+			"invalid %s %v for %s; expected string: %w",
+			fieldName,
+			val,
+			e.Type,
+			ErrInvalidFieldValue,
+		)
+	}
+}
+
+// toFloat64 converts the given numeric value to a float64. A false value is
+// returned if the given value is not a supported numeric type.
+func toFloat64(val interface{}) (float64, bool) {
+	switch v := val.(type) {
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// assertImageValidValues asserts that the URL, Width, Height and
+// BackgroundColor fields of an Image element have valid values.
+func assertImageValidValues(e Element) error {
+	if err := assertImageURLValidValue(e.URL); err != nil {
+		return err
+	}
+
+	if err := assertValidPixelSizeOrEmptyValue(e.Width); err != nil {
+		return fmt.Errorf("invalid Width for %s: %w", e.Type, err)
+	}
+
+	switch e.Height {
+	case "", ImageHeightAuto, ImageHeightStretch:
+	default:
+		if err := assertValidPixelSizeOrEmptyValue(e.Height); err != nil {
+			return fmt.Errorf(
+				"invalid Height for %s; expected %q, %q or pixel size: %w",
+				e.Type,
+				ImageHeightAuto,
+				ImageHeightStretch,
+				err,
+			)
+		}
+	}
+
+	if e.BackgroundColor != "" {
+		matched, _ := regexp.MatchString(ImageBackgroundColorRegex, e.BackgroundColor)
+		if !matched {
+			return fmt.Errorf(
+				"invalid BackgroundColor %q for %s; expected hex color value (e.g., #DDDDDD): %w",
+				e.BackgroundColor,
+				e.Type,
+				ErrInvalidFieldValue,
+			)
+		}
+	}
+
+	return nil
+}
+
+// assertImageURLValidValue asserts that the given (non-empty) Image URL is
+// either a http(s) URL or a base64 encoded image data URI.
+func assertImageURLValidValue(imageURL string) error {
+	if imageURL == "" {
+		return nil
+	}
+
+	if strings.HasPrefix(imageURL, ImageDataURIPrefix) {
+		matched, _ := regexp.MatchString(ImageDataURIRegex, imageURL)
+		if !matched {
+			return fmt.Errorf(
+				"invalid image data URI; expected format data:image/<type>;base64,<data>: %w",
+				ErrInvalidFieldValue,
+			)
+		}
+
+		return nil
+	}
+
+	u, err := url.Parse(imageURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf(
+			"invalid image URL %q; expected http(s) URL or data URI: %w",
+			imageURL,
+			ErrInvalidFieldValue,
+		)
+	}
+
+	return nil
+}
+
+// assertImageSetValidValues asserts that the Images collection of an ImageSet
+// element contains only valid Image elements.
+func assertImageSetValidValues(e Element) error {
+	for _, image := range e.Images {
+		if image.Type != TypeElementImage {
+			return fmt.Errorf(
+				"invalid element type %q in Images collection for %s; expected %s: %w",
+				image.Type,
+				e.Type,
+				TypeElementImage,
+				ErrInvalidType,
+			)
+		}
+
+		if err := image.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// assertRichTextBlockValidValues asserts that the Inlines collection of a
+// RichTextBlock element contains only valid TextRun elements.
+func assertRichTextBlockValidValues(e Element) error {
+	for _, inline := range e.Inlines {
+		if inline.Type != TypeElementTextRun {
+			return fmt.Errorf(
+				"invalid element type %q in Inlines collection for %s; expected %s: %w",
+				inline.Type,
+				e.Type,
+				TypeElementTextRun,
+				ErrInvalidType,
+			)
+		}
+
+		if err := inline.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// assertNotInlineElement asserts that the given element is not an inline
+// element type. Inline elements (TextRun) are only permitted within the
+// Inlines collection of a RichTextBlock element.
+func assertNotInlineElement(e Element) error {
+	if e.Type == TypeElementTextRun {
+		return fmt.Errorf(
+			"element type %s is only supported within the Inlines collection of a %s: %w",
+			e.Type,
+			TypeElementRichTextBlock,
+			ErrInvalidType,
+		)
+	}
+
+	return nil
+}
+
+// assertShowCardValidValues asserts that an Action.ShowCard action has a
+// valid nested Card and that the nesting depth of Action.ShowCard actions
+// does not exceed ActionShowCardMaxNestingDepth.
+func assertShowCardValidValues(a Action) error {
+	if a.Card == nil {
+		return fmt.Errorf(
+			"required field Card is empty for %s: %w",
+			a.Type,
+			ErrMissingValue,
+		)
+	}
+
+	if depth := showCardNestingDepth(a, ActionShowCardMaxNestingDepth+1); depth > ActionShowCardMaxNestingDepth {
+		return fmt.Errorf(
+			"%s nesting depth exceeds limit of %d: %w",
+			a.Type,
+			ActionShowCardMaxNestingDepth,
+			ErrInvalidFieldValue,
+		)
+	}
+
+	if err := a.Card.Validate(); err != nil {
+		return fmt.Errorf(
+			"invalid Card for %s %q: %w",
+			a.Type,
+			a.Title,
+			err,
+		)
+	}
+
+	return nil
+}
+
+// showCardNestingDepth returns the nesting depth of Action.ShowCard actions
+// starting with (and including) the given action. Evaluation stops once the
+// given limit is reached; this also guards against Card values which
+// (directly or indirectly) reference themselves.
+func showCardNestingDepth(a Action, limit int) int {
+	if a.Type != TypeActionShowCard || a.Card == nil {
+		return 0
+	}
+
+	if limit <= 1 {
+		return 1
+	}
+
+	var maxNested int
+	for _, nested := range cardActions(*a.Card) {
+		if depth := showCardNestingDepth(nested, limit-1); depth > maxNested {
+			maxNested = depth
+		}
+	}
+
+	return 1 + maxNested
+}
+
+// cardActions returns all Actions directly associated with the given Card,
+// including those within ActionSet elements found anywhere in the Card body.
+// Actions within nested Action.ShowCard Cards are not included.
+func cardActions(c Card) []Action {
+	actions := make([]Action, 0, len(c.Actions))
+	actions = append(actions, c.Actions...)
+
+	return append(actions, elementsActions(c.Body)...)
+}
+
+// elementsActions returns all Actions within ActionSet elements found in the
+// given elements or any of their child elements.
+func elementsActions(elements []Element) []Action {
+	var actions []Action
+
+	for _, element := range elements {
+		actions = append(actions, element.Actions...)
+		actions = append(actions, elementsActions(element.Items)...)
+
+		for _, column := range element.Columns {
+			actions = append(actions, elementPointersActions(column.Items)...)
+		}
+
+		for _, row := range element.Rows {
+			for _, cell := range row.Cells {
+				actions = append(actions, elementPointersActions(cell.Items)...)
+			}
+		}
+	}
+
+	return actions
+}
+
+// elementPointersActions is a helper function for elementsActions which
+// accepts a collection of Element pointers.
+func elementPointersActions(elements []*Element) []Action {
+	var actions []Action
+
+	for _, element := range elements {
+		if element != nil {
+			actions = append(actions, elementsActions([]Element{*element})...)
+		}
+	}
+
+	return actions
+}
+
+// assertActionDataFieldsValidValues asserts that the Verb, Data and
+// AssociatedInputs fields (if set) are used only with supporting action types
+// and that the Data field encodes to a JSON object or string.
+func assertActionDataFieldsValidValues(actionType string, verb string, data interface{}, associatedInputs string) error {
+	isExecute := actionType == TypeActionExecute
+	isSubmit := actionType == TypeActionSubmit
+
+	// A typed nil value (e.g., a nil map or pointer) is treated the same as
+	// an unset Data field.
+	if isNilValue(data) {
+		data = nil
+	}
+
+	switch {
+	case verb != "" && !isExecute:
+		return fmt.Errorf(
+			"error: specifying a Verb is unsupported for Action type %q: %w",
+			actionType,
+			ErrInvalidFieldValue,
+		)
+
+	case data != nil && !isExecute && !isSubmit:
+		return fmt.Errorf(
+			"error: specifying Data is unsupported for Action type %q: %w",
+			actionType,
+			ErrInvalidFieldValue,
+		)
+
+	case associatedInputs != "" && !isExecute && !isSubmit:
+		return fmt.Errorf(
+			"error: specifying AssociatedInputs is unsupported for Action type %q: %w",
+			actionType,
+			ErrInvalidFieldValue,
+		)
+
+	case associatedInputs != "" && !goteamsnotify.InList(associatedInputs, supportedAssociatedInputsValues(), false):
+		return fmt.Errorf(
+			"invalid AssociatedInputs %q for %s; expected one of %v: %w",
+			associatedInputs,
+			actionType,
+			supportedAssociatedInputsValues(),
+			ErrInvalidFieldValue,
+		)
+	}
+
+	if data == nil {
+		return nil
+	}
+
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf(
+			"failed to encode Data for %s: %v: %w",
+			actionType,
+			err,
+			ErrInvalidFieldValue,
+		)
+	}
+
+	encoded = bytes.TrimSpace(encoded)
+	if len(encoded) == 0 || (encoded[0] != '{' && encoded[0] != '"') {
+		return fmt.Errorf(
+			"invalid Data for %s; expected JSON object or string, got %s: %w",
+			actionType,
+			encoded,
+			ErrInvalidFieldValue,
+		)
+	}
+
+	return nil
+}
+
+// isNilValue indicates whether the given value is nil or is a nil map,
+// pointer, slice, interface, channel or func value.
+func isNilValue(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Ptr, reflect.Slice:
+		return rv.IsNil()
+	default:
+		return false
+	}
+}
+
+// walkElements calls the given function for each of the given elements and
+// all of their child elements (depth-first). Elements within nested
+// Action.ShowCard Cards are not included.
+func walkElements(elements []Element, fn func(Element)) {
+	for _, element := range elements {
+		fn(element)
+
+		walkElements(element.Items, fn)
+		walkElements(element.Inlines, fn)
+		walkElements(element.Images, fn)
+
+		for _, column := range element.Columns {
+			walkElementPointers(column.Items, fn)
+		}
+
+		for _, row := range element.Rows {
+			for _, cell := range row.Cells {
+				walkElementPointers(cell.Items, fn)
+			}
+		}
+	}
+}
+
+// walkElementPointers is a helper function for walkElements which accepts a
+// collection of Element pointers.
+func walkElementPointers(elements []*Element, fn func(Element)) {
+	for _, element := range elements {
+		if element != nil {
+			walkElements([]Element{*element}, fn)
+		}
+	}
+}
+
+// assertColumnFallbackValidValue asserts that the Fallback field of a Column
+// (if set) is either the "drop" fallback option or a valid Column.
+func assertColumnFallbackValidValue(c Column) error {
+	fallback, _, err := columnFallback(c.Fallback)
+	if err != nil {
+		return fmt.Errorf("invalid fallback for %s: %w", c.Type, err)
+	}
+
+	if fallback == nil {
+		return nil
+	}
+
+	if err := assertColumnFallbackDepth(c); err != nil {
+		return err
+	}
+
+	return fallback.Validate()
+}
+
+// assertColumnFallbackDepth asserts that the chain of Column fallbacks
+// starting with the given Column does not exceed FallbackMaxNestingDepth.
+// This also guards against fallback Column values which (directly or
+// indirectly) reference themselves.
+func assertColumnFallbackDepth(c Column) error {
+	next := c.Fallback
+	for depth := 0; depth <= FallbackMaxNestingDepth; depth++ {
+		fallback, _, err := columnFallback(next)
+		if err != nil || fallback == nil {
+			return nil
+		}
+
+		next = fallback.Fallback
+	}
+
+	return fmt.Errorf(
+		"invalid fallback for %s; Fallback nesting depth exceeds limit of %d: %w",
+		c.Type,
+		FallbackMaxNestingDepth,
+		ErrInvalidFieldValue,
+	)
+}
+
+// columnFallback returns the fallback Column (if any) for the given Column
+// Fallback field value. A true value is returned if the field value is set
+// to the "drop" fallback option. An error is returned for unsupported field
+// values.
+func columnFallback(fallback interface{}) (*Column, bool, error) {
+	switch v := fallback.(type) {
+	case nil:
+		return nil, false, nil
+
+	case string:
+		if v != TypeFallbackOptionDrop {
+			return nil, false, fmt.Errorf(
+				"invalid Fallback %q; expected %q or Column: %w",
+				v,
+				TypeFallbackOptionDrop,
+				ErrInvalidFieldValue,
+			)
+		}
+
+		return nil, true, nil
+
+	case Column:
+		return &v, false, nil
+
+	case *Column:
+		return v, false, nil
+
+	// Values decoded from JSON (e.g., a previously generated payload).
+	case map[string]interface{}:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return nil, false, fmt.Errorf(
+				"failed to encode Fallback: %v: %w",
+				err,
+				ErrInvalidFieldValue,
+			)
+		}
+
+		var column Column
+		if err := json.Unmarshal(encoded, &column); err != nil {
+			return nil, false, fmt.Errorf(
+				"failed to decode Fallback as Column: %v: %w",
+				err,
+				ErrInvalidFieldValue,
+			)
+		}
+
+		return &column, false, nil
+
+	default:
+		return nil, false, fmt.Errorf(
+			"unsupported Fallback type %T; expected %q or Column: %w",
+			fallback,
+			TypeFallbackOptionDrop,
+			ErrInvalidFieldValue,
+		)
+	}
+}
+
+// elementFallback returns the fallback Element (if any) for the given
+// Element Fallback field value. A true value is returned if the field value
+// is set to the "drop" fallback option. An error is returned for unsupported
+// field values.
+func elementFallback(fallback interface{}) (*Element, bool, error) {
+	switch v := fallback.(type) {
+	case nil:
+		return nil, false, nil
+
+	case string:
+		if v != TypeFallbackOptionDrop {
+			return nil, false, fmt.Errorf(
+				"invalid Fallback %q; expected %q or Element: %w",
+				v,
+				TypeFallbackOptionDrop,
+				ErrInvalidFieldValue,
+			)
+		}
+
+		return nil, true, nil
+
+	case Element:
+		return &v, false, nil
+
+	case *Element:
+		return v, false, nil
+
+	// Values decoded from JSON (e.g., a previously generated payload).
+	case map[string]interface{}:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return nil, false, fmt.Errorf(
+				"failed to encode Fallback: %v: %w",
+				err,
+				ErrInvalidFieldValue,
+			)
+		}
+
+		var element Element
+		if err := json.Unmarshal(encoded, &element); err != nil {
+			return nil, false, fmt.Errorf(
+				"failed to decode Fallback as Element: %v: %w",
+				err,
+				ErrInvalidFieldValue,
+			)
+		}
+
+		return &element, false, nil
+
+	default:
+		return nil, false, fmt.Errorf(
+			"unsupported Fallback type %T; expected %q or Element: %w",
+			fallback,
+			TypeFallbackOptionDrop,
+			ErrInvalidFieldValue,
+		)
+	}
+}
+
+// assertElementFallbackValidValue asserts that the Fallback field of an
+// Element (if set) is either the "drop" fallback option or a valid Element.
+func assertElementFallbackValidValue(e Element) error {
+	fallback, _, err := elementFallback(e.Fallback)
+	if err != nil {
+		return fmt.Errorf("invalid fallback for %s: %w", e.Type, err)
+	}
+
+	if fallback == nil {
+		return nil
+	}
+
+	if err := assertNotInlineElement(*fallback); err != nil {
+		return fmt.Errorf("invalid fallback for %s: %w", e.Type, err)
+	}
+
+	if depth := elementFallbackDepth(e, FallbackMaxNestingDepth+1); depth > FallbackMaxNestingDepth {
+		return fmt.Errorf(
+			"invalid fallback for %s; Fallback nesting depth exceeds limit of %d: %w",
+			e.Type,
+			FallbackMaxNestingDepth,
+			ErrInvalidFieldValue,
+		)
+	}
+
+	if err := fallback.Validate(); err != nil {
+		return fmt.Errorf("invalid fallback for %s: %w", e.Type, err)
+	}
+
+	return nil
+}
+
+// elementFallbackDepth returns the nesting depth of Element fallbacks
+// starting with (and including) the Fallback of the given Element. Fallbacks
+// of the child elements of a fallback Element are also counted. Evaluation
+// stops once the given limit is reached; this also guards against fallback
+// Element values which (directly or indirectly) reference themselves.
+func elementFallbackDepth(e Element, limit int) int {
+	fallback, _, err := elementFallback(e.Fallback)
+	if err != nil || fallback == nil {
+		return 0
+	}
+
+	if limit <= 1 {
+		return 1
+	}
+
+	var maxNested int
+	walkElements([]Element{*fallback}, func(nested Element) {
+		if depth := elementFallbackDepth(nested, limit-1); depth > maxNested {
+			maxNested = depth
+		}
+	})
+
+	return 1 + maxNested
+}
+
+// assertRequiresValidValues asserts that the given Requires collection has
+// non-empty feature names and valid version values.
+func assertRequiresValidValues(requires map[string]string) error {
+	for feature, version := range requires {
+		if strings.TrimSpace(feature) == "" {
+			return fmt.Errorf(
+				"empty feature name in Requires: %w",
+				ErrMissingValue,
+			)
+		}
+
+		if version == RequiresAnyVersion {
+			continue
+		}
+
+		if _, err := strconv.ParseFloat(version, 64); err != nil {
+			return fmt.Errorf(
+				"invalid version %q for feature %q in Requires; expected %q or major.minor format: %w",
+				version,
+				feature,
+				RequiresAnyVersion,
+				ErrInvalidFieldValue,
+			)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,294 @@
+// Copyright 2024 Adam Chalkley
+//
+// https://github.com/atc0005/go-teams-notify
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package adaptivecard
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CardBuilder provides a fluent API for assembling a Card from common
+// notification building blocks (title, text, facts, tables, buttons, user
+// mentions, etc.).
+//
+// Each method appends to the Card using the existing New* constructors and
+// returns the CardBuilder so that calls may be chained. Errors are
+// accumulated instead of returned immediately; they are reported (along with
+// any validation errors) by the Card or Message methods.
+//
+// Example usage:
+//
+//	msg, err := adaptivecard.Build().
+//		Title("Disk usage high").
+//		Text("Usage on db01 exceeds 95%").
+//		Facts(adaptivecard.Fact{Title: "Host", Value: "db01"}).
+//		Button("Dashboard", "https://example.com/dashboard").
+//		MentionUser("John Doe", "john.doe@example.com").
+//		Message()
+type CardBuilder struct {
+	card Card
+	errs []error
+}
+
+// Build creates a new CardBuilder for a Card with required fields
+// predefined.
+func Build() *CardBuilder {
+	return &CardBuilder{
+		card: NewCard(),
+	}
+}
+
+// addErr records the given error, if any.
+func (b *CardBuilder) addErr(err error) {
+	if err != nil {
+		b.errs = append(b.errs, err)
+	}
+}
+
+// Title adds a TextBlock formatted as a title or header to the Card body.
+func (b *CardBuilder) Title(title string) *CardBuilder {
+	b.addErr(b.card.AddElement(false, NewTitleTextBlock(title, true)))
+
+	return b
+}
+
+// Text adds a TextBlock with text wrapping enabled to the Card body.
+func (b *CardBuilder) Text(text string) *CardBuilder {
+	b.addErr(b.card.AddElement(false, NewTextBlock(text, true)))
+
+	return b
+}
+
+// Element adds the given Elements to the Card body.
+func (b *CardBuilder) Element(elements ...Element) *CardBuilder {
+	b.addErr(b.card.AddElement(false, elements...))
+
+	return b
+}
+
+// Image adds an Image to the Card body using the given image URL (or data
+// URI) and alternate text.
+func (b *CardBuilder) Image(imageURL string, altText string) *CardBuilder {
+	image, err := NewImage(imageURL, altText)
+	if err != nil {
+		b.addErr(err)
+		return b
+	}
+
+	return b.Element(image)
+}
+
+// Facts adds a FactSet containing the given Facts to the Card body.
+func (b *CardBuilder) Facts(facts ...Fact) *CardBuilder {
+	factSet := NewFactSet()
+	if err := factSet.AddFact(facts...); err != nil {
+		b.addErr(err)
+		return b
+	}
+
+	b.addErr(b.card.AddFactSet(false, factSet))
+
+	return b
+}
+
+// Table adds a Table to the Card body. The first row is used as the header
+// row; each row is expected to have the same number of values.
+func (b *CardBuilder) Table(rows [][]string) *CardBuilder {
+	if len(rows) == 0 {
+		b.addErr(fmt.Errorf("no table rows provided: %w", ErrMissingValue))
+		return b
+	}
+
+	cells := make([][]TableCell, 0, len(rows))
+	for i, row := range rows {
+		items := make([]interface{}, 0, len(row))
+		for _, value := range row {
+			items = append(items, value)
+		}
+
+		rowCells, err := NewTableCellsWithTextBlock(items)
+		if err != nil {
+			b.addErr(fmt.Errorf("table row %d: %w", i, err))
+			return b
+		}
+		cells = append(cells, rowCells)
+	}
+
+	table, err := NewTableFromTableCells(cells, len(rows[0]), true, true)
+	if err != nil {
+		b.addErr(err)
+		return b
+	}
+
+	return b.Element(table)
+}
+
+// Button adds an Action.OpenUrl button with the given title and URL to the
+// Card actions.
+func (b *CardBuilder) Button(title string, url string) *CardBuilder {
+	action, err := NewActionOpenURL(url, title)
+	if err != nil {
+		b.addErr(err)
+		return b
+	}
+
+	b.addErr(b.card.AddAction(false, action))
+
+	return b
+}
+
+// Toggle adds a hidden Container with the given ID holding the given
+// Elements to the Card body along with an Action.ToggleVisibility button
+// with the given title to show or hide it.
+func (b *CardBuilder) Toggle(title string, id string, elements ...Element) *CardBuilder {
+	if strings.TrimSpace(id) == "" {
+		b.addErr(fmt.Errorf("empty ID for toggle container: %w", ErrMissingValue))
+		return b
+	}
+
+	container := NewHiddenContainer()
+	container.ID = id
+
+	for _, element := range elements {
+		if err := container.AddElement(false, element); err != nil {
+			b.addErr(err)
+			return b
+		}
+	}
+
+	action := NewActionToggleVisibility(title)
+	if err := action.AddTargetElement(nil, Element(container)); err != nil {
+		b.addErr(err)
+		return b
+	}
+
+	b.addErr(b.card.AddContainer(false, container))
+	b.addErr(b.card.AddAction(false, action))
+
+	return b
+}
+
+// MentionUser adds a user mention to the Card along with a new TextBlock
+// containing the mention text appended to the Card body. The id value may
+// be an object ID or a UserPrincipalName.
+func (b *CardBuilder) MentionUser(displayName string, id string) *CardBuilder {
+	mention, err := NewMention(displayName, id)
+	if err != nil {
+		b.addErr(err)
+		return b
+	}
+
+	b.addErr(b.card.AddMention(false, mention))
+
+	return b
+}
+
+// MentionUsers adds the given mentions (e.g., user, tag, channel or team
+// mentions) to the Card along with a single new TextBlock containing the
+// mention text of each appended to the Card body.
+func (b *CardBuilder) MentionUsers(mentions ...Mention) *CardBuilder {
+	b.addErr(b.card.AddMention(false, mentions...))
+
+	return b
+}
+
+// MentionTag adds a mention of the given tag to the Card along with a new
+// TextBlock containing the mention text appended to the Card body. Each
+// member of the tag is notified.
+func (b *CardBuilder) MentionTag(tagName string, tagID string) *CardBuilder {
+	mention, err := NewTagMention(tagName, tagID)
+	if err != nil {
+		b.addErr(err)
+		return b
+	}
+
+	b.addErr(b.card.AddMention(false, mention))
+
+	return b
+}
+
+// FullWidth sets the Card to use the full width available in Microsoft
+// Teams.
+func (b *CardBuilder) FullWidth() *CardBuilder {
+	b.card.SetFullWidth()
+
+	return b
+}
+
+// FallbackText sets the text displayed by clients which are unable to render
+// the Card. If not set, the plain text rendering of the Card is used.
+func (b *CardBuilder) FallbackText(text string) *CardBuilder {
+	b.card.FallbackText = text
+
+	return b
+}
+
+// Err returns an error describing all errors accumulated so far, or nil if
+// there were none. The first error is wrapped so that it can be inspected
+// using errors.Is and errors.As.
+func (b *CardBuilder) Err() error {
+	switch len(b.errs) {
+	case 0:
+		return nil
+
+	case 1:
+		return fmt.Errorf("failed to build card: %w", b.errs[0])
+
+	default:
+		others := make([]string, 0, len(b.errs)-1)
+		for _, err := range b.errs[1:] {
+			others = append(others, err.Error())
+		}
+
+		return fmt.Errorf(
+			"failed to build card: %w (and %d more: %s)",
+			b.errs[0],
+			len(others),
+			strings.Join(others, "; "),
+		)
+	}
+}
+
+// Card returns the assembled Card. If not already set, the FallbackText
+// field is set to the plain text rendering of the Card (see
+// Card.SetFallbackText). An error is returned if any errors were accumulated
+// or if the Card fails validation.
+func (b *CardBuilder) Card() (Card, error) {
+	if err := b.Err(); err != nil {
+		return Card{}, err
+	}
+
+	b.card.SetFallbackText()
+
+	if err := (TopLevelCard{Card: b.card}).Validate(); err != nil {
+		return Card{}, fmt.Errorf("failed to build card: %w", err)
+	}
+
+	return b.card, nil
+}
+
+// Message returns a new Message with the assembled Card attached. An error
+// is returned if any errors were accumulated or if the Card or Message fails
+// validation.
+func (b *CardBuilder) Message() (*Message, error) {
+	card, err := b.Card()
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := NewMessageFromCard(card)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := msg.Validate(); err != nil {
+		return nil, fmt.Errorf("failed to build message: %w", err)
+	}
+
+	return msg, nil
+}
@@ -0,0 +1,220 @@
+// Copyright 2024 Adam Chalkley
+//
+// https://github.com/atc0005/go-teams-notify
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package adaptivecard
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCardBuilderCard(t *testing.T) {
+	card, err := Build().
+		Title("Disk usage high").
+		Text("Usage on db01 exceeds 95%").
+		Facts(Fact{Title: "Host", Value: "db01"}).
+		Button("Dashboard", "https://example.com/dashboard").
+		FullWidth().
+		Card()
+	mustNoError(t, err)
+
+	if assert.Len(t, card.Body, 3) {
+		assert.Equal(t, "Disk usage high", card.Body[0].Text)
+		assert.Equal(t, "Usage on db01 exceeds 95%", card.Body[1].Text)
+		assert.Equal(t, TypeElementFactSet, card.Body[2].Type)
+	}
+
+	if assert.Len(t, card.Actions, 1) {
+		assert.Equal(t, TypeActionOpenURL, card.Actions[0].Type)
+		assert.Equal(t, "https://example.com/dashboard", card.Actions[0].URL)
+	}
+
+	assert.Equal(t, MSTeamsWidthFull, card.MSTeams.Width)
+	assert.Contains(t, card.FallbackText, "Disk usage high")
+
+	card, err = Build().Text("text").FallbackText("custom").Card()
+	if assert.NoError(t, err) {
+		assert.Equal(t, "custom", card.FallbackText)
+	}
+}
+
+func TestCardBuilderTable(t *testing.T) {
+	card, err := Build().
+		Table([][]string{
+			{"Host", "Status"},
+			{"db01", "down"},
+			{"db02", "up"},
+		}).
+		Card()
+	mustNoError(t, err)
+
+	if !assert.Len(t, card.Body, 1) {
+		return
+	}
+
+	table := card.Body[0]
+	assert.Equal(t, TypeElementTable, table.Type)
+	assert.Len(t, table.Columns, 2)
+
+	if assert.Len(t, table.Rows, 3) {
+		assert.Equal(t, "Host", table.Rows[0].Cells[0].Items[0].Text)
+		assert.Equal(t, "up", table.Rows[2].Cells[1].Items[0].Text)
+	}
+
+	if assert.NotNil(t, table.FirstRowAsHeaders) {
+		assert.True(t, *table.FirstRowAsHeaders)
+	}
+
+	_, err = Build().Table(nil).Card()
+	assert.True(t, errors.Is(err, ErrMissingValue))
+
+	_, err = Build().Table([][]string{{"Host"}, {}}).Card()
+	assert.True(t, errors.Is(err, ErrMissingValue))
+}
+
+func TestCardBuilderToggle(t *testing.T) {
+	card, err := Build().
+		Toggle("Details", "details", NewTextBlock("hidden text", true)).
+		Card()
+	mustNoError(t, err)
+
+	if assert.Len(t, card.Body, 1) {
+		container := card.Body[0]
+		assert.Equal(t, TypeElementContainer, container.Type)
+		assert.Equal(t, "details", container.ID)
+
+		if assert.NotNil(t, container.Visible) {
+			assert.False(t, *container.Visible)
+		}
+
+		assert.Len(t, container.Items, 1)
+	}
+
+	if assert.Len(t, card.Actions, 1) {
+		action := card.Actions[0]
+		assert.Equal(t, TypeActionToggleVisibility, action.Type)
+		assert.Equal(t, "Details", action.Title)
+
+		if assert.Len(t, action.TargetElements, 1) {
+			assert.Equal(t, "details", action.TargetElements[0].ElementID)
+		}
+	}
+
+	_, err = Build().Toggle("Details", " ", NewTextBlock("hidden text", true)).Card()
+	assert.True(t, errors.Is(err, ErrMissingValue))
+
+	_, err = Build().Toggle("Details", "details").Card()
+	assert.True(t, errors.Is(err, ErrMissingValue))
+}
+
+func TestCardBuilderMentions(t *testing.T) {
+	channel, err := NewChannelMention("General", "channel-1")
+	mustNoError(t, err)
+
+	team, err := NewTeamMention("Ops", "team-1")
+	mustNoError(t, err)
+
+	card, err := Build().
+		MentionUser("Jane Doe", "jane.doe@example.com").
+		MentionTag("oncall-db", "tag-1").
+		MentionUsers(channel, team).
+		Card()
+	mustNoError(t, err)
+
+	if !assert.Len(t, card.MSTeams.Entities, 4) {
+		return
+	}
+
+	assert.Equal(t, "", card.MSTeams.Entities[0].Mentioned.Type)
+	assert.Equal(t, MentionedTypeTag, card.MSTeams.Entities[1].Mentioned.Type)
+	assert.Equal(t, MentionedTypeChannel, card.MSTeams.Entities[2].Mentioned.Type)
+	assert.Equal(t, MentionedTypeTeam, card.MSTeams.Entities[3].Mentioned.Type)
+
+	// MentionUser and MentionTag each add a TextBlock while MentionUsers adds
+	// a single TextBlock for all given mentions.
+	if assert.Len(t, card.Body, 3) {
+		assert.Equal(t, "<at>Jane Doe</at>", strings.TrimSpace(card.Body[0].Text))
+		assert.Equal(t, "<at>oncall-db</at>", strings.TrimSpace(card.Body[1].Text))
+		assert.Equal(t, "<at>General</at> <at>Ops</at>", strings.TrimSpace(card.Body[2].Text))
+	}
+
+	_, err = Build().MentionTag("", "tag-1").Card()
+	assert.True(t, errors.Is(err, ErrMissingValue))
+
+	_, err = Build().MentionUsers().Card()
+	assert.Error(t, err)
+}
+
+func TestCardBuilderErrors(t *testing.T) {
+	b := Build()
+	assert.NoError(t, b.Err())
+
+	b.Image("", "missing").Button("Dashboard", "")
+	err := b.Err()
+
+	if assert.Error(t, err) {
+		assert.True(t, errors.Is(err, ErrMissingValue))
+		assert.True(t, strings.HasPrefix(err.Error(), "failed to build card: "))
+		assert.Contains(t, err.Error(), "(and 1 more: ")
+	}
+
+	_, err = b.Card()
+	assert.True(t, errors.Is(err, ErrMissingValue))
+
+	msg, err := b.Message()
+	assert.Nil(t, msg)
+	assert.True(t, errors.Is(err, ErrMissingValue))
+
+	err = Build().Element(NewTextBlock("bad", false), Element{Type: TypeElementTextBlock, Color: "pink"}).Err()
+	if assert.Error(t, err) {
+		assert.True(t, errors.Is(err, ErrInvalidFieldValue))
+		assert.NotContains(t, err.Error(), "more:")
+	}
+}
+
+func TestCardBuilderInvalidCard(t *testing.T) {
+	// Each Element is valid on its own, but the card as a whole is not: the
+	// default card Version predates Media caption sources.
+	media := Element{
+		Type:           TypeElementMedia,
+		Sources:        []MediaSource{NewMediaSource("video/mp4", "https://example.com/demo.mp4")},
+		CaptionSources: []CaptionSource{{MIMEType: "vtt", Label: "English", URL: "https://example.com/en.vtt"}},
+	}
+
+	b := Build().Element(media)
+	assert.NoError(t, b.Err())
+
+	_, err := b.Card()
+	if assert.Error(t, err) {
+		assert.True(t, strings.HasPrefix(err.Error(), "failed to build card: "))
+	}
+
+	msg, err := b.Message()
+	assert.Nil(t, msg)
+	assert.Error(t, err)
+}
+
+func TestCardBuilderMessage(t *testing.T) {
+	msg, err := Build().
+		Title("Deployment finished").
+		MentionUser("Jane Doe", "jane.doe@example.com").
+		Message()
+	mustNoError(t, err)
+
+	assert.Equal(t, TypeMessage, msg.Type)
+	assert.NoError(t, msg.Validate())
+
+	if assert.Len(t, msg.Attachments, 1) {
+		attachment := msg.Attachments[0]
+		assert.Equal(t, AttachmentContentType, attachment.ContentType)
+		assert.Equal(t, "Deployment finished", attachment.Content.Body[0].Text)
+		assert.Len(t, attachment.Content.MSTeams.Entities, 1)
+	}
+}
@@ -0,0 +1,886 @@
+// Copyright 2024 Adam Chalkley
+//
+// https://github.com/atc0005/go-teams-notify
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package elements
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/flashcatcloud/go-teams-notify/v2/adaptivecard"
+)
+
+func (tb TextBlock) toElement() adaptivecard.Element {
+	e := adaptivecard.Element{
+		Type:                adaptivecard.TypeElementTextBlock,
+		Text:                tb.Text,
+		Size:                tb.Size,
+		Weight:              tb.Weight,
+		Color:               tb.Color,
+		FontType:            tb.FontType,
+		HorizontalAlignment: tb.HorizontalAlignment,
+		Style:               tb.Style,
+		IsSubtle:            tb.IsSubtle,
+		Wrap:                tb.Wrap,
+	}
+	tb.apply(&e)
+
+	return e
+}
+
+func textBlockFromElement(e adaptivecard.Element) (CardElement, error) {
+	cp, err := commonFromElement(e)
+	if err != nil {
+		return nil, err
+	}
+
+	return TextBlock{
+		CommonProperties:    cp,
+		Text:                e.Text,
+		Size:                e.Size,
+		Weight:              e.Weight,
+		Color:               e.Color,
+		FontType:            e.FontType,
+		HorizontalAlignment: e.HorizontalAlignment,
+		Style:               e.Style,
+		IsSubtle:            e.IsSubtle,
+		Wrap:                e.Wrap,
+	}, nil
+}
+
+func (rtb RichTextBlock) toElement() adaptivecard.Element {
+	e := adaptivecard.Element{
+		Type:                adaptivecard.TypeElementRichTextBlock,
+		HorizontalAlignment: rtb.HorizontalAlignment,
+	}
+
+	if rtb.Inlines != nil {
+		e.Inlines = make([]adaptivecard.Element, 0, len(rtb.Inlines))
+		for _, run := range rtb.Inlines {
+			e.Inlines = append(e.Inlines, run.toElement())
+		}
+	}
+	rtb.apply(&e)
+
+	return e
+}
+
+func richTextBlockFromElement(e adaptivecard.Element) (CardElement, error) {
+	cp, err := commonFromElement(e)
+	if err != nil {
+		return nil, err
+	}
+
+	rtb := RichTextBlock{
+		CommonProperties:    cp,
+		HorizontalAlignment: e.HorizontalAlignment,
+	}
+
+	if e.Inlines != nil {
+		rtb.Inlines = make([]TextRun, 0, len(e.Inlines))
+		for i, inline := range e.Inlines {
+			if inline.Type != adaptivecard.TypeElementTextRun {
+				return nil, fmt.Errorf(
+					"inline %d: expected type %q, got %q: %w",
+					i,
+					adaptivecard.TypeElementTextRun,
+					inline.Type,
+					adaptivecard.ErrInvalidType,
+				)
+			}
+			rtb.Inlines = append(rtb.Inlines, textRunFromElement(inline))
+		}
+	}
+
+	return rtb, nil
+}
+
+func (tr TextRun) toElement() adaptivecard.Element {
+	return adaptivecard.Element{
+		Type:          adaptivecard.TypeElementTextRun,
+		Text:          tr.Text,
+		Size:          tr.Size,
+		Weight:        tr.Weight,
+		Color:         tr.Color,
+		FontType:      tr.FontType,
+		IsSubtle:      tr.IsSubtle,
+		Italic:        tr.Italic,
+		Strikethrough: tr.Strikethrough,
+		Underline:     tr.Underline,
+		Highlight:     tr.Highlight,
+		SelectAction:  tr.SelectAction,
+	}
+}
+
+func textRunFromElement(e adaptivecard.Element) TextRun {
+	return TextRun{
+		Text:          e.Text,
+		Size:          e.Size,
+		Weight:        e.Weight,
+		Color:         e.Color,
+		FontType:      e.FontType,
+		IsSubtle:      e.IsSubtle,
+		Italic:        e.Italic,
+		Strikethrough: e.Strikethrough,
+		Underline:     e.Underline,
+		Highlight:     e.Highlight,
+		SelectAction:  e.SelectAction,
+	}
+}
+
+func (i Image) toElement() adaptivecard.Element {
+	e := adaptivecard.Element{
+		Type:                adaptivecard.TypeElementImage,
+		URL:                 i.URL,
+		AltText:             i.AltText,
+		BackgroundColor:     i.BackgroundColor,
+		Size:                i.Size,
+		Style:               i.Style,
+		Width:               i.Width,
+		HorizontalAlignment: i.HorizontalAlignment,
+		SelectAction:        i.SelectAction,
+	}
+	i.apply(&e)
+
+	return e
+}
+
+func imageFromElement(e adaptivecard.Element) (CardElement, error) {
+	cp, err := commonFromElement(e)
+	if err != nil {
+		return nil, err
+	}
+
+	return Image{
+		CommonProperties:    cp,
+		URL:                 e.URL,
+		AltText:             e.AltText,
+		BackgroundColor:     e.BackgroundColor,
+		Size:                e.Size,
+		Style:               e.Style,
+		Width:               e.Width,
+		HorizontalAlignment: e.HorizontalAlignment,
+		SelectAction:        e.SelectAction,
+	}, nil
+}
+
+func (ims ImageSet) toElement() adaptivecard.Element {
+	e := adaptivecard.Element{
+		Type:      adaptivecard.TypeElementImageSet,
+		ImageSize: ims.ImageSize,
+	}
+
+	if ims.Images != nil {
+		e.Images = make([]adaptivecard.Element, 0, len(ims.Images))
+		for _, image := range ims.Images {
+			e.Images = append(e.Images, image.toElement())
+		}
+	}
+	ims.apply(&e)
+
+	return e
+}
+
+func imageSetFromElement(e adaptivecard.Element) (CardElement, error) {
+	cp, err := commonFromElement(e)
+	if err != nil {
+		return nil, err
+	}
+
+	ims := ImageSet{
+		CommonProperties: cp,
+		ImageSize:        e.ImageSize,
+	}
+
+	if e.Images != nil {
+		ims.Images = make([]Image, 0, len(e.Images))
+		for i, image := range e.Images {
+			if image.Type != adaptivecard.TypeElementImage {
+				return nil, fmt.Errorf(
+					"image %d: expected type %q, got %q: %w",
+					i,
+					adaptivecard.TypeElementImage,
+					image.Type,
+					adaptivecard.ErrInvalidType,
+				)
+			}
+
+			ce, err := imageFromElement(image)
+			if err != nil {
+				return nil, fmt.Errorf("image %d: %w", i, err)
+			}
+			ims.Images = append(ims.Images, ce.(Image))
+		}
+	}
+
+	return ims, nil
+}
+
+func (m Media) toElement() adaptivecard.Element {
+	e := adaptivecard.Element{
+		Type:           adaptivecard.TypeElementMedia,
+		Sources:        m.Sources,
+		Poster:         m.Poster,
+		AltText:        m.AltText,
+		CaptionSources: m.CaptionSources,
+	}
+	m.apply(&e)
+
+	return e
+}
+
+func mediaFromElement(e adaptivecard.Element) (CardElement, error) {
+	cp, err := commonFromElement(e)
+	if err != nil {
+		return nil, err
+	}
+
+	return Media{
+		CommonProperties: cp,
+		Sources:          e.Sources,
+		Poster:           e.Poster,
+		AltText:          e.AltText,
+		CaptionSources:   e.CaptionSources,
+	}, nil
+}
+
+func (c Container) toElement() adaptivecard.Element {
+	e := adaptivecard.Element{
+		Type:                     adaptivecard.TypeElementContainer,
+		Items:                    toElements(c.Items),
+		Style:                    c.Style,
+		Bleed:                    c.Bleed,
+		MinHeight:                c.MinHeight,
+		VerticalContentAlignment: c.VerticalContentAlignment,
+		BackgroundImage:          c.BackgroundImage,
+		RTL:                      c.RTL,
+		SelectAction:             c.SelectAction,
+	}
+	c.apply(&e)
+
+	return e
+}
+
+func containerFromElement(e adaptivecard.Element) (CardElement, error) {
+	cp, err := commonFromElement(e)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := fromElementValues(e.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	return Container{
+		CommonProperties:         cp,
+		Items:                    items,
+		Style:                    e.Style,
+		Bleed:                    e.Bleed,
+		MinHeight:                e.MinHeight,
+		VerticalContentAlignment: e.VerticalContentAlignment,
+		BackgroundImage:          e.BackgroundImage,
+		RTL:                      e.RTL,
+		SelectAction:             e.SelectAction,
+	}, nil
+}
+
+func (cs ColumnSet) toElement() adaptivecard.Element {
+	e := adaptivecard.Element{
+		Type:                adaptivecard.TypeElementColumnSet,
+		Style:               cs.Style,
+		Bleed:               cs.Bleed,
+		MinHeight:           cs.MinHeight,
+		HorizontalAlignment: cs.HorizontalAlignment,
+		SelectAction:        cs.SelectAction,
+	}
+
+	if cs.Columns != nil {
+		e.Columns = make([]adaptivecard.Column, 0, len(cs.Columns))
+		for _, column := range cs.Columns {
+			e.Columns = append(e.Columns, column.toColumn())
+		}
+	}
+	cs.apply(&e)
+
+	return e
+}
+
+func columnSetFromElement(e adaptivecard.Element) (CardElement, error) {
+	cp, err := commonFromElement(e)
+	if err != nil {
+		return nil, err
+	}
+
+	cs := ColumnSet{
+		CommonProperties:    cp,
+		Style:               e.Style,
+		Bleed:               e.Bleed,
+		MinHeight:           e.MinHeight,
+		HorizontalAlignment: e.HorizontalAlignment,
+		SelectAction:        e.SelectAction,
+	}
+
+	if e.Columns != nil {
+		cs.Columns = make([]Column, 0, len(e.Columns))
+		for i, column := range e.Columns {
+			c, err := columnFromColumn(column)
+			if err != nil {
+				return nil, fmt.Errorf("column %d: %w", i, err)
+			}
+			cs.Columns = append(cs.Columns, c)
+		}
+	}
+
+	return cs, nil
+}
+
+func (c Column) toColumn() adaptivecard.Column {
+	return adaptivecard.Column{
+		Type:                     adaptivecard.TypeColumn,
+		ID:                       c.ID,
+		Width:                    c.Width,
+		Items:                    toElementPointers(c.Items),
+		Style:                    c.Style,
+		Spacing:                  c.Spacing,
+		Separator:                c.Separator,
+		Visible:                  c.Visible,
+		Fallback:                 c.Fallback,
+		Bleed:                    c.Bleed,
+		MinHeight:                c.MinHeight,
+		VerticalContentAlignment: c.VerticalContentAlignment,
+		BackgroundImage:          c.BackgroundImage,
+		RTL:                      c.RTL,
+		SelectAction:             c.SelectAction,
+	}
+}
+
+func columnFromColumn(c adaptivecard.Column) (Column, error) {
+	items, err := fromElementPointers(c.Items)
+	if err != nil {
+		return Column{}, err
+	}
+
+	return Column{
+		ID:                       c.ID,
+		Width:                    c.Width,
+		Items:                    items,
+		Style:                    c.Style,
+		Spacing:                  c.Spacing,
+		Separator:                c.Separator,
+		Visible:                  c.Visible,
+		Fallback:                 c.Fallback,
+		Bleed:                    c.Bleed,
+		MinHeight:                c.MinHeight,
+		VerticalContentAlignment: c.VerticalContentAlignment,
+		BackgroundImage:          c.BackgroundImage,
+		RTL:                      c.RTL,
+		SelectAction:             c.SelectAction,
+	}, nil
+}
+
+func (fs FactSet) toElement() adaptivecard.Element {
+	e := adaptivecard.Element{
+		Type:  adaptivecard.TypeElementFactSet,
+		Facts: fs.Facts,
+	}
+	fs.apply(&e)
+
+	return e
+}
+
+func factSetFromElement(e adaptivecard.Element) (CardElement, error) {
+	cp, err := commonFromElement(e)
+	if err != nil {
+		return nil, err
+	}
+
+	return FactSet{
+		CommonProperties: cp,
+		Facts:            e.Facts,
+	}, nil
+}
+
+func (t Table) toElement() adaptivecard.Element {
+	e := adaptivecard.Element{
+		Type:              adaptivecard.TypeElementTable,
+		FirstRowAsHeaders: t.FirstRowAsHeaders,
+		ShowGridLines:     t.ShowGridLines,
+		GridStyle:         t.GridStyle,
+	}
+
+	if t.Columns != nil {
+		e.Columns = make([]adaptivecard.Column, 0, len(t.Columns))
+		for _, column := range t.Columns {
+			e.Columns = append(e.Columns, adaptivecard.Column{
+				Type:                           adaptivecard.TypeTableColumnDefinition,
+				Width:                          column.Width,
+				HorizontalCellContentAlignment: column.HorizontalCellContentAlignment,
+				VerticalCellContentAlignment:   column.VerticalCellContentAlignment,
+			})
+		}
+	}
+
+	if t.Rows != nil {
+		e.Rows = make([]adaptivecard.TableRow, 0, len(t.Rows))
+		for _, row := range t.Rows {
+			e.Rows = append(e.Rows, row.toTableRow())
+		}
+	}
+	t.apply(&e)
+
+	return e
+}
+
+func tableFromElement(e adaptivecard.Element) (CardElement, error) {
+	cp, err := commonFromElement(e)
+	if err != nil {
+		return nil, err
+	}
+
+	t := Table{
+		CommonProperties:  cp,
+		FirstRowAsHeaders: e.FirstRowAsHeaders,
+		ShowGridLines:     e.ShowGridLines,
+		GridStyle:         e.GridStyle,
+	}
+
+	if e.Columns != nil {
+		t.Columns = make([]TableColumnDefinition, 0, len(e.Columns))
+		for _, column := range e.Columns {
+			t.Columns = append(t.Columns, TableColumnDefinition{
+				Width:                          column.Width,
+				HorizontalCellContentAlignment: column.HorizontalCellContentAlignment,
+				VerticalCellContentAlignment:   column.VerticalCellContentAlignment,
+			})
+		}
+	}
+
+	if e.Rows != nil {
+		t.Rows = make([]TableRow, 0, len(e.Rows))
+		for i, row := range e.Rows {
+			r, err := tableRowFromTableRow(row)
+			if err != nil {
+				return nil, fmt.Errorf("row %d: %w", i, err)
+			}
+			t.Rows = append(t.Rows, r)
+		}
+	}
+
+	return t, nil
+}
+
+func (tr TableRow) toTableRow() adaptivecard.TableRow {
+	row := adaptivecard.TableRow{
+		Type:                           adaptivecard.TypeTableRow,
+		Style:                          tr.Style,
+		HorizontalCellContentAlignment: tr.HorizontalCellContentAlignment,
+		VerticalCellContentAlignment:   tr.VerticalCellContentAlignment,
+		Cells:                          make([]adaptivecard.TableCell, 0, len(tr.Cells)),
+	}
+
+	for _, cell := range tr.Cells {
+		row.Cells = append(row.Cells, adaptivecard.TableCell{
+			Type:                     adaptivecard.TypeTableCell,
+			Items:                    toElementPointers(cell.Items),
+			Style:                    cell.Style,
+			Bleed:                    cell.Bleed,
+			MinHeight:                cell.MinHeight,
+			VerticalContentAlignment: cell.VerticalContentAlignment,
+		})
+	}
+
+	return row
+}
+
+func tableRowFromTableRow(row adaptivecard.TableRow) (TableRow, error) {
+	tr := TableRow{
+		Style:                          row.Style,
+		HorizontalCellContentAlignment: row.HorizontalCellContentAlignment,
+		VerticalCellContentAlignment:   row.VerticalCellContentAlignment,
+		Cells:                          make([]TableCell, 0, len(row.Cells)),
+	}
+
+	for i, cell := range row.Cells {
+		items, err := fromElementPointers(cell.Items)
+		if err != nil {
+			return TableRow{}, fmt.Errorf("cell %d: %w", i, err)
+		}
+
+		tr.Cells = append(tr.Cells, TableCell{
+			Items:                    items,
+			Style:                    cell.Style,
+			Bleed:                    cell.Bleed,
+			MinHeight:                cell.MinHeight,
+			VerticalContentAlignment: cell.VerticalContentAlignment,
+		})
+	}
+
+	return tr, nil
+}
+
+func (as ActionSet) toElement() adaptivecard.Element {
+	e := adaptivecard.Element{
+		Type:    adaptivecard.TypeElementActionSet,
+		Actions: as.Actions,
+	}
+	as.apply(&e)
+
+	return e
+}
+
+func actionSetFromElement(e adaptivecard.Element) (CardElement, error) {
+	cp, err := commonFromElement(e)
+	if err != nil {
+		return nil, err
+	}
+
+	return ActionSet{
+		CommonProperties: cp,
+		Actions:          e.Actions,
+	}, nil
+}
+
+func (it InputText) toElement() adaptivecard.Element {
+	e := adaptivecard.Element{
+		Type:         adaptivecard.TypeElementInputText,
+		Label:        it.Label,
+		Placeholder:  it.Placeholder,
+		Value:        stringValue(it.Value),
+		IsRequired:   it.IsRequired,
+		ErrorMessage: it.ErrorMessage,
+		MaxLength:    it.MaxLength,
+		Regex:        it.Regex,
+		IsMultiline:  it.IsMultiline,
+		Style:        it.Style,
+	}
+	it.apply(&e)
+
+	return e
+}
+
+func inputTextFromElement(e adaptivecard.Element) (CardElement, error) {
+	cp, err := commonFromElement(e)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := stringFromValue("value", e.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	return InputText{
+		CommonProperties: cp,
+		Label:            e.Label,
+		Placeholder:      e.Placeholder,
+		Value:            value,
+		IsRequired:       e.IsRequired,
+		ErrorMessage:     e.ErrorMessage,
+		MaxLength:        e.MaxLength,
+		Regex:            e.Regex,
+		IsMultiline:      e.IsMultiline,
+		Style:            e.Style,
+	}, nil
+}
+
+func (in InputNumber) toElement() adaptivecard.Element {
+	e := adaptivecard.Element{
+		Type:         adaptivecard.TypeElementInputNumber,
+		Label:        in.Label,
+		Placeholder:  in.Placeholder,
+		Value:        numberValue(in.Value),
+		Min:          numberValue(in.Min),
+		Max:          numberValue(in.Max),
+		IsRequired:   in.IsRequired,
+		ErrorMessage: in.ErrorMessage,
+	}
+	in.apply(&e)
+
+	return e
+}
+
+func inputNumberFromElement(e adaptivecard.Element) (CardElement, error) {
+	cp, err := commonFromElement(e)
+	if err != nil {
+		return nil, err
+	}
+
+	in := InputNumber{
+		CommonProperties: cp,
+		Label:            e.Label,
+		Placeholder:      e.Placeholder,
+		IsRequired:       e.IsRequired,
+		ErrorMessage:     e.ErrorMessage,
+	}
+
+	if in.Value, err = numberFromValue("value", e.Value); err != nil {
+		return nil, err
+	}
+	if in.Min, err = numberFromValue("min", e.Min); err != nil {
+		return nil, err
+	}
+	if in.Max, err = numberFromValue("max", e.Max); err != nil {
+		return nil, err
+	}
+
+	return in, nil
+}
+
+func (id InputDate) toElement() adaptivecard.Element {
+	e := adaptivecard.Element{
+		Type:         adaptivecard.TypeElementInputDate,
+		Label:        id.Label,
+		Placeholder:  id.Placeholder,
+		Value:        stringValue(id.Value),
+		Min:          stringValue(id.Min),
+		Max:          stringValue(id.Max),
+		IsRequired:   id.IsRequired,
+		ErrorMessage: id.ErrorMessage,
+	}
+	id.apply(&e)
+
+	return e
+}
+
+func inputDateFromElement(e adaptivecard.Element) (CardElement, error) {
+	cp, err := commonFromElement(e)
+	if err != nil {
+		return nil, err
+	}
+
+	value, min, max, err := valueMinMaxStrings(e)
+	if err != nil {
+		return nil, err
+	}
+
+	return InputDate{
+		CommonProperties: cp,
+		Label:            e.Label,
+		Placeholder:      e.Placeholder,
+		Value:            value,
+		Min:              min,
+		Max:              max,
+		IsRequired:       e.IsRequired,
+		ErrorMessage:     e.ErrorMessage,
+	}, nil
+}
+
+func (it InputTime) toElement() adaptivecard.Element {
+	e := adaptivecard.Element{
+		Type:         adaptivecard.TypeElementInputTime,
+		Label:        it.Label,
+		Placeholder:  it.Placeholder,
+		Value:        stringValue(it.Value),
+		Min:          stringValue(it.Min),
+		Max:          stringValue(it.Max),
+		IsRequired:   it.IsRequired,
+		ErrorMessage: it.ErrorMessage,
+	}
+	it.apply(&e)
+
+	return e
+}
+
+func inputTimeFromElement(e adaptivecard.Element) (CardElement, error) {
+	cp, err := commonFromElement(e)
+	if err != nil {
+		return nil, err
+	}
+
+	value, min, max, err := valueMinMaxStrings(e)
+	if err != nil {
+		return nil, err
+	}
+
+	return InputTime{
+		CommonProperties: cp,
+		Label:            e.Label,
+		Placeholder:      e.Placeholder,
+		Value:            value,
+		Min:              min,
+		Max:              max,
+		IsRequired:       e.IsRequired,
+		ErrorMessage:     e.ErrorMessage,
+	}, nil
+}
+
+func (it InputToggle) toElement() adaptivecard.Element {
+	e := adaptivecard.Element{
+		Type:         adaptivecard.TypeElementInputToggle,
+		Title:        it.Title,
+		Label:        it.Label,
+		Value:        stringValue(it.Value),
+		ValueOn:      it.ValueOn,
+		ValueOff:     it.ValueOff,
+		IsRequired:   it.IsRequired,
+		ErrorMessage: it.ErrorMessage,
+	}
+	it.apply(&e)
+
+	return e
+}
+
+func inputToggleFromElement(e adaptivecard.Element) (CardElement, error) {
+	cp, err := commonFromElement(e)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := stringFromValue("value", e.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	return InputToggle{
+		CommonProperties: cp,
+		Title:            e.Title,
+		Label:            e.Label,
+		Value:            value,
+		ValueOn:          e.ValueOn,
+		ValueOff:         e.ValueOff,
+		IsRequired:       e.IsRequired,
+		ErrorMessage:     e.ErrorMessage,
+	}, nil
+}
+
+func (ics InputChoiceSet) toElement() adaptivecard.Element {
+	e := adaptivecard.Element{
+		Type:          adaptivecard.TypeElementInputChoiceSet,
+		Label:         ics.Label,
+		Placeholder:   ics.Placeholder,
+		Choices:       ics.Choices,
+		IsMultiSelect: ics.IsMultiSelect,
+		Style:         ics.Style,
+		Value:         stringValue(ics.Value),
+		IsRequired:    ics.IsRequired,
+		ErrorMessage:  ics.ErrorMessage,
+	}
+	ics.apply(&e)
+
+	return e
+}
+
+func inputChoiceSetFromElement(e adaptivecard.Element) (CardElement, error) {
+	cp, err := commonFromElement(e)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := stringFromValue("value", e.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	return InputChoiceSet{
+		CommonProperties: cp,
+		Label:            e.Label,
+		Placeholder:      e.Placeholder,
+		Choices:          e.Choices,
+		IsMultiSelect:    e.IsMultiSelect,
+		Style:            e.Style,
+		Value:            value,
+		IsRequired:       e.IsRequired,
+		ErrorMessage:     e.ErrorMessage,
+	}, nil
+}
+
+// stringValue returns the given string as an Element value, or nil if the
+// string is empty so that the value is omitted.
+func stringValue(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+
+	return s
+}
+
+// numberValue returns the given number as an Element value, or nil if not
+// set so that the value is omitted.
+func numberValue(n *float64) interface{} {
+	if n == nil {
+		return nil
+	}
+
+	return *n
+}
+
+// stringFromValue returns the given Element value as a string.
+func stringFromValue(field string, v interface{}) (string, error) {
+	switch value := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return value, nil
+	default:
+		return "", fmt.Errorf(
+			"%s: expected string, got %T: %w",
+			field,
+			v,
+			adaptivecard.ErrInvalidFieldValue,
+		)
+	}
+}
+
+// numberFromValue returns the given Element value as a number.
+func numberFromValue(field string, v interface{}) (*float64, error) {
+	var n float64
+
+	switch value := v.(type) {
+	case nil:
+		return nil, nil
+	case float64:
+		n = value
+	case float32:
+		n = float64(value)
+	case int:
+		n = float64(value)
+	case int64:
+		n = float64(value)
+	case json.Number:
+		f, err := value.Float64()
+		if err != nil {
+			return nil, fmt.Errorf(
+				"%s: %v: %w",
+				field,
+				err,
+				adaptivecard.ErrInvalidFieldValue,
+			)
+		}
+		n = f
+	default:
+		return nil, fmt.Errorf(
+			"%s: expected number, got %T: %w",
+			field,
+			v,
+			adaptivecard.ErrInvalidFieldValue,
+		)
+	}
+
+	return &n, nil
+}
+
+// valueMinMaxStrings returns the Value, Min and Max fields of the given
+// Element as strings.
+func valueMinMaxStrings(e adaptivecard.Element) (string, string, string, error) {
+	value, err := stringFromValue("value", e.Value)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	min, err := stringFromValue("min", e.Min)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	max, err := stringFromValue("max", e.Max)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	return value, min, max, nil
+}
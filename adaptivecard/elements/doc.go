@@ -0,0 +1,38 @@
+// Copyright 2024 Adam Chalkley
+//
+// https://github.com/atc0005/go-teams-notify
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+/*
+Package elements provides a typed alternative to the flat adaptivecard.Element
+struct.
+
+Each Adaptive Card element type (TextBlock, Container, Table, Input.Text,
+etc.) is represented by its own struct exposing only the properties which
+apply to it. These structs implement the sealed CardElement interface; child
+collections (e.g., Container.Items) accept only CardElement values so that
+invalid combinations, such as a TextRun placed directly within a Container,
+are rejected at compile time.
+
+Values marshal to and unmarshal from the same JSON as the equivalent
+adaptivecard.Element. The ToElement and FromElement functions (and their
+plural forms) convert between the two representations so that typed values
+may be added to an adaptivecard.Card:
+
+	elems, err := elements.ToElements(
+		elements.TextBlock{Text: "Disk usage high", Weight: adaptivecard.WeightBolder},
+		elements.FactSet{Facts: facts},
+	)
+	if err != nil {
+		// handle error
+	}
+
+	if err := card.AddElement(false, elems...); err != nil {
+		// handle error
+	}
+
+See https://adaptivecards.io/explorer for details on each element type.
+*/
+package elements
@@ -0,0 +1,403 @@
+// Copyright 2024 Adam Chalkley
+//
+// https://github.com/atc0005/go-teams-notify
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package elements
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/flashcatcloud/go-teams-notify/v2/adaptivecard"
+)
+
+// ErrNilElement indicates that a nil CardElement was given where a value is
+// required.
+var ErrNilElement = errors.New("nil CardElement")
+
+// CardElement is implemented by each of the typed element structs provided
+// by this package. The interface is sealed; types outside of this package
+// cannot implement it.
+type CardElement interface {
+	// ElementType returns the Adaptive Card type of the element (e.g.,
+	// "TextBlock").
+	ElementType() string
+
+	// Validate asserts that the element has valid values.
+	Validate() error
+
+	// toElement converts the value to the equivalent adaptivecard.Element.
+	toElement() adaptivecard.Element
+}
+
+// CardElements is a collection of CardElement values. Unlike a plain
+// []CardElement this type can be unmarshaled from JSON.
+type CardElements []CardElement
+
+// CommonProperties holds the properties shared by all element types.
+type CommonProperties struct {
+	// ID is a unique identifier associated with this element.
+	ID string
+
+	// Spacing controls the amount of spacing between this element and the
+	// preceding element.
+	Spacing string
+
+	// Separator, when true, indicates that a separating line should be
+	// drawn at the top of the element.
+	Separator bool
+
+	// Visible specifies whether this element will be removed from the
+	// visual tree. Unset is treated as visible.
+	Visible *bool
+
+	// Height specifies the height of the element; "auto" or "stretch".
+	Height string
+
+	// Requires is a series of key/value pairs indicating features that the
+	// element requires with corresponding minimum version.
+	Requires map[string]string
+
+	// Fallback is rendered by clients which do not support this element.
+	Fallback CardElement
+
+	// FallbackDrop indicates that clients which do not support this element
+	// should drop it. This takes precedence over Fallback.
+	FallbackDrop bool
+
+	// UnknownFields holds properties not modeled by the element type. See
+	// adaptivecard.Card.UnknownFields for details.
+	UnknownFields map[string]json.RawMessage
+}
+
+// ToElement converts the given CardElement to the equivalent
+// adaptivecard.Element.
+func ToElement(ce CardElement) (adaptivecard.Element, error) {
+	if ce == nil {
+		return adaptivecard.Element{}, ErrNilElement
+	}
+
+	return ce.toElement(), nil
+}
+
+// ToElements converts the given CardElement values to the equivalent
+// adaptivecard.Element values.
+func ToElements(ces ...CardElement) ([]adaptivecard.Element, error) {
+	result := make([]adaptivecard.Element, 0, len(ces))
+
+	for i, ce := range ces {
+		e, err := ToElement(ce)
+		if err != nil {
+			return nil, fmt.Errorf("element %d: %w", i, err)
+		}
+		result = append(result, e)
+	}
+
+	return result, nil
+}
+
+// FromElement converts the given adaptivecard.Element to the equivalent
+// typed CardElement. An error is returned if the element type is not
+// supported or if the element cannot be placed within a collection of
+// elements (e.g., TextRun).
+func FromElement(e adaptivecard.Element) (CardElement, error) {
+	switch e.Type {
+	case adaptivecard.TypeElementTextBlock:
+		return textBlockFromElement(e)
+	case adaptivecard.TypeElementRichTextBlock:
+		return richTextBlockFromElement(e)
+	case adaptivecard.TypeElementImage:
+		return imageFromElement(e)
+	case adaptivecard.TypeElementImageSet:
+		return imageSetFromElement(e)
+	case adaptivecard.TypeElementMedia:
+		return mediaFromElement(e)
+	case adaptivecard.TypeElementContainer:
+		return containerFromElement(e)
+	case adaptivecard.TypeElementColumnSet:
+		return columnSetFromElement(e)
+	case adaptivecard.TypeElementFactSet:
+		return factSetFromElement(e)
+	case adaptivecard.TypeElementTable:
+		return tableFromElement(e)
+	case adaptivecard.TypeElementActionSet:
+		return actionSetFromElement(e)
+	case adaptivecard.TypeElementInputText:
+		return inputTextFromElement(e)
+	case adaptivecard.TypeElementInputNumber:
+		return inputNumberFromElement(e)
+	case adaptivecard.TypeElementInputDate:
+		return inputDateFromElement(e)
+	case adaptivecard.TypeElementInputTime:
+		return inputTimeFromElement(e)
+	case adaptivecard.TypeElementInputToggle:
+		return inputToggleFromElement(e)
+	case adaptivecard.TypeElementInputChoiceSet:
+		return inputChoiceSetFromElement(e)
+	default:
+		return nil, fmt.Errorf(
+			"element type %q is not supported as a CardElement: %w",
+			e.Type,
+			adaptivecard.ErrInvalidType,
+		)
+	}
+}
+
+// FromElements converts the given adaptivecard.Element values to the
+// equivalent typed CardElement values.
+func FromElements(elements ...adaptivecard.Element) (CardElements, error) {
+	result := make(CardElements, 0, len(elements))
+
+	for i, e := range elements {
+		ce, err := FromElement(e)
+		if err != nil {
+			return nil, fmt.Errorf("element %d: %w", i, err)
+		}
+		result = append(result, ce)
+	}
+
+	return result, nil
+}
+
+// Unmarshal decodes the given element JSON into the matching typed
+// CardElement based on its type property.
+func Unmarshal(data []byte) (CardElement, error) {
+	var e adaptivecard.Element
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, err
+	}
+
+	return FromElement(e)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, decoding each
+// element into the matching typed CardElement.
+func (ces *CardElements) UnmarshalJSON(data []byte) error {
+	var elements []adaptivecard.Element
+	if err := json.Unmarshal(data, &elements); err != nil {
+		return err
+	}
+
+	result, err := FromElements(elements...)
+	if err != nil {
+		return err
+	}
+
+	*ces = result
+
+	return nil
+}
+
+// Validate asserts that each element has valid values.
+func (ces CardElements) Validate() error {
+	for i, ce := range ces {
+		if ce == nil {
+			return fmt.Errorf("element %d: %w", i, ErrNilElement)
+		}
+		if err := ce.Validate(); err != nil {
+			return fmt.Errorf("element %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// apply copies the common properties to the given element.
+func (cp CommonProperties) apply(e *adaptivecard.Element) {
+	e.ID = cp.ID
+	e.Spacing = cp.Spacing
+	e.Separator = cp.Separator
+	e.Visible = cp.Visible
+	e.Height = cp.Height
+	e.Requires = cp.Requires
+	e.UnknownFields = cp.UnknownFields
+
+	switch {
+	case cp.FallbackDrop:
+		e.SetFallbackDrop()
+	case cp.Fallback != nil:
+		e.Fallback = cp.Fallback.toElement()
+	}
+}
+
+// commonFromElement returns the common properties of the given element.
+func commonFromElement(e adaptivecard.Element) (CommonProperties, error) {
+	cp := CommonProperties{
+		ID:            e.ID,
+		Spacing:       e.Spacing,
+		Separator:     e.Separator,
+		Visible:       e.Visible,
+		Height:        e.Height,
+		Requires:      e.Requires,
+		UnknownFields: e.UnknownFields,
+	}
+
+	switch fallback := e.Fallback.(type) {
+	case nil:
+
+	case string:
+		if fallback != adaptivecard.TypeFallbackOptionDrop {
+			return CommonProperties{}, fmt.Errorf(
+				"unsupported fallback value %q: %w",
+				fallback,
+				adaptivecard.ErrInvalidFieldValue,
+			)
+		}
+		cp.FallbackDrop = true
+
+	default:
+		fallbackElement, err := fallbackToElement(fallback)
+		if err != nil {
+			return CommonProperties{}, err
+		}
+
+		ce, err := FromElement(fallbackElement)
+		if err != nil {
+			return CommonProperties{}, fmt.Errorf("fallback: %w", err)
+		}
+		cp.Fallback = ce
+	}
+
+	return cp, nil
+}
+
+// fallbackToElement converts the given Element fallback value to an Element.
+func fallbackToElement(fallback interface{}) (adaptivecard.Element, error) {
+	switch f := fallback.(type) {
+	case adaptivecard.Element:
+		return f, nil
+
+	case *adaptivecard.Element:
+		if f == nil {
+			return adaptivecard.Element{}, fmt.Errorf("fallback: %w", ErrNilElement)
+		}
+		return *f, nil
+
+	default:
+		// Fallback elements decoded from JSON are generic maps.
+		encoded, err := json.Marshal(f)
+		if err != nil {
+			return adaptivecard.Element{}, fmt.Errorf(
+				"fallback: %v: %w",
+				err,
+				adaptivecard.ErrInvalidFieldValue,
+			)
+		}
+
+		var e adaptivecard.Element
+		if err := json.Unmarshal(encoded, &e); err != nil {
+			return adaptivecard.Element{}, fmt.Errorf(
+				"fallback: %v: %w",
+				err,
+				adaptivecard.ErrInvalidFieldValue,
+			)
+		}
+
+		return e, nil
+	}
+}
+
+// toElements converts the given CardElement values, skipping nil values.
+func toElements(ces []CardElement) []adaptivecard.Element {
+	if ces == nil {
+		return nil
+	}
+
+	result := make([]adaptivecard.Element, 0, len(ces))
+	for _, ce := range ces {
+		if ce != nil {
+			result = append(result, ce.toElement())
+		}
+	}
+
+	return result
+}
+
+// toElementPointers converts the given CardElement values, skipping nil
+// values.
+func toElementPointers(ces []CardElement) []*adaptivecard.Element {
+	if ces == nil {
+		return nil
+	}
+
+	result := make([]*adaptivecard.Element, 0, len(ces))
+	for _, ce := range ces {
+		if ce != nil {
+			e := ce.toElement()
+			result = append(result, &e)
+		}
+	}
+
+	return result
+}
+
+// fromElementPointers converts the given element pointers, skipping nil
+// values.
+func fromElementPointers(elements []*adaptivecard.Element) ([]CardElement, error) {
+	if elements == nil {
+		return nil, nil
+	}
+
+	result := make([]CardElement, 0, len(elements))
+	for i, e := range elements {
+		if e == nil {
+			continue
+		}
+
+		ce, err := FromElement(*e)
+		if err != nil {
+			return nil, fmt.Errorf("element %d: %w", i, err)
+		}
+		result = append(result, ce)
+	}
+
+	return result, nil
+}
+
+// fromElementValues converts the given elements.
+func fromElementValues(elements []adaptivecard.Element) ([]CardElement, error) {
+	if elements == nil {
+		return nil, nil
+	}
+
+	result, err := FromElements(elements...)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// validate converts the given CardElement and validates the result.
+func validate(ce CardElement) error {
+	return ce.toElement().Validate()
+}
+
+// marshal encodes the given CardElement as the equivalent Element.
+func marshal(ce CardElement) ([]byte, error) {
+	return json.Marshal(ce.toElement())
+}
+
+// unmarshal decodes the given element JSON, asserting that it has the
+// expected type.
+func unmarshal(data []byte, elementType string) (CardElement, error) {
+	var e adaptivecard.Element
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, err
+	}
+
+	if e.Type != elementType {
+		return nil, fmt.Errorf(
+			"expected element type %q, got %q: %w",
+			elementType,
+			e.Type,
+			adaptivecard.ErrInvalidType,
+		)
+	}
+
+	return FromElement(e)
+}
@@ -0,0 +1,144 @@
+// Copyright 2024 Adam Chalkley
+//
+// https://github.com/atc0005/go-teams-notify
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package elements
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/flashcatcloud/go-teams-notify/v2/adaptivecard"
+	"github.com/stretchr/testify/assert"
+)
+
+const bodyJSON = `[
+	{"type": "TextBlock", "text": "Disk usage high", "weight": "bolder", "wrap": true, "id": "title"},
+	{"type": "RichTextBlock", "inlines": [{"type": "TextRun", "text": "bold", "weight": "bolder", "italic": true}]},
+	{"type": "Container", "style": "attention", "items": [
+		{"type": "FactSet", "facts": [{"title": "host", "value": "db01"}]},
+		{"type": "Image", "url": "https://example.com/a.png", "altText": "graph", "fallback": "drop"}
+	]},
+	{"type": "ColumnSet", "columns": [
+		{"type": "Column", "width": "auto", "items": [{"type": "TextBlock", "text": "left"}]},
+		{"type": "Column", "width": 2, "items": [{"type": "TextBlock", "text": "right"}]}
+	]},
+	{"type": "Table", "columns": [{"type": "TableColumnDefinition", "width": 1}], "rows": [
+		{"type": "TableRow", "cells": [{"type": "TableCell", "items": [{"type": "TextBlock", "text": "cell"}]}]}
+	]},
+	{"type": "ImageSet", "imageSize": "small", "images": [{"type": "Image", "url": "https://example.com/b.png"}]},
+	{"type": "ActionSet", "actions": [{"type": "Action.OpenUrl", "title": "Open", "url": "https://example.com"}]},
+	{"type": "Input.Text", "id": "comment", "label": "Comment", "value": "n/a", "isMultiline": true},
+	{"type": "Input.Number", "id": "count", "label": "Count", "min": 1, "max": 10, "value": 5},
+	{"type": "Input.Date", "id": "date", "label": "Date", "min": "2024-01-01"},
+	{"type": "Input.Time", "id": "time", "label": "Time", "value": "13:30"},
+	{"type": "Input.Toggle", "id": "ack", "title": "Acknowledge", "value": "true"},
+	{"type": "Input.ChoiceSet", "id": "sev", "label": "Severity", "choices": [{"title": "High", "value": "high"}]},
+	{"type": "Media", "sources": [{"mimeType": "video/mp4", "url": "https://example.com/v.mp4"}], "fallback": {"type": "TextBlock", "text": "no video"}}
+]`
+
+func TestCardElementsJSONRoundTrip(t *testing.T) {
+	var ces CardElements
+	if !assert.NoError(t, json.Unmarshal([]byte(bodyJSON), &ces)) {
+		return
+	}
+
+	if !assert.Len(t, ces, 14) {
+		return
+	}
+
+	tb, ok := ces[0].(TextBlock)
+	if assert.True(t, ok) {
+		assert.Equal(t, "title", tb.ID)
+		assert.Equal(t, adaptivecard.WeightBolder, tb.Weight)
+		assert.True(t, tb.Wrap)
+	}
+
+	container, ok := ces[2].(Container)
+	if assert.True(t, ok) && assert.Len(t, container.Items, 2) {
+		assert.IsType(t, FactSet{}, container.Items[0])
+		assert.True(t, container.Items[1].(Image).FallbackDrop)
+	}
+
+	number, ok := ces[8].(InputNumber)
+	if assert.True(t, ok) && assert.NotNil(t, number.Value) {
+		assert.Equal(t, float64(5), *number.Value)
+	}
+
+	media, ok := ces[13].(Media)
+	if assert.True(t, ok) {
+		assert.IsType(t, TextBlock{}, media.Fallback)
+	}
+
+	assert.NoError(t, ces.Validate())
+
+	encoded, err := json.Marshal(ces)
+	if assert.NoError(t, err) {
+		assert.JSONEq(t, bodyJSON, string(encoded))
+	}
+}
+
+func TestToElementsAddToCard(t *testing.T) {
+	visible := false
+
+	elems, err := ToElements(
+		TextBlock{Text: "Summary", Size: adaptivecard.SizeLarge},
+		Container{
+			CommonProperties: CommonProperties{ID: "details", Visible: &visible},
+			Items: []CardElement{
+				FactSet{Facts: []adaptivecard.Fact{{Title: "a", Value: "b"}}},
+			},
+		},
+	)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	card := adaptivecard.NewCard()
+	if !assert.NoError(t, card.AddElement(false, elems...)) {
+		return
+	}
+
+	assert.NoError(t, adaptivecard.TopLevelCard{Card: card}.Validate())
+	assert.Equal(t, adaptivecard.TypeElementContainer, card.Body[1].Type)
+	assert.Equal(t, adaptivecard.TypeElementFactSet, card.Body[1].Items[0].Type)
+
+	ces, err := FromElements(card.Body...)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "details", ces[1].(Container).ID)
+	}
+
+	_, err = ToElements(TextBlock{Text: "x"}, nil)
+	assert.True(t, errors.Is(err, ErrNilElement))
+}
+
+func TestFromElementUnsupportedType(t *testing.T) {
+	_, err := FromElement(adaptivecard.NewTextRun("inline"))
+	assert.True(t, errors.Is(err, adaptivecard.ErrInvalidType))
+
+	_, err = Unmarshal([]byte(`{"type": "Carousel"}`))
+	assert.True(t, errors.Is(err, adaptivecard.ErrInvalidType))
+
+	var tb TextBlock
+	err = json.Unmarshal([]byte(`{"type": "Image", "url": "https://example.com/a.png"}`), &tb)
+	assert.True(t, errors.Is(err, adaptivecard.ErrInvalidType))
+
+	_, err = FromElement(adaptivecard.Element{
+		Type:  adaptivecard.TypeElementInputNumber,
+		Value: "five",
+	})
+	assert.True(t, errors.Is(err, adaptivecard.ErrInvalidFieldValue))
+}
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, TextBlock{Text: "ok"}.Validate())
+	assert.Error(t, TextBlock{Text: "bad", Color: "pink"}.Validate())
+	assert.Error(t, FactSet{}.Validate())
+
+	err := CardElements{TextBlock{Text: "ok"}, nil}.Validate()
+	assert.True(t, errors.Is(err, ErrNilElement))
+}
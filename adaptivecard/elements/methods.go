@@ -0,0 +1,464 @@
+// Copyright 2024 Adam Chalkley
+//
+// https://github.com/atc0005/go-teams-notify
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package elements
+
+import (
+	"github.com/flashcatcloud/go-teams-notify/v2/adaptivecard"
+)
+
+// Assert that each element type implements the CardElement interface.
+var (
+	_ CardElement = TextBlock{}
+	_ CardElement = RichTextBlock{}
+	_ CardElement = Image{}
+	_ CardElement = ImageSet{}
+	_ CardElement = Media{}
+	_ CardElement = Container{}
+	_ CardElement = ColumnSet{}
+	_ CardElement = FactSet{}
+	_ CardElement = Table{}
+	_ CardElement = ActionSet{}
+	_ CardElement = InputText{}
+	_ CardElement = InputNumber{}
+	_ CardElement = InputDate{}
+	_ CardElement = InputTime{}
+	_ CardElement = InputToggle{}
+	_ CardElement = InputChoiceSet{}
+)
+
+// ElementType returns the Adaptive Card type of the element.
+func (tb TextBlock) ElementType() string {
+	return adaptivecard.TypeElementTextBlock
+}
+
+// Validate asserts that the element has valid values.
+func (tb TextBlock) Validate() error {
+	return validate(tb)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (tb TextBlock) MarshalJSON() ([]byte, error) {
+	return marshal(tb)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (tb *TextBlock) UnmarshalJSON(data []byte) error {
+	ce, err := unmarshal(data, adaptivecard.TypeElementTextBlock)
+	if err != nil {
+		return err
+	}
+
+	*tb = ce.(TextBlock)
+
+	return nil
+}
+
+// ElementType returns the Adaptive Card type of the element.
+func (rtb RichTextBlock) ElementType() string {
+	return adaptivecard.TypeElementRichTextBlock
+}
+
+// Validate asserts that the element has valid values.
+func (rtb RichTextBlock) Validate() error {
+	return validate(rtb)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (rtb RichTextBlock) MarshalJSON() ([]byte, error) {
+	return marshal(rtb)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (rtb *RichTextBlock) UnmarshalJSON(data []byte) error {
+	ce, err := unmarshal(data, adaptivecard.TypeElementRichTextBlock)
+	if err != nil {
+		return err
+	}
+
+	*rtb = ce.(RichTextBlock)
+
+	return nil
+}
+
+// ElementType returns the Adaptive Card type of the element.
+func (i Image) ElementType() string {
+	return adaptivecard.TypeElementImage
+}
+
+// Validate asserts that the element has valid values.
+func (i Image) Validate() error {
+	return validate(i)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (i Image) MarshalJSON() ([]byte, error) {
+	return marshal(i)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (i *Image) UnmarshalJSON(data []byte) error {
+	ce, err := unmarshal(data, adaptivecard.TypeElementImage)
+	if err != nil {
+		return err
+	}
+
+	*i = ce.(Image)
+
+	return nil
+}
+
+// ElementType returns the Adaptive Card type of the element.
+func (ims ImageSet) ElementType() string {
+	return adaptivecard.TypeElementImageSet
+}
+
+// Validate asserts that the element has valid values.
+func (ims ImageSet) Validate() error {
+	return validate(ims)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (ims ImageSet) MarshalJSON() ([]byte, error) {
+	return marshal(ims)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (ims *ImageSet) UnmarshalJSON(data []byte) error {
+	ce, err := unmarshal(data, adaptivecard.TypeElementImageSet)
+	if err != nil {
+		return err
+	}
+
+	*ims = ce.(ImageSet)
+
+	return nil
+}
+
+// ElementType returns the Adaptive Card type of the element.
+func (m Media) ElementType() string {
+	return adaptivecard.TypeElementMedia
+}
+
+// Validate asserts that the element has valid values.
+func (m Media) Validate() error {
+	return validate(m)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (m Media) MarshalJSON() ([]byte, error) {
+	return marshal(m)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (m *Media) UnmarshalJSON(data []byte) error {
+	ce, err := unmarshal(data, adaptivecard.TypeElementMedia)
+	if err != nil {
+		return err
+	}
+
+	*m = ce.(Media)
+
+	return nil
+}
+
+// ElementType returns the Adaptive Card type of the element.
+func (c Container) ElementType() string {
+	return adaptivecard.TypeElementContainer
+}
+
+// Validate asserts that the element has valid values.
+func (c Container) Validate() error {
+	return validate(c)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (c Container) MarshalJSON() ([]byte, error) {
+	return marshal(c)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (c *Container) UnmarshalJSON(data []byte) error {
+	ce, err := unmarshal(data, adaptivecard.TypeElementContainer)
+	if err != nil {
+		return err
+	}
+
+	*c = ce.(Container)
+
+	return nil
+}
+
+// ElementType returns the Adaptive Card type of the element.
+func (cs ColumnSet) ElementType() string {
+	return adaptivecard.TypeElementColumnSet
+}
+
+// Validate asserts that the element has valid values.
+func (cs ColumnSet) Validate() error {
+	return validate(cs)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (cs ColumnSet) MarshalJSON() ([]byte, error) {
+	return marshal(cs)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (cs *ColumnSet) UnmarshalJSON(data []byte) error {
+	ce, err := unmarshal(data, adaptivecard.TypeElementColumnSet)
+	if err != nil {
+		return err
+	}
+
+	*cs = ce.(ColumnSet)
+
+	return nil
+}
+
+// ElementType returns the Adaptive Card type of the element.
+func (fs FactSet) ElementType() string {
+	return adaptivecard.TypeElementFactSet
+}
+
+// Validate asserts that the element has valid values.
+func (fs FactSet) Validate() error {
+	return validate(fs)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (fs FactSet) MarshalJSON() ([]byte, error) {
+	return marshal(fs)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (fs *FactSet) UnmarshalJSON(data []byte) error {
+	ce, err := unmarshal(data, adaptivecard.TypeElementFactSet)
+	if err != nil {
+		return err
+	}
+
+	*fs = ce.(FactSet)
+
+	return nil
+}
+
+// ElementType returns the Adaptive Card type of the element.
+func (t Table) ElementType() string {
+	return adaptivecard.TypeElementTable
+}
+
+// Validate asserts that the element has valid values.
+func (t Table) Validate() error {
+	return validate(t)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (t Table) MarshalJSON() ([]byte, error) {
+	return marshal(t)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (t *Table) UnmarshalJSON(data []byte) error {
+	ce, err := unmarshal(data, adaptivecard.TypeElementTable)
+	if err != nil {
+		return err
+	}
+
+	*t = ce.(Table)
+
+	return nil
+}
+
+// ElementType returns the Adaptive Card type of the element.
+func (as ActionSet) ElementType() string {
+	return adaptivecard.TypeElementActionSet
+}
+
+// Validate asserts that the element has valid values.
+func (as ActionSet) Validate() error {
+	return validate(as)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (as ActionSet) MarshalJSON() ([]byte, error) {
+	return marshal(as)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (as *ActionSet) UnmarshalJSON(data []byte) error {
+	ce, err := unmarshal(data, adaptivecard.TypeElementActionSet)
+	if err != nil {
+		return err
+	}
+
+	*as = ce.(ActionSet)
+
+	return nil
+}
+
+// ElementType returns the Adaptive Card type of the element.
+func (it InputText) ElementType() string {
+	return adaptivecard.TypeElementInputText
+}
+
+// Validate asserts that the element has valid values.
+func (it InputText) Validate() error {
+	return validate(it)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (it InputText) MarshalJSON() ([]byte, error) {
+	return marshal(it)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (it *InputText) UnmarshalJSON(data []byte) error {
+	ce, err := unmarshal(data, adaptivecard.TypeElementInputText)
+	if err != nil {
+		return err
+	}
+
+	*it = ce.(InputText)
+
+	return nil
+}
+
+// ElementType returns the Adaptive Card type of the element.
+func (in InputNumber) ElementType() string {
+	return adaptivecard.TypeElementInputNumber
+}
+
+// Validate asserts that the element has valid values.
+func (in InputNumber) Validate() error {
+	return validate(in)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (in InputNumber) MarshalJSON() ([]byte, error) {
+	return marshal(in)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (in *InputNumber) UnmarshalJSON(data []byte) error {
+	ce, err := unmarshal(data, adaptivecard.TypeElementInputNumber)
+	if err != nil {
+		return err
+	}
+
+	*in = ce.(InputNumber)
+
+	return nil
+}
+
+// ElementType returns the Adaptive Card type of the element.
+func (id InputDate) ElementType() string {
+	return adaptivecard.TypeElementInputDate
+}
+
+// Validate asserts that the element has valid values.
+func (id InputDate) Validate() error {
+	return validate(id)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (id InputDate) MarshalJSON() ([]byte, error) {
+	return marshal(id)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (id *InputDate) UnmarshalJSON(data []byte) error {
+	ce, err := unmarshal(data, adaptivecard.TypeElementInputDate)
+	if err != nil {
+		return err
+	}
+
+	*id = ce.(InputDate)
+
+	return nil
+}
+
+// ElementType returns the Adaptive Card type of the element.
+func (it InputTime) ElementType() string {
+	return adaptivecard.TypeElementInputTime
+}
+
+// Validate asserts that the element has valid values.
+func (it InputTime) Validate() error {
+	return validate(it)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (it InputTime) MarshalJSON() ([]byte, error) {
+	return marshal(it)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (it *InputTime) UnmarshalJSON(data []byte) error {
+	ce, err := unmarshal(data, adaptivecard.TypeElementInputTime)
+	if err != nil {
+		return err
+	}
+
+	*it = ce.(InputTime)
+
+	return nil
+}
+
+// ElementType returns the Adaptive Card type of the element.
+func (it InputToggle) ElementType() string {
+	return adaptivecard.TypeElementInputToggle
+}
+
+// Validate asserts that the element has valid values.
+func (it InputToggle) Validate() error {
+	return validate(it)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (it InputToggle) MarshalJSON() ([]byte, error) {
+	return marshal(it)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (it *InputToggle) UnmarshalJSON(data []byte) error {
+	ce, err := unmarshal(data, adaptivecard.TypeElementInputToggle)
+	if err != nil {
+		return err
+	}
+
+	*it = ce.(InputToggle)
+
+	return nil
+}
+
+// ElementType returns the Adaptive Card type of the element.
+func (ics InputChoiceSet) ElementType() string {
+	return adaptivecard.TypeElementInputChoiceSet
+}
+
+// Validate asserts that the element has valid values.
+func (ics InputChoiceSet) Validate() error {
+	return validate(ics)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (ics InputChoiceSet) MarshalJSON() ([]byte, error) {
+	return marshal(ics)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (ics *InputChoiceSet) UnmarshalJSON(data []byte) error {
+	ce, err := unmarshal(data, adaptivecard.TypeElementInputChoiceSet)
+	if err != nil {
+		return err
+	}
+
+	*ics = ce.(InputChoiceSet)
+
+	return nil
+}
@@ -0,0 +1,571 @@
+// Copyright 2024 Adam Chalkley
+//
+// https://github.com/atc0005/go-teams-notify
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package elements
+
+import (
+	"github.com/flashcatcloud/go-teams-notify/v2/adaptivecard"
+)
+
+// TextBlock displays text, allowing control over font sizes, weight and
+// color.
+//
+// https://adaptivecards.io/explorer/TextBlock.html
+type TextBlock struct {
+	CommonProperties
+
+	// Text is required; the text to display. A subset of markdown is
+	// supported.
+	Text string
+
+	// Size controls the size of the text.
+	Size string
+
+	// Weight controls the weight of the text.
+	Weight string
+
+	// Color controls the color of the text.
+	Color string
+
+	// FontType controls the type of font to use for rendering.
+	FontType string
+
+	// HorizontalAlignment controls the horizontal text alignment.
+	HorizontalAlignment string
+
+	// Style controls the style of the text; "default" or "heading".
+	Style string
+
+	// IsSubtle displays the text slightly toned down if true.
+	IsSubtle bool
+
+	// Wrap allows the text to wrap if true.
+	Wrap bool
+}
+
+// RichTextBlock displays a collection of TextRun values, each of which may
+// be individually styled.
+//
+// https://adaptivecards.io/explorer/RichTextBlock.html
+type RichTextBlock struct {
+	CommonProperties
+
+	// Inlines is required; the collection of TextRun values to display.
+	Inlines []TextRun
+
+	// HorizontalAlignment controls the horizontal text alignment.
+	HorizontalAlignment string
+}
+
+// TextRun is a run of text within a RichTextBlock. A TextRun is not a
+// CardElement; it is only valid within RichTextBlock.Inlines.
+//
+// https://adaptivecards.io/explorer/TextRun.html
+type TextRun struct {
+	// Text is required; the text to display. Markdown is not supported.
+	Text string
+
+	// Size controls the size of the text.
+	Size string
+
+	// Weight controls the weight of the text.
+	Weight string
+
+	// Color controls the color of the text.
+	Color string
+
+	// FontType controls the type of font to use for rendering.
+	FontType string
+
+	// IsSubtle displays the text slightly toned down if true.
+	IsSubtle bool
+
+	// Italic displays the text using italic font if true.
+	Italic bool
+
+	// Strikethrough displays the text with a strikethrough if true.
+	Strikethrough bool
+
+	// Underline displays the text underlined if true.
+	Underline bool
+
+	// Highlight displays the text highlighted if true.
+	Highlight bool
+
+	// SelectAction is invoked when the text is tapped or selected.
+	SelectAction *adaptivecard.ISelectAction
+}
+
+// Image displays an image.
+//
+// https://adaptivecards.io/explorer/Image.html
+type Image struct {
+	CommonProperties
+
+	// URL is required; the URL (or data URI) of the image.
+	URL string
+
+	// AltText is the alternate text describing the image.
+	AltText string
+
+	// BackgroundColor is applied behind the image to fill in transparent
+	// areas.
+	BackgroundColor string
+
+	// Size controls the approximate size of the image.
+	Size string
+
+	// Style controls how the image is displayed; "default" or "person".
+	Style string
+
+	// Width is the desired width of the image in pixels (e.g., "50px").
+	Width string
+
+	// HorizontalAlignment controls how the image is horizontally
+	// positioned.
+	HorizontalAlignment string
+
+	// SelectAction is invoked when the image is tapped or selected.
+	SelectAction *adaptivecard.ISelectAction
+}
+
+// ImageSet displays a collection of images similar to a gallery.
+//
+// https://adaptivecards.io/explorer/ImageSet.html
+type ImageSet struct {
+	CommonProperties
+
+	// Images is required; the collection of images to display.
+	Images []Image
+
+	// ImageSize controls the approximate size of each image.
+	ImageSize string
+}
+
+// Media displays a media player for audio or video content.
+//
+// https://adaptivecards.io/explorer/Media.html
+type Media struct {
+	CommonProperties
+
+	// Sources is required; the collection of media sources to choose from.
+	Sources []adaptivecard.MediaSource
+
+	// Poster is the URL of an image displayed before the media is played.
+	Poster string
+
+	// AltText is the alternate text describing the media.
+	AltText string
+
+	// CaptionSources is a collection of caption sources for the media.
+	CaptionSources []adaptivecard.CaptionSource
+}
+
+// Container groups items together.
+//
+// https://adaptivecards.io/explorer/Container.html
+type Container struct {
+	CommonProperties
+
+	// Items is required; the elements to render within the container.
+	Items []CardElement
+
+	// Style controls the style of the container.
+	Style string
+
+	// Bleed determines whether the container should bleed through its
+	// parent's padding.
+	Bleed bool
+
+	// MinHeight specifies the minimum height of the container in pixels
+	// (e.g., "80px").
+	MinHeight string
+
+	// VerticalContentAlignment controls how the content is vertically
+	// positioned within the container.
+	VerticalContentAlignment string
+
+	// BackgroundImage specifies the background image of the container.
+	BackgroundImage *adaptivecard.BackgroundImage
+
+	// RTL controls the text direction of the content. Unset inherits the
+	// direction of the parent.
+	RTL *bool
+
+	// SelectAction is invoked when the container is tapped or selected.
+	SelectAction *adaptivecard.ISelectAction
+}
+
+// ColumnSet divides a region into columns.
+//
+// https://adaptivecards.io/explorer/ColumnSet.html
+type ColumnSet struct {
+	CommonProperties
+
+	// Columns is the collection of columns to divide the region into.
+	Columns []Column
+
+	// Style controls the style of the column set.
+	Style string
+
+	// Bleed determines whether the column set should bleed through its
+	// parent's padding.
+	Bleed bool
+
+	// MinHeight specifies the minimum height of the column set in pixels
+	// (e.g., "80px").
+	MinHeight string
+
+	// HorizontalAlignment controls how the content is horizontally
+	// positioned.
+	HorizontalAlignment string
+
+	// SelectAction is invoked when the column set is tapped or selected.
+	SelectAction *adaptivecard.ISelectAction
+}
+
+// Column is a column within a ColumnSet. A Column is not a CardElement; it
+// is only valid within ColumnSet.Columns.
+//
+// https://adaptivecards.io/explorer/Column.html
+type Column struct {
+	// ID is a unique identifier associated with this column.
+	ID string
+
+	// Width is either "auto", "stretch", a weight (e.g., 1) or a specific
+	// width in pixels (e.g., "50px").
+	Width interface{}
+
+	// Items is the collection of elements to render within the column.
+	Items []CardElement
+
+	// Style controls the style of the column.
+	Style string
+
+	// Spacing controls the amount of spacing between this column and the
+	// preceding column.
+	Spacing string
+
+	// Separator, when true, indicates that a separating line should be
+	// drawn at the left of the column.
+	Separator bool
+
+	// Visible specifies whether this column will be removed from the visual
+	// tree. Unset is treated as visible.
+	Visible *bool
+
+	// Fallback is either "drop" or a replacement Column rendered by clients
+	// which do not support this column.
+	Fallback interface{}
+
+	// Bleed determines whether the column should bleed through its parent's
+	// padding.
+	Bleed bool
+
+	// MinHeight specifies the minimum height of the column in pixels (e.g.,
+	// "80px").
+	MinHeight string
+
+	// VerticalContentAlignment controls how the content is vertically
+	// positioned within the column.
+	VerticalContentAlignment string
+
+	// BackgroundImage specifies the background image of the column.
+	BackgroundImage *adaptivecard.BackgroundImage
+
+	// RTL controls the text direction of the content. Unset inherits the
+	// direction of the parent.
+	RTL *bool
+
+	// SelectAction is invoked when the column is tapped or selected.
+	SelectAction *adaptivecard.ISelectAction
+}
+
+// FactSet displays a series of facts (i.e., name/value pairs) in a tabular
+// form.
+//
+// https://adaptivecards.io/explorer/FactSet.html
+type FactSet struct {
+	CommonProperties
+
+	// Facts is required; the collection of facts to display.
+	Facts []adaptivecard.Fact
+}
+
+// Table displays data in a tabular form.
+//
+// https://adaptivecards.io/explorer/Table.html
+type Table struct {
+	CommonProperties
+
+	// Columns defines the number of columns in the table and their
+	// characteristics.
+	Columns []TableColumnDefinition
+
+	// Rows defines the rows of the table.
+	Rows []TableRow
+
+	// FirstRowAsHeaders specifies whether the first row should be treated
+	// as a header row. Unset is treated as true.
+	FirstRowAsHeaders *bool
+
+	// ShowGridLines specifies whether grid lines should be displayed. Unset
+	// is treated as true.
+	ShowGridLines *bool
+
+	// GridStyle defines the style of the grid.
+	GridStyle string
+}
+
+// TableColumnDefinition defines the characteristics of a column in a Table.
+//
+// https://adaptivecards.io/explorer/TableColumnDefinition.html
+type TableColumnDefinition struct {
+	// Width is either a weight (e.g., 1) or a specific width in pixels
+	// (e.g., "50px").
+	Width interface{}
+
+	// HorizontalCellContentAlignment controls how the content of all cells
+	// in the column is horizontally aligned by default.
+	HorizontalCellContentAlignment string
+
+	// VerticalCellContentAlignment controls how the content of all cells in
+	// the column is vertically aligned by default.
+	VerticalCellContentAlignment string
+}
+
+// TableRow is a row within a Table.
+//
+// https://adaptivecards.io/explorer/TableRow.html
+type TableRow struct {
+	// Cells is the collection of cells in the row.
+	Cells []TableCell
+
+	// Style defines the style of the entire row.
+	Style string
+
+	// HorizontalCellContentAlignment controls how the content of all cells
+	// in the row is horizontally aligned by default.
+	HorizontalCellContentAlignment string
+
+	// VerticalCellContentAlignment controls how the content of all cells in
+	// the row is vertically aligned by default.
+	VerticalCellContentAlignment string
+}
+
+// TableCell is a cell within a TableRow.
+//
+// https://adaptivecards.io/explorer/TableCell.html
+type TableCell struct {
+	// Items is required; the elements to render within the cell.
+	Items []CardElement
+
+	// Style controls the style of the cell.
+	Style string
+
+	// Bleed determines whether the cell should bleed through its parent's
+	// padding.
+	Bleed bool
+
+	// MinHeight specifies the minimum height of the cell in pixels (e.g.,
+	// "80px").
+	MinHeight string
+
+	// VerticalContentAlignment controls how the content is vertically
+	// positioned within the cell.
+	VerticalContentAlignment string
+}
+
+// ActionSet displays a set of actions.
+//
+// https://adaptivecards.io/explorer/ActionSet.html
+type ActionSet struct {
+	CommonProperties
+
+	// Actions is required; the collection of actions to display.
+	Actions []adaptivecard.Action
+}
+
+// InputText lets a user enter text.
+//
+// https://adaptivecards.io/explorer/Input.Text.html
+type InputText struct {
+	CommonProperties
+
+	// Label describes the input.
+	Label string
+
+	// Placeholder is the text displayed when no value has been entered.
+	Placeholder string
+
+	// Value is the initial value.
+	Value string
+
+	// IsRequired indicates whether a value is required.
+	IsRequired bool
+
+	// ErrorMessage is displayed when the entered value is invalid.
+	ErrorMessage string
+
+	// MaxLength is the maximum length of the value; 0 is unlimited.
+	MaxLength int
+
+	// Regex is a regular expression the value is required to match.
+	Regex string
+
+	// IsMultiline allows multiple lines of input if true.
+	IsMultiline bool
+
+	// Style is a hint for the style of keyboard to use (e.g., "email").
+	Style string
+}
+
+// InputNumber lets a user enter a number.
+//
+// https://adaptivecards.io/explorer/Input.Number.html
+type InputNumber struct {
+	CommonProperties
+
+	// Label describes the input.
+	Label string
+
+	// Placeholder is the text displayed when no value has been entered.
+	Placeholder string
+
+	// Value is the initial value.
+	Value *float64
+
+	// Min is the minimum value allowed.
+	Min *float64
+
+	// Max is the maximum value allowed.
+	Max *float64
+
+	// IsRequired indicates whether a value is required.
+	IsRequired bool
+
+	// ErrorMessage is displayed when the entered value is invalid.
+	ErrorMessage string
+}
+
+// InputDate lets a user choose a date. Dates use the YYYY-MM-DD format (see
+// adaptivecard.InputDateFormat).
+//
+// https://adaptivecards.io/explorer/Input.Date.html
+type InputDate struct {
+	CommonProperties
+
+	// Label describes the input.
+	Label string
+
+	// Placeholder is the text displayed when no value has been entered.
+	Placeholder string
+
+	// Value is the initial value.
+	Value string
+
+	// Min is the earliest date allowed.
+	Min string
+
+	// Max is the latest date allowed.
+	Max string
+
+	// IsRequired indicates whether a value is required.
+	IsRequired bool
+
+	// ErrorMessage is displayed when the entered value is invalid.
+	ErrorMessage string
+}
+
+// InputTime lets a user select a time. Times use the HH:MM format (see
+// adaptivecard.InputTimeFormat).
+//
+// https://adaptivecards.io/explorer/Input.Time.html
+type InputTime struct {
+	CommonProperties
+
+	// Label describes the input.
+	Label string
+
+	// Placeholder is the text displayed when no value has been entered.
+	Placeholder string
+
+	// Value is the initial value.
+	Value string
+
+	// Min is the earliest time allowed.
+	Min string
+
+	// Max is the latest time allowed.
+	Max string
+
+	// IsRequired indicates whether a value is required.
+	IsRequired bool
+
+	// ErrorMessage is displayed when the entered value is invalid.
+	ErrorMessage string
+}
+
+// InputToggle lets a user choose between two options.
+//
+// https://adaptivecards.io/explorer/Input.Toggle.html
+type InputToggle struct {
+	CommonProperties
+
+	// Title is required; the text displayed next to the toggle.
+	Title string
+
+	// Label describes the input.
+	Label string
+
+	// Value is the initial value; either ValueOn or ValueOff.
+	Value string
+
+	// ValueOn is the value when the toggle is on; defaults to "true".
+	ValueOn string
+
+	// ValueOff is the value when the toggle is off; defaults to "false".
+	ValueOff string
+
+	// IsRequired indicates whether a value is required.
+	IsRequired bool
+
+	// ErrorMessage is displayed when the entered value is invalid.
+	ErrorMessage string
+}
+
+// InputChoiceSet lets a user choose from a collection of choices.
+//
+// https://adaptivecards.io/explorer/Input.ChoiceSet.html
+type InputChoiceSet struct {
+	CommonProperties
+
+	// Label describes the input.
+	Label string
+
+	// Placeholder is the text displayed when no choice has been made.
+	Placeholder string
+
+	// Choices is the collection of choices.
+	Choices []adaptivecard.Choice
+
+	// IsMultiSelect allows multiple choices to be made if true.
+	IsMultiSelect bool
+
+	// Style controls how the choices are displayed; "compact" or
+	// "expanded".
+	Style string
+
+	// Value is the initial choice (or comma separated choices).
+	Value string
+
+	// IsRequired indicates whether a value is required.
+	IsRequired bool
+
+	// ErrorMessage is displayed when the entered value is invalid.
+	ErrorMessage string
+}
@@ -0,0 +1,153 @@
+// Copyright 2024 Adam Chalkley
+//
+// https://github.com/atc0005/go-teams-notify
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package adaptivecard
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fallbackChain returns a TextBlock element with a chain of TextBlock
+// fallbacks of the given length.
+func fallbackChain(length int) Element {
+	element := NewTextBlock("innermost", false)
+
+	for i := 0; i < length; i++ {
+		fallback := element
+
+		element = NewTextBlock("fallback", false)
+		element.Fallback = &fallback
+	}
+
+	return element
+}
+
+func TestElementSetFallback(t *testing.T) {
+	table := Element{Type: TypeElementTable}
+
+	facts := Element{Type: TypeElementFactSet, Facts: []Fact{{Title: "a", Value: "b"}}}
+	assert.NoError(t, table.SetFallback(facts))
+	assert.Equal(t, &facts, table.Fallback)
+
+	assert.Error(t, table.SetFallback(NewTextRun("inline")))
+	assert.Error(t, table.SetFallback(Element{Type: TypeElementImage}))
+
+	table.SetFallbackDrop()
+	assert.Equal(t, TypeFallbackOptionDrop, table.Fallback)
+	assert.NoError(t, table.Validate())
+}
+
+func TestElementFallbackValidation(t *testing.T) {
+	var decoded interface{}
+	mustNoError(t, json.Unmarshal([]byte(`{"type":"TextBlock","text":"fallback"}`), &decoded))
+
+	tests := map[string]struct {
+		fallback interface{}
+		wantErr  error
+	}{
+		"drop":                 {fallback: TypeFallbackOptionDrop},
+		"element":              {fallback: NewTextBlock("fallback", false)},
+		"element pointer":      {fallback: &Element{Type: TypeElementTextBlock, Text: "fallback"}},
+		"decoded from JSON":    {fallback: decoded},
+		"unknown option":       {fallback: "hide", wantErr: ErrInvalidFieldValue},
+		"unsupported type":     {fallback: 42, wantErr: ErrInvalidFieldValue},
+		"invalid element":      {fallback: Element{Type: TypeElementImage}, wantErr: ErrMissingValue},
+		"inline element":       {fallback: NewTextRun("inline"), wantErr: ErrInvalidType},
+		"chain at depth limit": {fallback: fallbackChain(FallbackMaxNestingDepth - 1)},
+		"chain over depth limit": {
+			fallback: fallbackChain(FallbackMaxNestingDepth),
+			wantErr:  ErrInvalidFieldValue,
+		},
+	}
+
+	for name, tt := range tests {
+		name, tt := name, tt
+
+		t.Run(name, func(t *testing.T) {
+			element := NewTextBlock("primary", false)
+			element.Fallback = tt.fallback
+
+			err := element.Validate()
+
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.True(t, errors.Is(err, tt.wantErr), "got error: %v", err)
+		})
+	}
+}
+
+func TestElementFallbackCycles(t *testing.T) {
+	element := NewTextBlock("loop", false)
+	element.Fallback = &element
+	assert.True(t, errors.Is(element.Validate(), ErrInvalidFieldValue))
+
+	// A fallback whose child element falls back to the fallback.
+	container := Element{Type: TypeElementContainer}
+	child := NewTextBlock("child", false)
+	child.Fallback = &container
+	container.Items = []Element{child}
+
+	primary := NewTextBlock("primary", false)
+	primary.Fallback = &container
+	assert.True(t, errors.Is(primary.Validate(), ErrInvalidFieldValue))
+
+	column := Column{Type: TypeColumn}
+	column.Fallback = &column
+	assert.True(t, errors.Is(column.Validate(), ErrInvalidFieldValue))
+}
+
+func TestRequiresValidation(t *testing.T) {
+	tests := map[string]struct {
+		requires map[string]string
+		wantErr  error
+	}{
+		"any version":      {requires: map[string]string{"adaptiveCards": RequiresAnyVersion}},
+		"specific version": {requires: map[string]string{"adaptiveCards": "1.5"}},
+		"empty feature":    {requires: map[string]string{" ": "1.0"}, wantErr: ErrMissingValue},
+		"invalid version":  {requires: map[string]string{"adaptiveCards": "latest"}, wantErr: ErrInvalidFieldValue},
+	}
+
+	for name, tt := range tests {
+		name, tt := name, tt
+
+		t.Run(name, func(t *testing.T) {
+			element := NewTextBlock("text", false)
+			element.Requires = tt.requires
+
+			card := NewCard()
+			card.Requires = tt.requires
+
+			if tt.wantErr == nil {
+				assert.NoError(t, element.Validate())
+				assert.NoError(t, card.Validate())
+				return
+			}
+
+			assert.True(t, errors.Is(element.Validate(), tt.wantErr))
+			assert.True(t, errors.Is(card.Validate(), tt.wantErr))
+		})
+	}
+}
+
+func TestActionFallbackValidation(t *testing.T) {
+	action := Action{Type: TypeActionOpenURL, URL: "https://example.com"}
+
+	for _, fallback := range []string{TypeFallbackOptionDrop, TypeFallbackActionSubmit} {
+		action.Fallback = fallback
+		assert.NoError(t, action.Validate())
+	}
+
+	action.Fallback = "Action.Unknown"
+	assert.True(t, errors.Is(action.Validate(), ErrInvalidFieldValue))
+}
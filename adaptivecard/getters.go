@@ -28,7 +28,7 @@ func supportedElementTypes() []string {
 		TypeElementInputText,
 		TypeElementInputTime,
 		TypeElementInputToggle,
-		TypeElementMedia, // Introduced in version 1.1 (TODO: Is this supported in Teams message?)
+		TypeElementMedia, // Introduced in version 1.1 (see Element.Warnings for Teams client support)
 		TypeElementRichTextBlock,
 		TypeElementTable, // Introduced in version 1.5
 		TypeElementTextBlock,
@@ -36,10 +36,48 @@ func supportedElementTypes() []string {
 	}
 }
 
-// supportedSizeValues returns a list of valid Size values for applicable
-// Element types. This list is intended to be used for validation and display
+// elementMinCardVersion returns the Adaptive Card schema version in which
+// the specified element type was introduced. This value is intended to be
+// used for validation purposes.
+func elementMinCardVersion(elementType string) float64 {
+	// https://adaptivecards.io/explorer/
+	switch elementType {
+	case TypeElementMedia:
+		return 1.1
+	case TypeElementActionSet, TypeElementRichTextBlock, TypeElementTextRun:
+		return 1.2
+	case TypeElementTable:
+		return 1.5
+	default:
+		return AdaptiveCardMinVersion
+	}
+}
+
+// actionMinCardVersion returns the Adaptive Card schema version in which the
+// specified Action type was introduced. This value is intended to be used for
+// validation purposes.
+//
+// NOTE: See also the supportedActionValues() function.
+func actionMinCardVersion(actionType string) float64 {
+	// https://adaptivecards.io/explorer/
+	switch actionType {
+	case TypeActionToggleVisibility:
+		return 1.2
+	case TypeActionExecute:
+		return ActionExecuteMinCardVersionRequired
+	default:
+		return AdaptiveCardMinVersion
+	}
+}
+
+// supportedSizeValues returns a list of valid Size values for the specified
+// element type. This list is intended to be used for validation and display
 // purposes.
-func supportedSizeValues() []string {
+func supportedSizeValues(elementType string) []string {
+	if elementType == TypeElementImage {
+		return supportedImageSizeValues()
+	}
+
 	// https://adaptivecards.io/explorer/TextBlock.html
 	return []string{
 		SizeSmall,
@@ -50,6 +88,20 @@ func supportedSizeValues() []string {
 	}
 }
 
+// supportedImageSizeValues returns a list of valid Size values for the Image
+// element type and ImageSize values for the ImageSet element type. This list
+// is intended to be used for validation and display purposes.
+func supportedImageSizeValues() []string {
+	// https://adaptivecards.io/explorer/Image.html
+	return []string{
+		ImageSizeAuto,
+		ImageSizeStretch,
+		ImageSizeSmall,
+		ImageSizeMedium,
+		ImageSizeLarge,
+	}
+}
+
 // supportedWeightValues returns a list of valid Weight values for text in
 // applicable Element types. This list is intended to be used for validation
 // and display purposes.
@@ -62,6 +114,17 @@ func supportedWeightValues() []string {
 	}
 }
 
+// supportedFontTypeValues returns a list of valid FontType values for text in
+// applicable Element types. This list is intended to be used for validation
+// and display purposes.
+func supportedFontTypeValues() []string {
+	// https://adaptivecards.io/explorer/TextBlock.html
+	return []string{
+		FontTypeDefault,
+		FontTypeMonospace,
+	}
+}
+
 // supportedColorValues returns a list of valid Color values for text in
 // applicable Element types. This list is intended to be used for validation
 // and display purposes.
@@ -108,6 +171,30 @@ func supportedHorizontalAlignmentValues() []string {
 	}
 }
 
+// supportedHeightValues returns a list of valid Height values for Element
+// types. This list is intended to be used for validation and display
+// purposes.
+func supportedHeightValues() []string {
+	// https://adaptivecards.io/explorer/Container.html
+	return []string{
+		HeightAuto,
+		HeightStretch,
+	}
+}
+
+// supportedFillModeValues returns a list of valid FillMode values for the
+// BackgroundImage type. This list is intended to be used for validation and
+// display purposes.
+func supportedFillModeValues() []string {
+	// https://adaptivecards.io/explorer/BackgroundImage.html
+	return []string{
+		FillModeCover,
+		FillModeRepeatHorizontally,
+		FillModeRepeatVertically,
+		FillModeRepeat,
+	}
+}
+
 // supportedVerticalAlignmentValues returns a list of valid vertical content
 // alignment values for supported container types. This list is intended to be
 // used for validation and display purposes.
@@ -137,9 +224,9 @@ func supportedActionValues(version float64) []string {
 		TypeActionToggleVisibility,
 
 		// Action.Submit is not supported for Adaptive Cards in Incoming
-		// Webhooks.
-		//
-		// TypeActionSubmit,
+		// Webhooks, but is supported for cards delivered by other means
+		// (e.g., bots).
+		TypeActionSubmit,
 	}
 
 	// Version 1.4 is when Action.Execute was introduced.
@@ -176,9 +263,9 @@ func supportedISelectActionValues(version float64) []string {
 		TypeActionToggleVisibility,
 
 		// Action.Submit is not supported for Adaptive Cards in Incoming
-		// Webhooks.
-		//
-		// TypeActionSubmit,
+		// Webhooks, but is supported for cards delivered by other means
+		// (e.g., bots).
+		TypeActionSubmit,
 
 		// Action.ShowCard is not a supported Action for selectAction fields
 		// (ISelectAction).
@@ -204,6 +291,40 @@ func supportedISelectActionValues(version float64) []string {
 	return supportedValues
 }
 
+// supportedActionStyleValues returns a list of valid Style field values for
+// Action types. This list is intended to be used for validation and display
+// purposes.
+func supportedActionStyleValues() []string {
+	// https://adaptivecards.io/explorer/Action.Execute.html
+	return []string{
+		ActionStyleDefault,
+		ActionStylePositive,
+		ActionStyleDestructive,
+	}
+}
+
+// supportedActionModeValues returns a list of valid Mode field values for
+// Action types. This list is intended to be used for validation and display
+// purposes.
+func supportedActionModeValues() []string {
+	// https://adaptivecards.io/explorer/Action.Execute.html
+	return []string{
+		ActionModePrimary,
+		ActionModeSecondary,
+	}
+}
+
+// supportedAssociatedInputsValues returns a list of valid AssociatedInputs
+// field values for the Action.Execute and Action.Submit types. This list is
+// intended to be used for validation and display purposes.
+func supportedAssociatedInputsValues() []string {
+	// https://adaptivecards.io/explorer/Action.Execute.html
+	return []string{
+		AssociatedInputsAuto,
+		AssociatedInputsNone,
+	}
+}
+
 // supportedAttachmentLayoutValues returns a list of valid AttachmentLayout
 // values for Message type. This list is intended to be used for validation
 // and display purposes.
@@ -300,6 +421,17 @@ func supportedContainerStyleValues() []string {
 	}
 }
 
+// supportedMentionedTypeValues returns a list of valid non-empty Type field
+// values for the Mentioned type. This list is intended to be used for
+// validation and display purposes.
+func supportedMentionedTypeValues() []string {
+	return []string{
+		MentionedTypeTag,
+		MentionedTypeChannel,
+		MentionedTypeTeam,
+	}
+}
+
 // supportedMSTeamsWidthValues returns a list of valid Width field values for
 // MSTeams type. This list is intended to be used for validation and display
 // purposes.
@@ -0,0 +1,169 @@
+// Copyright 2024 Adam Chalkley
+//
+// https://github.com/atc0005/go-teams-notify
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package adaptivecard
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewImage(t *testing.T) {
+	image, err := NewImage("https://example.com/logo.png", "Logo")
+	if assert.NoError(t, err) {
+		assert.Equal(t, TypeElementImage, image.Type)
+		assert.Equal(t, "https://example.com/logo.png", image.URL)
+		assert.Equal(t, "Logo", image.AltText)
+	}
+
+	_, err = NewImage("", "Logo")
+	assert.True(t, errors.Is(err, ErrMissingValue))
+
+	_, err = NewImage("ftp://example.com/logo.png", "Logo")
+	assert.True(t, errors.Is(err, ErrInvalidFieldValue))
+}
+
+func TestNewImageFromData(t *testing.T) {
+	image, err := NewImageFromData("image/png", []byte("png"), "Graph")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "data:image/png;base64,cG5n", image.URL)
+		assert.True(t, strings.HasPrefix(image.URL, ImageDataURIPrefix))
+		assert.Equal(t, "Graph", image.AltText)
+	}
+
+	_, err = NewImageFromData("image/png", nil, "Graph")
+	assert.True(t, errors.Is(err, ErrMissingValue))
+
+	_, err = NewImageFromData("text/plain", []byte("png"), "Graph")
+	assert.True(t, errors.Is(err, ErrInvalidFieldValue))
+}
+
+func TestNewImageSet(t *testing.T) {
+	first, err := NewImage("https://example.com/1.png", "")
+	mustNoError(t, err)
+
+	second, err := NewImage("https://example.com/2.png", "")
+	mustNoError(t, err)
+
+	imageSet, err := NewImageSet(ImageSizeSmall, first, second)
+	if assert.NoError(t, err) {
+		assert.Equal(t, TypeElementImageSet, imageSet.Type)
+		assert.Equal(t, ImageSizeSmall, imageSet.ImageSize)
+		assert.Len(t, imageSet.Images, 2)
+	}
+
+	_, err = NewImageSet("")
+	assert.True(t, errors.Is(err, ErrMissingValue))
+
+	_, err = NewImageSet("huge", first)
+	assert.True(t, errors.Is(err, ErrInvalidFieldValue))
+
+	_, err = NewImageSet("", NewTextBlock("not an image", false))
+	assert.True(t, errors.Is(err, ErrInvalidType))
+
+	assert.NoError(t, imageSet.AddImage(first))
+	assert.Len(t, imageSet.Images, 3)
+	assert.True(t, errors.Is(imageSet.AddImage(), ErrMissingValue))
+	assert.True(t, errors.Is(imageSet.AddImage(NewTextBlock("text", false)), ErrInvalidType))
+
+	textBlock := NewTextBlock("text", false)
+	assert.True(t, errors.Is(textBlock.AddImage(first), ErrInvalidType))
+}
+
+func TestImageValidation(t *testing.T) {
+	const imageURL = "https://example.com/logo.png"
+
+	tests := map[string]struct {
+		image   Element
+		wantErr error
+	}{
+		"url only": {
+			image: Element{Type: TypeElementImage, URL: imageURL},
+		},
+		"missing url": {
+			image:   Element{Type: TypeElementImage},
+			wantErr: ErrMissingValue,
+		},
+		"relative url": {
+			image:   Element{Type: TypeElementImage, URL: "/logo.png"},
+			wantErr: ErrInvalidFieldValue,
+		},
+		"malformed data uri": {
+			image:   Element{Type: TypeElementImage, URL: "data:image/png,notbase64"},
+			wantErr: ErrInvalidFieldValue,
+		},
+		"pixel width and height": {
+			image: Element{Type: TypeElementImage, URL: imageURL, Width: "50px", Height: "40px"},
+		},
+		"invalid width": {
+			image:   Element{Type: TypeElementImage, URL: imageURL, Width: "50%"},
+			wantErr: ErrInvalidFieldValue,
+		},
+		"auto height": {
+			image: Element{Type: TypeElementImage, URL: imageURL, Height: ImageHeightAuto},
+		},
+		"stretch height": {
+			image: Element{Type: TypeElementImage, URL: imageURL, Height: ImageHeightStretch},
+		},
+		"invalid height": {
+			image:   Element{Type: TypeElementImage, URL: imageURL, Height: "tall"},
+			wantErr: ErrInvalidFieldValue,
+		},
+		"short background color": {
+			image: Element{Type: TypeElementImage, URL: imageURL, BackgroundColor: "#DDD"},
+		},
+		"background color with alpha": {
+			image: Element{Type: TypeElementImage, URL: imageURL, BackgroundColor: "#DDDDDD80"},
+		},
+		"invalid background color": {
+			image:   Element{Type: TypeElementImage, URL: imageURL, BackgroundColor: "grey"},
+			wantErr: ErrInvalidFieldValue,
+		},
+		"person style": {
+			image: Element{Type: TypeElementImage, URL: imageURL, Style: ImageStylePerson},
+		},
+		"invalid style": {
+			image:   Element{Type: TypeElementImage, URL: imageURL, Style: "round"},
+			wantErr: ErrInvalidFieldValue,
+		},
+		"image set with images": {
+			image: Element{
+				Type:   TypeElementImageSet,
+				Images: []Element{{Type: TypeElementImage, URL: imageURL}},
+			},
+		},
+		"image set without images": {
+			image:   Element{Type: TypeElementImageSet},
+			wantErr: ErrMissingValue,
+		},
+		"image set with invalid image": {
+			image: Element{
+				Type:   TypeElementImageSet,
+				Images: []Element{{Type: TypeElementImage, URL: imageURL, BackgroundColor: "grey"}},
+			},
+			wantErr: ErrInvalidFieldValue,
+		},
+	}
+
+	for name, tt := range tests {
+		name, tt := name, tt
+
+		t.Run(name, func(t *testing.T) {
+			err := tt.image.Validate()
+
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.True(t, errors.Is(err, tt.wantErr), "got error: %v", err)
+		})
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright 2024 Adam Chalkley
+//
+// https://github.com/atc0005/go-teams-notify
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package adaptivecard
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInputConstructors(t *testing.T) {
+	text, err := NewInputText("comment", "Comment")
+	if assert.NoError(t, err) {
+		assert.Equal(t, TypeElementInputText, text.Type)
+		assert.Equal(t, "comment", text.ID)
+		assert.Equal(t, "Comment", text.Label)
+	}
+
+	for _, newInput := range []func(id string, label string) (Element, error){
+		NewInputText,
+		NewInputNumber,
+		NewInputDate,
+		NewInputTime,
+		NewInputToggle,
+	} {
+		_, err := newInput("", "Label")
+		assert.True(t, errors.Is(err, ErrMissingValue))
+	}
+
+	_, err = NewInputToggle("approve", "")
+	assert.True(t, errors.Is(err, ErrMissingValue))
+
+	choiceSet, err := NewInputChoiceSet("env", "Environment", NewChoice("Production", "prod"))
+	if assert.NoError(t, err) && assert.Len(t, choiceSet.Choices, 1) {
+		assert.Equal(t, "prod", choiceSet.Choices[0].Value)
+	}
+
+	_, err = NewInputChoiceSet("env", "Environment", NewChoice("Production", ""))
+	assert.True(t, errors.Is(err, ErrMissingValue))
+}
+
+func TestInputValidation(t *testing.T) {
+	tests := map[string]struct {
+		input   Element
+		wantErr error
+	}{
+		"text with valid fields": {
+			input: Element{Type: TypeElementInputText, ID: "a", Value: "abc", MaxLength: 10, Regex: "^[a-z]+$"},
+		},
+		"text with non-string value": {
+			input:   Element{Type: TypeElementInputText, ID: "a", Value: 1},
+			wantErr: ErrInvalidFieldValue,
+		},
+		"text with negative max length": {
+			input:   Element{Type: TypeElementInputText, ID: "a", MaxLength: -1},
+			wantErr: ErrInvalidFieldValue,
+		},
+		"text with invalid regex": {
+			input:   Element{Type: TypeElementInputText, ID: "a", Regex: "("},
+			wantErr: ErrInvalidFieldValue,
+		},
+		"required without label": {
+			input:   Element{Type: TypeElementInputText, ID: "a", IsRequired: true},
+			wantErr: ErrMissingValue,
+		},
+		"required with label": {
+			input: Element{Type: TypeElementInputText, ID: "a", IsRequired: true, Label: "A", ErrorMessage: "A is required"},
+		},
+		"number within range": {
+			input: Element{Type: TypeElementInputNumber, ID: "a", Value: 5, Min: 1, Max: 10.5},
+		},
+		"number below min": {
+			input:   Element{Type: TypeElementInputNumber, ID: "a", Value: 0, Min: 1},
+			wantErr: ErrInvalidFieldValue,
+		},
+		"number min greater than max": {
+			input:   Element{Type: TypeElementInputNumber, ID: "a", Min: 10, Max: 1},
+			wantErr: ErrInvalidFieldValue,
+		},
+		"number with string value": {
+			input:   Element{Type: TypeElementInputNumber, ID: "a", Value: "5"},
+			wantErr: ErrInvalidFieldValue,
+		},
+		"date within range": {
+			input: Element{Type: TypeElementInputDate, ID: "a", Value: "2024-02-01", Min: "2024-01-01", Max: "2024-12-31"},
+		},
+		"date in wrong format": {
+			input:   Element{Type: TypeElementInputDate, ID: "a", Value: "02/01/2024"},
+			wantErr: ErrInvalidFieldValue,
+		},
+		"time after max": {
+			input:   Element{Type: TypeElementInputTime, ID: "a", Value: "18:00", Max: "17:00"},
+			wantErr: ErrInvalidFieldValue,
+		},
+		"toggle with custom values": {
+			input: Element{Type: TypeElementInputToggle, ID: "a", Title: "Approve", Value: "yes", ValueOn: "yes", ValueOff: "no"},
+		},
+		"toggle with unknown value": {
+			input:   Element{Type: TypeElementInputToggle, ID: "a", Title: "Approve", Value: "maybe"},
+			wantErr: ErrInvalidFieldValue,
+		},
+		"choice set with multiple values": {
+			input: Element{
+				Type:          TypeElementInputChoiceSet,
+				ID:            "a",
+				IsMultiSelect: true,
+				Value:         "x,y",
+				Choices:       []Choice{NewChoice("X", "x"), NewChoice("Y", "y")},
+			},
+		},
+		"choice set with multiple values without multi-select": {
+			input: Element{
+				Type:    TypeElementInputChoiceSet,
+				ID:      "a",
+				Value:   "x,y",
+				Choices: []Choice{NewChoice("X", "x"), NewChoice("Y", "y")},
+			},
+			wantErr: ErrInvalidFieldValue,
+		},
+		"choice set with unknown value": {
+			input: Element{
+				Type:    TypeElementInputChoiceSet,
+				ID:      "a",
+				Value:   "z",
+				Choices: []Choice{NewChoice("X", "x")},
+			},
+			wantErr: ErrInvalidFieldValue,
+		},
+		"input without ID": {
+			input:   Element{Type: TypeElementInputDate},
+			wantErr: ErrMissingValue,
+		},
+	}
+
+	for name, tt := range tests {
+		name, tt := name, tt
+
+		t.Run(name, func(t *testing.T) {
+			err := tt.input.Validate()
+
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.True(t, errors.Is(err, tt.wantErr), "got error: %v", err)
+		})
+	}
+}
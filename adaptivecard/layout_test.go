@@ -0,0 +1,198 @@
+// Copyright 2024 Adam Chalkley
+//
+// https://github.com/atc0005/go-teams-notify
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package adaptivecard
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBackgroundImage(t *testing.T) {
+	bg, err := NewBackgroundImage("https://example.com/bg.png", FillModeRepeat)
+	if assert.NoError(t, err) {
+		assert.Equal(t, FillModeRepeat, bg.FillMode)
+	}
+
+	_, err = NewBackgroundImage("", FillModeCover)
+	assert.True(t, errors.Is(err, ErrMissingValue))
+
+	_, err = NewBackgroundImage("https://example.com/bg.png", "tile")
+	assert.True(t, errors.Is(err, ErrInvalidFieldValue))
+
+	_, err = NewBackgroundImage("ftp://example.com/bg.png", FillModeCover)
+	assert.True(t, errors.Is(err, ErrInvalidFieldValue))
+}
+
+func TestLayoutValidation(t *testing.T) {
+	validBG := &BackgroundImage{
+		URL:                 "https://example.com/bg.png",
+		FillMode:            FillModeCover,
+		HorizontalAlignment: HorizontalAlignmentLeft,
+		VerticalAlignment:   VerticalAlignmentCenter,
+	}
+
+	tests := map[string]struct {
+		value   interface{ Validate() error }
+		wantErr error
+	}{
+		"container with layout fields": {
+			value: Element{
+				Type:                     TypeElementContainer,
+				Bleed:                    true,
+				Height:                   HeightStretch,
+				MinHeight:                "80px",
+				VerticalContentAlignment: VerticalAlignmentCenter,
+				BackgroundImage:          validBG,
+				Items:                    []Element{NewTextBlock("text", false)},
+			},
+		},
+		"container with pixel height": {
+			value:   Element{Type: TypeElementContainer, Height: "50px"},
+			wantErr: ErrInvalidFieldValue,
+		},
+		"image with pixel height": {
+			value: Element{Type: TypeElementImage, URL: "https://example.com/a.png", Height: "50px"},
+		},
+		"container with invalid min height": {
+			value:   Element{Type: TypeElementContainer, MinHeight: "tall", VerticalContentAlignment: VerticalAlignmentTop},
+			wantErr: ErrInvalidFieldValue,
+		},
+		"container min height without alignment": {
+			value:   Element{Type: TypeElementContainer, MinHeight: "80px"},
+			wantErr: ErrMissingValue,
+		},
+		"column set with invalid alignment": {
+			value:   Element{Type: TypeElementColumnSet, VerticalContentAlignment: "middle"},
+			wantErr: ErrInvalidFieldValue,
+		},
+		"container with invalid background fill mode": {
+			value: Element{
+				Type:            TypeElementContainer,
+				BackgroundImage: &BackgroundImage{URL: "https://example.com/bg.png", FillMode: "tile"},
+			},
+			wantErr: ErrInvalidFieldValue,
+		},
+		"card with layout fields": {
+			value: Card{
+				Type:                     TypeAdaptiveCard,
+				Schema:                   AdaptiveCardSchema,
+				Version:                  "1.5",
+				MinHeight:                "200px",
+				VerticalContentAlignment: VerticalAlignmentBottom,
+				BackgroundImage:          validBG,
+			},
+		},
+		"card with background image without URL": {
+			value: Card{
+				Type:            TypeAdaptiveCard,
+				Schema:          AdaptiveCardSchema,
+				Version:         "1.5",
+				BackgroundImage: &BackgroundImage{},
+			},
+			wantErr: ErrMissingValue,
+		},
+		"column with layout fields": {
+			value: Column{
+				Type:                     TypeColumn,
+				Style:                    ContainerStyleEmphasis,
+				Spacing:                  SpacingMedium,
+				Separator:                true,
+				Bleed:                    true,
+				MinHeight:                "80px",
+				VerticalContentAlignment: VerticalAlignmentTop,
+				BackgroundImage:          validBG,
+			},
+		},
+		"column with invalid style": {
+			value:   Column{Type: TypeColumn, Style: "loud"},
+			wantErr: ErrInvalidFieldValue,
+		},
+		"column with invalid spacing": {
+			value:   Column{Type: TypeColumn, Spacing: "huge"},
+			wantErr: ErrInvalidFieldValue,
+		},
+		"column min height without alignment": {
+			value:   Column{Type: TypeColumn, MinHeight: "80px"},
+			wantErr: ErrMissingValue,
+		},
+		"column with drop fallback": {
+			value: Column{Type: TypeColumn, Fallback: TypeFallbackOptionDrop},
+		},
+		"column with column fallback": {
+			value: Column{Type: TypeColumn, Fallback: &Column{Type: TypeColumn}},
+		},
+		"column with invalid column fallback": {
+			value:   Column{Type: TypeColumn, Fallback: Column{Type: TypeColumn, Style: "loud"}},
+			wantErr: ErrInvalidFieldValue,
+		},
+		"column with unknown fallback option": {
+			value:   Column{Type: TypeColumn, Fallback: "hide"},
+			wantErr: ErrInvalidFieldValue,
+		},
+		"column with element fallback": {
+			value:   Column{Type: TypeColumn, Fallback: NewTextBlock("fallback", false)},
+			wantErr: ErrInvalidFieldValue,
+		},
+	}
+
+	for name, tt := range tests {
+		name, tt := name, tt
+
+		t.Run(name, func(t *testing.T) {
+			err := tt.value.Validate()
+
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.True(t, errors.Is(err, tt.wantErr), "got error: %v", err)
+		})
+	}
+}
+
+func TestLayoutRTLEncoding(t *testing.T) {
+	rtl := false
+
+	container := Element{Type: TypeElementContainer, RTL: &rtl}
+	b, err := json.Marshal(container)
+	mustNoError(t, err)
+	assert.Contains(t, string(b), `"rtl":false`)
+
+	container.RTL = nil
+	b, err = json.Marshal(container)
+	mustNoError(t, err)
+	assert.NotContains(t, string(b), `"rtl"`)
+}
+
+func TestColumnFallbackFromJSON(t *testing.T) {
+	column := Column{
+		Type:     TypeColumn,
+		Width:    ColumnWidthAuto,
+		Fallback: &Column{Type: TypeColumn, Style: ContainerStyleEmphasis},
+	}
+
+	b, err := json.Marshal(column)
+	mustNoError(t, err)
+
+	var decoded Column
+	mustNoError(t, json.Unmarshal(b, &decoded))
+
+	if assert.IsType(t, map[string]interface{}{}, decoded.Fallback) {
+		assert.NoError(t, decoded.Validate())
+	}
+
+	b = []byte(`{"type":"Column","fallback":{"type":"Column","style":"loud"}}`)
+	mustNoError(t, json.Unmarshal(b, &decoded))
+
+	err = decoded.Validate()
+	assert.True(t, errors.Is(err, ErrInvalidFieldValue), "got error: %v", err)
+}
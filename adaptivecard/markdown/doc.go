@@ -0,0 +1,41 @@
+// Copyright 2024 Adam Chalkley
+//
+// https://github.com/atc0005/go-teams-notify
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+/*
+Package markdown converts Markdown documents (e.g., release notes or
+postmortem summaries) into Adaptive Card elements.
+
+TextBlock text supports only a small subset of Markdown; headings, tables,
+code blocks and images are not rendered. The ToElements function parses the
+block structure of a CommonMark (plus GitHub Flavored Markdown tables)
+document and maps each block to the closest Adaptive Card equivalent:
+
+  - headings become TextBlocks using the heading style and a size based on
+    the heading level
+  - paragraphs and lists become TextBlocks with text wrapping enabled
+  - fenced and indented code blocks become TextBlocks using a monospace font
+  - block quotes become Containers using the emphasis style
+  - tables become Table elements
+  - paragraphs consisting only of images become Image elements
+  - thematic breaks add a separator line to the following element
+
+Inline links are kept as-is, as they are supported by TextBlock text. Inline
+images are converted to links.
+
+Example usage:
+
+	elements, err := markdown.ToElements(releaseNotes)
+	if err != nil {
+		// handle error
+	}
+
+	card := adaptivecard.NewCard()
+	if err := card.AddElement(false, elements...); err != nil {
+		// handle error
+	}
+*/
+package markdown
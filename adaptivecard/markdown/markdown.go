@@ -0,0 +1,580 @@
+// Copyright 2024 Adam Chalkley
+//
+// https://github.com/atc0005/go-teams-notify
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package markdown
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/flashcatcloud/go-teams-notify/v2/adaptivecard"
+)
+
+// Regular expressions used to recognize block level constructs.
+var (
+	atxHeadingRegex    = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+(.*))?$`)
+	setextH1Regex      = regexp.MustCompile(`^ {0,3}=+[ \t]*$`)
+	setextH2Regex      = regexp.MustCompile(`^ {0,3}-+[ \t]*$`)
+	thematicBreakRegex = regexp.MustCompile(`^ {0,3}(?:(?:\*[ \t]*){3,}|(?:-[ \t]*){3,}|(?:_[ \t]*){3,})$`)
+	fenceRegex         = regexp.MustCompile("^( {0,3})(`{3,}|~{3,})(.*)$")
+	blockQuoteRegex    = regexp.MustCompile(`^ {0,3}> ?`)
+	listItemRegex      = regexp.MustCompile(`^( *)([-*+]|\d{1,9}[.)])(?:[ \t]+(.*))?$`)
+	indentedCodeRegex  = regexp.MustCompile(`^(?: {4}|\t)`)
+	tableDelimiterCell = regexp.MustCompile(`^:?-+:?$`)
+)
+
+// Regular expressions used to convert inline content.
+var (
+	imageRegex      = regexp.MustCompile(`!\[([^\]]*)\]\(([^)\s]+)(?:\s+"[^"]*")?\)`)
+	imagesOnlyRegex = regexp.MustCompile(`^(?:\s*!\[[^\]]*\]\([^)\s]+(?:\s+"[^"]*")?\)\s*)+$`)
+	linkTitleRegex  = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\s+"[^"]*"\)`)
+	autolinkRegex   = regexp.MustCompile(`<((?:https?|mailto):[^>\s]+)>`)
+)
+
+// headingSizes maps the level of a heading to the size of the TextBlock.
+var headingSizes = map[int]string{
+	1: adaptivecard.SizeExtraLarge,
+	2: adaptivecard.SizeLarge,
+	3: adaptivecard.SizeMedium,
+}
+
+// ToElements converts the given Markdown document into a collection of
+// Elements suitable for adding to a Card or Container. See the package
+// documentation for details of how each Markdown construct is converted. An
+// error is returned if an element cannot be created (e.g., an image with an
+// invalid URL).
+func ToElements(markdown string) ([]adaptivecard.Element, error) {
+	markdown = strings.NewReplacer("\r\n", "\n", "\r", "\n").Replace(markdown)
+
+	return convertBlocks(strings.Split(markdown, "\n"), 1)
+}
+
+// converter holds the state used while converting a collection of lines.
+type converter struct {
+	lines []string
+
+	// lineOffset is the line number of the first line, used when reporting
+	// errors for nested blocks.
+	lineOffset int
+
+	pos       int
+	elements  []adaptivecard.Element
+	separator bool
+}
+
+// convertBlocks converts the given lines into Elements. The lineOffset is the
+// line number of the first line.
+func convertBlocks(lines []string, lineOffset int) ([]adaptivecard.Element, error) {
+	c := converter{
+		lines:      lines,
+		lineOffset: lineOffset,
+	}
+
+	for c.pos < len(c.lines) {
+		start := c.pos
+		if err := c.block(); err != nil {
+			return nil, fmt.Errorf("line %d: %w", c.lineOffset+start, err)
+		}
+	}
+
+	return c.elements, nil
+}
+
+// add appends the given Elements, applying a pending separator from a
+// preceding thematic break to the first.
+func (c *converter) add(elements ...adaptivecard.Element) {
+	if len(elements) == 0 {
+		return
+	}
+
+	if c.separator {
+		elements[0].Separator = true
+		c.separator = false
+	}
+
+	c.elements = append(c.elements, elements...)
+}
+
+// block converts the block starting at the current line and advances past
+// it.
+func (c *converter) block() error {
+	line := c.lines[c.pos]
+
+	switch {
+	case strings.TrimSpace(line) == "":
+		c.pos++
+		return nil
+
+	case fenceRegex.MatchString(line):
+		c.fencedCode()
+		return nil
+
+	case atxHeadingRegex.MatchString(line):
+		c.atxHeading()
+		return nil
+
+	case thematicBreakRegex.MatchString(line):
+		c.separator = true
+		c.pos++
+		return nil
+
+	case blockQuoteRegex.MatchString(line):
+		return c.blockQuote()
+
+	case listItemRegex.MatchString(line):
+		c.list()
+		return nil
+
+	case indentedCodeRegex.MatchString(line):
+		c.indentedCode()
+		return nil
+
+	case c.isTableStart(c.pos):
+		return c.table()
+
+	default:
+		return c.paragraph()
+	}
+}
+
+// fencedCode converts a fenced code block to a monospace TextBlock.
+func (c *converter) fencedCode() {
+	match := fenceRegex.FindStringSubmatch(c.lines[c.pos])
+	indent, fence := len(match[1]), match[2]
+	c.pos++
+
+	var code []string
+	for ; c.pos < len(c.lines); c.pos++ {
+		line := c.lines[c.pos]
+
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]) == "" {
+			c.pos++
+			break
+		}
+
+		// Remove up to the indentation of the opening fence.
+		for i := 0; i < indent && strings.HasPrefix(line, " "); i++ {
+			line = line[1:]
+		}
+		code = append(code, line)
+	}
+
+	c.addCode(code)
+}
+
+// indentedCode converts an indented code block to a monospace TextBlock.
+func (c *converter) indentedCode() {
+	var code []string
+	for ; c.pos < len(c.lines); c.pos++ {
+		line := c.lines[c.pos]
+
+		if strings.TrimSpace(line) == "" {
+			// Blank lines are only part of the code block if followed by
+			// further indented lines.
+			next := c.pos + 1
+			for next < len(c.lines) && strings.TrimSpace(c.lines[next]) == "" {
+				next++
+			}
+			if next == len(c.lines) || !indentedCodeRegex.MatchString(c.lines[next]) {
+				break
+			}
+			code = append(code, "")
+			continue
+		}
+
+		if !indentedCodeRegex.MatchString(line) {
+			break
+		}
+
+		code = append(code, indentedCodeRegex.ReplaceAllString(line, ""))
+	}
+
+	c.addCode(code)
+}
+
+// addCode adds the given lines of code as a monospace TextBlock.
+func (c *converter) addCode(code []string) {
+	text := strings.Join(code, "\n")
+	if strings.TrimSpace(text) == "" {
+		return
+	}
+
+	textBlock := adaptivecard.NewTextBlock(text, true)
+	textBlock.FontType = adaptivecard.FontTypeMonospace
+
+	c.add(textBlock)
+}
+
+// atxHeading converts an ATX heading (e.g., "## Title") to a TextBlock.
+func (c *converter) atxHeading() {
+	match := atxHeadingRegex.FindStringSubmatch(c.lines[c.pos])
+	c.pos++
+
+	// Remove the optional closing sequence of # characters.
+	text := strings.TrimSpace(match[2])
+	if trimmed := strings.TrimRight(text, "#"); trimmed == "" || strings.HasSuffix(trimmed, " ") {
+		text = strings.TrimSpace(trimmed)
+	}
+
+	c.addHeading(len(match[1]), text)
+}
+
+// addHeading adds a TextBlock for a heading of the given level.
+func (c *converter) addHeading(level int, text string) {
+	if text == "" {
+		return
+	}
+
+	textBlock := adaptivecard.NewTextBlock(inline(text), true)
+	textBlock.Style = adaptivecard.TextBlockStyleHeading
+	textBlock.Weight = adaptivecard.WeightBolder
+	textBlock.Size = headingSizes[level]
+
+	c.add(textBlock)
+}
+
+// blockQuote converts a block quote to a Container using the emphasis style
+// holding the converted content of the quote.
+func (c *converter) blockQuote() error {
+	start := c.pos
+
+	var lines []string
+	for ; c.pos < len(c.lines); c.pos++ {
+		line := c.lines[c.pos]
+		if !blockQuoteRegex.MatchString(line) {
+			break
+		}
+		lines = append(lines, blockQuoteRegex.ReplaceAllString(line, ""))
+	}
+
+	elements, err := convertBlocks(lines, c.lineOffset+start)
+	if err != nil {
+		return err
+	}
+
+	if len(elements) == 0 {
+		return nil
+	}
+
+	container := adaptivecard.NewContainer()
+	container.Style = adaptivecard.ContainerStyleEmphasis
+	for _, element := range elements {
+		if err := container.AddElement(false, element); err != nil {
+			return err
+		}
+	}
+
+	c.add(adaptivecard.Element(container))
+
+	return nil
+}
+
+// list converts a list (including any nested lists) to a single TextBlock
+// with one line per list item. Nested list items are indented by two spaces
+// per level.
+func (c *converter) list() {
+	var items []string
+	var indents []int
+	var listOrdered bool
+
+	for c.pos < len(c.lines) {
+		line := c.lines[c.pos]
+
+		if match := listItemRegex.FindStringSubmatch(line); match != nil {
+			indent := len(match[1])
+			ordered := isOrderedMarker(match[2])
+
+			// A top level item of a different list type starts a new list.
+			if len(items) > 0 && indent <= indents[0] && ordered != listOrdered {
+				break
+			}
+			if len(items) == 0 {
+				listOrdered = ordered
+			}
+
+			// Determine the nesting level of this item from the indentation
+			// of the enclosing items.
+			for len(indents) > 0 && indent < indents[len(indents)-1] {
+				indents = indents[:len(indents)-1]
+			}
+			if len(indents) == 0 || indent > indents[len(indents)-1]+1 {
+				indents = append(indents, indent)
+			}
+
+			marker := match[2]
+			if marker == "*" || marker == "+" {
+				marker = "-"
+			}
+			marker = strings.Replace(marker, ")", ".", 1)
+
+			items = append(items, strings.Repeat("  ", len(indents)-1)+marker+" "+strings.TrimSpace(match[3]))
+			c.pos++
+			continue
+		}
+
+		if strings.TrimSpace(line) == "" {
+			// The list continues after blank lines only if followed by
+			// another (or indented) list item.
+			next := c.pos + 1
+			for next < len(c.lines) && strings.TrimSpace(c.lines[next]) == "" {
+				next++
+			}
+			if next < len(c.lines) && listItemRegex.MatchString(c.lines[next]) {
+				c.pos = next
+				continue
+			}
+			break
+		}
+
+		// Continuation lines of the previous item must be indented and may
+		// not start a new block.
+		if !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "\t") {
+			break
+		}
+		if len(items) > 0 {
+			items[len(items)-1] += " " + strings.TrimSpace(line)
+		}
+		c.pos++
+	}
+
+	for i := range items {
+		items[i] = inline(items[i])
+	}
+
+	c.add(adaptivecard.NewTextBlock(strings.Join(items, "\n"), true))
+}
+
+// isOrderedMarker indicates whether the given list item marker is an
+// ordered list marker (e.g., "1." or "1)").
+func isOrderedMarker(marker string) bool {
+	return strings.HasSuffix(marker, ".") || strings.HasSuffix(marker, ")")
+}
+
+// isTableStart indicates whether a table starts at the given line; a table
+// is a header row followed by a delimiter row with the same number of cells.
+func (c *converter) isTableStart(pos int) bool {
+	if pos+1 >= len(c.lines) || !strings.Contains(c.lines[pos], "|") {
+		return false
+	}
+
+	header := splitTableRow(c.lines[pos])
+	delimiter := splitTableRow(c.lines[pos+1])
+	if len(header) != len(delimiter) {
+		return false
+	}
+
+	for _, cell := range delimiter {
+		if !tableDelimiterCell.MatchString(cell) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// table converts a GitHub Flavored Markdown table to a Table element. The
+// alignment of each column is taken from the delimiter row.
+func (c *converter) table() error {
+	header := splitTableRow(c.lines[c.pos])
+	delimiter := splitTableRow(c.lines[c.pos+1])
+	numColumns := len(header)
+
+	rows := [][]string{header}
+	for c.pos += 2; c.pos < len(c.lines); c.pos++ {
+		line := c.lines[c.pos]
+		if strings.TrimSpace(line) == "" || !strings.Contains(line, "|") {
+			break
+		}
+		rows = append(rows, splitTableRow(line))
+	}
+
+	cells := make([][]adaptivecard.TableCell, 0, len(rows))
+	for _, row := range rows {
+		// Rows are padded or truncated to match the header row. Each cell
+		// requires an item, so empty cells hold an empty TextBlock.
+		items := make([]interface{}, numColumns)
+		for i := range items {
+			items[i] = ""
+			if i < len(row) {
+				items[i] = inline(row[i])
+			}
+		}
+
+		rowCells, err := adaptivecard.NewTableCellsWithTextBlock(items)
+		if err != nil {
+			return err
+		}
+
+		for _, cell := range rowCells {
+			for _, item := range cell.Items {
+				item.Wrap = true
+			}
+		}
+
+		cells = append(cells, rowCells)
+	}
+
+	table, err := adaptivecard.NewTableFromTableCells(cells, numColumns, true, true)
+	if err != nil {
+		return err
+	}
+
+	for i, cell := range delimiter {
+		switch {
+		case strings.HasPrefix(cell, ":") && strings.HasSuffix(cell, ":"):
+			table.Columns[i].HorizontalCellContentAlignment = adaptivecard.HorizontalAlignmentCenter
+		case strings.HasSuffix(cell, ":"):
+			table.Columns[i].HorizontalCellContentAlignment = adaptivecard.HorizontalAlignmentRight
+		default:
+			table.Columns[i].HorizontalCellContentAlignment = adaptivecard.HorizontalAlignmentLeft
+		}
+	}
+
+	c.add(table)
+
+	return nil
+}
+
+// splitTableRow splits a table row into trimmed cell values. Leading and
+// trailing pipes are optional and escaped pipes (\|) are kept within the
+// cell value.
+func splitTableRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, `\|`) {
+		line = strings.TrimSuffix(line, "|")
+	}
+
+	var cells []string
+	var cell strings.Builder
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line) && line[i+1] == '|':
+			cell.WriteByte('|')
+			i++
+		case line[i] == '|':
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(line[i])
+		}
+	}
+
+	return append(cells, strings.TrimSpace(cell.String()))
+}
+
+// paragraph converts a paragraph to a TextBlock, to a heading if followed by
+// a setext heading underline or to Image elements if the paragraph consists
+// only of images.
+func (c *converter) paragraph() error {
+	var lines []string
+
+	for c.pos < len(c.lines) {
+		line := c.lines[c.pos]
+
+		if len(lines) > 0 {
+			switch {
+			case setextH1Regex.MatchString(line):
+				c.pos++
+				c.addHeading(1, joinLines(lines))
+				return nil
+
+			case setextH2Regex.MatchString(line):
+				c.pos++
+				c.addHeading(2, joinLines(lines))
+				return nil
+
+			case strings.TrimSpace(line) == "",
+				fenceRegex.MatchString(line),
+				atxHeadingRegex.MatchString(line),
+				thematicBreakRegex.MatchString(line),
+				blockQuoteRegex.MatchString(line),
+				listItemRegex.MatchString(line),
+				c.isTableStart(c.pos):
+
+				return c.addParagraph(lines)
+			}
+		}
+
+		lines = append(lines, line)
+		c.pos++
+	}
+
+	return c.addParagraph(lines)
+}
+
+// addParagraph adds the given paragraph lines as a TextBlock or, if the
+// paragraph consists only of images, as Image elements.
+func (c *converter) addParagraph(lines []string) error {
+	text := joinLines(lines)
+	if text == "" {
+		return nil
+	}
+
+	if !imagesOnlyRegex.MatchString(text) {
+		c.add(adaptivecard.NewTextBlock(inline(text), true))
+		return nil
+	}
+
+	var images []adaptivecard.Element
+	for _, match := range imageRegex.FindAllStringSubmatch(text, -1) {
+		image, err := adaptivecard.NewImage(match[2], match[1])
+		if err != nil {
+			return err
+		}
+		images = append(images, image)
+	}
+
+	c.add(images...)
+
+	return nil
+}
+
+// joinLines joins the lines of a paragraph. Line breaks are kept only for
+// hard line breaks (a line ending with two or more spaces or a backslash);
+// other lines are joined using a space.
+func joinLines(lines []string) string {
+	var b strings.Builder
+
+	for i, line := range lines {
+		hardBreak := strings.HasSuffix(line, "  ") || strings.HasSuffix(line, `\`)
+
+		line = strings.TrimSpace(line)
+		if hardBreak {
+			line = strings.TrimSuffix(line, `\`)
+		}
+		b.WriteString(line)
+
+		if i < len(lines)-1 {
+			if hardBreak {
+				b.WriteString("\n")
+			} else {
+				b.WriteString(" ")
+			}
+		}
+	}
+
+	return strings.TrimSpace(b.String())
+}
+
+// inline converts inline content not supported by TextBlock text: images are
+// converted to links, link titles are removed and autolinks are converted to
+// links.
+func inline(text string) string {
+	text = imageRegex.ReplaceAllStringFunc(text, func(match string) string {
+		parts := imageRegex.FindStringSubmatch(match)
+		if parts[1] == "" {
+			parts[1] = parts[2]
+		}
+		return fmt.Sprintf("[%s](%s)", parts[1], parts[2])
+	})
+	text = linkTitleRegex.ReplaceAllString(text, "[$1]($2)")
+
+	return autolinkRegex.ReplaceAllString(text, "[$1]($1)")
+}
@@ -0,0 +1,143 @@
+// Copyright 2024 Adam Chalkley
+//
+// https://github.com/atc0005/go-teams-notify
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package markdown
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/flashcatcloud/go-teams-notify/v2/adaptivecard"
+	"github.com/stretchr/testify/assert"
+)
+
+const releaseNotes = `# Release 1.4.0
+
+Highlights of this release, see the
+[changelog](https://example.com/changelog "Changelog") or <https://example.com>.
+
+Setext heading
+--------------
+
+- Faster startup
+- Support for *tables*
+  - including alignment
+* Fixed crash on exit
+
+1. Upgrade
+2) Restart
+
+> **Note:** restart required.
+>
+> See [docs](https://example.com/docs).
+
+---
+
+| Service | Version | Status |
+| :------ | ------: | :----: |
+| api     | 1.4.0   | done   |
+| web \| ui | 1.3.9 |
+
+` + "```go" + `
+fmt.Println("hello")
+
+    indented within fence
+` + "```" + `
+
+    indented code block
+
+![Architecture](https://example.com/arch.png "Diagram")
+`
+
+func TestToElements(t *testing.T) {
+	elements, err := ToElements(releaseNotes)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	if !assert.Len(t, elements, 10) {
+		return
+	}
+
+	h1 := elements[0]
+	assert.Equal(t, "Release 1.4.0", h1.Text)
+	assert.Equal(t, adaptivecard.TextBlockStyleHeading, h1.Style)
+	assert.Equal(t, adaptivecard.SizeExtraLarge, h1.Size)
+
+	assert.Equal(t,
+		"Highlights of this release, see the [changelog](https://example.com/changelog) or [https://example.com](https://example.com).",
+		elements[1].Text,
+	)
+
+	assert.Equal(t, "Setext heading", elements[2].Text)
+	assert.Equal(t, adaptivecard.SizeLarge, elements[2].Size)
+
+	assert.Equal(t, "- Faster startup\n- Support for *tables*\n  - including alignment\n- Fixed crash on exit", elements[3].Text)
+	assert.Equal(t, "1. Upgrade\n2. Restart", elements[4].Text)
+
+	quote := elements[5]
+	assert.Equal(t, adaptivecard.TypeElementContainer, quote.Type)
+	assert.Equal(t, adaptivecard.ContainerStyleEmphasis, quote.Style)
+	if assert.Len(t, quote.Items, 2) {
+		assert.Equal(t, "**Note:** restart required.", quote.Items[0].Text)
+	}
+
+	table := elements[6]
+	assert.Equal(t, adaptivecard.TypeElementTable, table.Type)
+	assert.True(t, table.Separator)
+	if assert.Len(t, table.Columns, 3) && assert.Len(t, table.Rows, 3) {
+		assert.Equal(t, adaptivecard.HorizontalAlignmentLeft, table.Columns[0].HorizontalCellContentAlignment)
+		assert.Equal(t, adaptivecard.HorizontalAlignmentRight, table.Columns[1].HorizontalCellContentAlignment)
+		assert.Equal(t, adaptivecard.HorizontalAlignmentCenter, table.Columns[2].HorizontalCellContentAlignment)
+		assert.Equal(t, "web | ui", table.Rows[2].Cells[0].Items[0].Text)
+		assert.Len(t, table.Rows[2].Cells, 3)
+	}
+
+	code := elements[7]
+	assert.Equal(t, adaptivecard.FontTypeMonospace, code.FontType)
+	assert.Equal(t, "fmt.Println(\"hello\")\n\n    indented within fence", code.Text)
+
+	assert.Equal(t, adaptivecard.FontTypeMonospace, elements[8].FontType)
+	assert.Equal(t, "indented code block", elements[8].Text)
+
+	image := elements[9]
+	assert.Equal(t, adaptivecard.TypeElementImage, image.Type)
+	assert.Equal(t, "https://example.com/arch.png", image.URL)
+	assert.Equal(t, "Architecture", image.AltText)
+
+	card := adaptivecard.NewCard()
+	if assert.NoError(t, card.AddElement(false, elements...)) {
+		assert.NoError(t, adaptivecard.TopLevelCard{Card: card}.Validate())
+	}
+}
+
+func TestToElementsInline(t *testing.T) {
+	elements, err := ToElements("Line one  \nline two\\\nline three\nsame line ![logo](https://example.com/logo.png)")
+	if assert.NoError(t, err) && assert.Len(t, elements, 1) {
+		assert.Equal(t,
+			"Line one\nline two\nline three same line [logo](https://example.com/logo.png)",
+			elements[0].Text,
+		)
+	}
+
+	elements, err = ToElements("## Title ##\n### #hashtag")
+	if assert.NoError(t, err) && assert.Len(t, elements, 2) {
+		assert.Equal(t, "Title", elements[0].Text)
+		assert.Equal(t, "#hashtag", elements[1].Text)
+		assert.Equal(t, adaptivecard.SizeMedium, elements[1].Size)
+	}
+
+	elements, err = ToElements("")
+	assert.NoError(t, err)
+	assert.Empty(t, elements)
+}
+
+func TestToElementsInvalidImage(t *testing.T) {
+	_, err := ToElements("text\n\n![bad](ftp://example.com/a.png)")
+	assert.True(t, errors.Is(err, adaptivecard.ErrInvalidFieldValue))
+	assert.Contains(t, err.Error(), "line 3")
+}
@@ -0,0 +1,124 @@
+// Copyright 2024 Adam Chalkley
+//
+// https://github.com/atc0005/go-teams-notify
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package adaptivecard
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewMedia(t *testing.T) {
+	media, err := NewMedia("Demo", NewMediaSource("video/mp4", "https://example.com/demo.mp4"))
+	if assert.NoError(t, err) {
+		assert.Equal(t, TypeElementMedia, media.Type)
+		assert.Equal(t, "Demo", media.AltText)
+		assert.Len(t, media.Sources, 1)
+	}
+
+	_, err = NewMedia("Demo")
+	assert.True(t, errors.Is(err, ErrMissingValue))
+
+	_, err = NewMedia("Demo", NewMediaSource("video/mp4", ""))
+	assert.True(t, errors.Is(err, ErrMissingValue))
+}
+
+func TestMediaValidation(t *testing.T) {
+	source := NewMediaSource("video/mp4", "https://example.com/demo.mp4")
+
+	tests := map[string]struct {
+		media   Element
+		wantErr error
+	}{
+		"with poster and captions": {
+			media: Element{
+				Type:           TypeElementMedia,
+				Sources:        []MediaSource{source},
+				Poster:         "https://example.com/poster.png",
+				CaptionSources: []CaptionSource{{MIMEType: "vtt", URL: "https://example.com/en.vtt", Label: "English"}},
+			},
+		},
+		"without sources": {
+			media:   Element{Type: TypeElementMedia},
+			wantErr: ErrMissingValue,
+		},
+		"with empty sources": {
+			media:   Element{Type: TypeElementMedia, Sources: []MediaSource{}},
+			wantErr: ErrMissingValue,
+		},
+		"caption without label": {
+			media: Element{
+				Type:           TypeElementMedia,
+				Sources:        []MediaSource{source},
+				CaptionSources: []CaptionSource{{MIMEType: "vtt", URL: "https://example.com/en.vtt"}},
+			},
+			wantErr: ErrMissingValue,
+		},
+		"invalid poster URL": {
+			media: Element{
+				Type:    TypeElementMedia,
+				Sources: []MediaSource{source},
+				Poster:  "ftp://example.com/poster.png",
+			},
+			wantErr: ErrInvalidFieldValue,
+		},
+	}
+
+	for name, tt := range tests {
+		name, tt := name, tt
+
+		t.Run(name, func(t *testing.T) {
+			err := tt.media.Validate()
+
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.True(t, errors.Is(err, tt.wantErr), "got error: %v", err)
+		})
+	}
+}
+
+func TestMediaWarnings(t *testing.T) {
+	media := Element{
+		Type:    TypeElementMedia,
+		Sources: []MediaSource{{URL: "https://example.com/demo.mp4"}},
+	}
+
+	warnings := media.Warnings()
+	assert.Contains(t, warnings, MediaClientSupportWarning)
+	assert.Len(t, warnings, 4)
+
+	media.Poster = "https://example.com/poster.png"
+	media.AltText = "Demo"
+	media.Sources[0].MIMEType = "video/mp4"
+	assert.Equal(t, []string{MediaClientSupportWarning}, media.Warnings())
+
+	card := NewCard()
+	card.Body = []Element{{Type: TypeElementContainer, Items: []Element{media}}}
+	assert.Equal(t, []string{MediaClientSupportWarning}, card.Warnings())
+
+	assert.Empty(t, NewTextBlock("text", false).Warnings())
+}
+
+// Collections other than Media Sources are validated as before; empty
+// collections are not reported as missing values.
+func TestEmptyCollectionsValidation(t *testing.T) {
+	cells, err := NewTableCellsWithTextBlock([]interface{}{"a", nil, "c", nil, "e", nil})
+	mustNoError(t, err)
+
+	table, err := NewTableWithGridFromTableCells(cells, 3)
+	if assert.NoError(t, err) {
+		assert.Len(t, table.Rows, 2)
+	}
+
+	assert.True(t, errors.Is(Element{Type: TypeElementContainer}.Validate(), ErrMissingValue))
+	assert.True(t, errors.Is(NewMessage().Validate(), ErrMissingValue))
+}
@@ -0,0 +1,130 @@
+// Copyright 2024 Adam Chalkley
+//
+// https://github.com/atc0005/go-teams-notify
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package adaptivecard
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewMentionTypes(t *testing.T) {
+	tests := map[string]struct {
+		newMention func(name string, id string) (Mention, error)
+		wantType   string
+	}{
+		"user":    {newMention: NewMention},
+		"tag":     {newMention: NewTagMention, wantType: MentionedTypeTag},
+		"channel": {newMention: NewChannelMention, wantType: MentionedTypeChannel},
+		"team":    {newMention: NewTeamMention, wantType: MentionedTypeTeam},
+	}
+
+	for name, tt := range tests {
+		name, tt := name, tt
+
+		t.Run(name, func(t *testing.T) {
+			mention, err := tt.newMention("On-call", "id-1")
+			mustNoError(t, err)
+
+			assert.Equal(t, TypeMention, mention.Type)
+			assert.Equal(t, "<at>On-call</at>", mention.Text)
+			assert.Equal(t, "id-1", mention.Mentioned.ID)
+			assert.Equal(t, "On-call", mention.Mentioned.Name)
+			assert.Equal(t, tt.wantType, mention.Mentioned.Type)
+
+			_, err = tt.newMention("", "id-1")
+			assert.True(t, errors.Is(err, ErrMissingValue))
+
+			_, err = tt.newMention("On-call", "")
+			assert.True(t, errors.Is(err, ErrMissingValue))
+		})
+	}
+}
+
+func TestMentionedTypeEncoding(t *testing.T) {
+	user, err := NewMention("Jane", "user-1")
+	mustNoError(t, err)
+
+	b, err := json.Marshal(user.Mentioned)
+	mustNoError(t, err)
+	assert.NotContains(t, string(b), `"type"`)
+
+	tag, err := NewTagMention("oncall-db", "tag-1")
+	mustNoError(t, err)
+
+	b, err = json.Marshal(tag.Mentioned)
+	mustNoError(t, err)
+	assert.Contains(t, string(b), `"type":"tag"`)
+}
+
+func TestMentionedValidation(t *testing.T) {
+	tests := map[string]struct {
+		mentioned Mentioned
+		wantErr   error
+	}{
+		"user":         {mentioned: Mentioned{ID: "1", Name: "a"}},
+		"tag":          {mentioned: Mentioned{ID: "1", Name: "a", Type: MentionedTypeTag}},
+		"channel":      {mentioned: Mentioned{ID: "1", Name: "a", Type: MentionedTypeChannel}},
+		"team":         {mentioned: Mentioned{ID: "1", Name: "a", Type: MentionedTypeTeam}},
+		"unknown type": {mentioned: Mentioned{ID: "1", Name: "a", Type: "group"}, wantErr: ErrInvalidType},
+		"missing ID":   {mentioned: Mentioned{Name: "a"}, wantErr: ErrMissingValue},
+		"missing name": {mentioned: Mentioned{ID: "1"}, wantErr: ErrMissingValue},
+	}
+
+	for name, tt := range tests {
+		name, tt := name, tt
+
+		t.Run(name, func(t *testing.T) {
+			err := tt.mentioned.Validate()
+
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.True(t, errors.Is(err, tt.wantErr), "got error: %v", err)
+		})
+	}
+
+	mention := Mention{Type: TypeMention, Text: "<at>a</at>", Mentioned: Mentioned{ID: "1", Name: "a", Type: "group"}}
+	assert.True(t, errors.Is(mention.Validate(), ErrInvalidType))
+}
+
+func TestMessageAddMention(t *testing.T) {
+	tag, err := NewTagMention("oncall-db", "tag-1")
+	mustNoError(t, err)
+
+	channel, err := NewChannelMention("General", "channel-1")
+	mustNoError(t, err)
+
+	msg := NewMessage()
+	mustNoError(t, msg.AddMention(false, "database is down", tag, channel))
+
+	if assert.Len(t, msg.Attachments, 1) {
+		card := msg.Attachments[0].Content
+
+		if assert.Len(t, card.Body, 1) {
+			assert.Equal(t, "<at>oncall-db</at> <at>General</at> database is down", card.Body[0].Text)
+		}
+		assert.Equal(t, []Mention{tag, channel}, card.MSTeams.Entities)
+	}
+
+	mustNoError(t, msg.Validate())
+
+	// The Mention method is limited to user mentions.
+	mustNoError(t, msg.Mention(true, "Jane", "user-1", "please investigate"))
+	card := msg.Attachments[0].Content
+	if assert.Len(t, card.MSTeams.Entities, 3) {
+		assert.Equal(t, "", card.MSTeams.Entities[2].Mentioned.Type)
+	}
+	assert.Equal(t, "<at>Jane</at> please investigate", card.Body[0].Text)
+
+	assert.Error(t, msg.AddMention(false, "text", Mention{Type: TypeMention}))
+}
@@ -0,0 +1,566 @@
+// Copyright 2024 Adam Chalkley
+//
+// https://github.com/atc0005/go-teams-notify
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package adaptivecard
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	goteamsnotify "github.com/flashcatcloud/go-teams-notify/v2"
+)
+
+// ParseMode controls how ParseCard and ParseMessage handle properties and
+// types which are not modeled by this package.
+type ParseMode int
+
+const (
+	// ParseModeLenient reports unknown properties and types as ParseIssue
+	// values without failing. Unknown properties are retained in the
+	// UnknownFields field of the value they were found on.
+	ParseModeLenient ParseMode = iota
+
+	// ParseModeStrict causes parsing to fail if any unknown properties or
+	// types are found.
+	ParseModeStrict
+)
+
+// ParseIssueKind describes the kind of problem found while parsing.
+type ParseIssueKind string
+
+// Kinds of problems reported while parsing.
+const (
+	// ParseIssueUnknownProperty indicates a property not modeled by the
+	// type it was found on.
+	ParseIssueUnknownProperty ParseIssueKind = "unknown property"
+
+	// ParseIssueUnknownElementType indicates an element with an
+	// unsupported type.
+	ParseIssueUnknownElementType ParseIssueKind = "unknown element type"
+
+	// ParseIssueUnknownActionType indicates an action with an unsupported
+	// type.
+	ParseIssueUnknownActionType ParseIssueKind = "unknown action type"
+)
+
+// ErrUnknownProperty indicates that a property is not modeled by the type it
+// was found on.
+var ErrUnknownProperty = errors.New("unknown property")
+
+// ParseIssue describes a property or type found while parsing which is not
+// modeled by this package.
+type ParseIssue struct {
+	// Kind is the kind of problem found.
+	Kind ParseIssueKind
+
+	// Path is the JSON path of the property (e.g., "$.body[0].foo").
+	Path string
+
+	// Name is the property name or the unsupported type value.
+	Name string
+}
+
+// ParseIssues is a collection of ParseIssue values.
+type ParseIssues []ParseIssue
+
+// ParseError is returned by ParseCard and ParseMessage when using
+// ParseModeStrict and unknown properties or types are found.
+type ParseError struct {
+	Issues ParseIssues
+}
+
+// String provides a human readable description of the issue.
+func (pi ParseIssue) String() string {
+	return fmt.Sprintf("%s %q at %s", pi.Kind, pi.Name, pi.Path)
+}
+
+// Error implements the error interface.
+func (pe *ParseError) Error() string {
+	issues := make([]string, 0, len(pe.Issues))
+	for _, issue := range pe.Issues {
+		issues = append(issues, issue.String())
+	}
+
+	return fmt.Sprintf(
+		"failed to parse Adaptive Card JSON: %s",
+		strings.Join(issues, "; "),
+	)
+}
+
+// Is reports whether the error matches the given target. ErrUnknownProperty
+// is matched for unknown properties and ErrInvalidType is matched for
+// unknown element or action types.
+func (pe *ParseError) Is(target error) bool {
+	for _, issue := range pe.Issues {
+		switch {
+		case issue.Kind == ParseIssueUnknownProperty && target == ErrUnknownProperty:
+			return true
+		case issue.Kind != ParseIssueUnknownProperty && target == ErrInvalidType:
+			return true
+		}
+	}
+
+	return false
+}
+
+// ParseCard decodes the given Adaptive Card JSON, such as a card exported
+// from the Adaptive Cards Designer, into a Card. Properties and element or
+// action types which are not modeled by this package are returned as
+// ParseIssues along with their JSON path.
+//
+// In ParseModeLenient unknown properties are retained in the UnknownFields
+// field of the value they were found on (e.g., the Element or Action),
+// keyed by property name and holding the raw JSON value. UnknownFields are
+// appended to the properties of the value when it is encoded as JSON again,
+// allowing a Card to be round-tripped without losing content. Entries
+// matching a modeled property (compared case-insensitively, as
+// encoding/json does when decoding) are ignored. UnknownFields may also be
+// set directly to include properties not yet modeled by this package. In
+// ParseModeStrict a *ParseError is returned if any issues are found.
+//
+// The Card is not validated; call Validate (or TopLevelCard.Validate) as
+// needed.
+func ParseCard(data []byte, mode ParseMode) (Card, ParseIssues, error) {
+	var card Card
+
+	issues, err := parse(data, &card, mode)
+	if err != nil {
+		return Card{}, issues, err
+	}
+
+	return card, issues, nil
+}
+
+// ParseMessage decodes the given Microsoft Teams message JSON containing one
+// or more Adaptive Card attachments into a Message. See ParseCard for
+// details on the handling of unknown properties and types.
+func ParseMessage(data []byte, mode ParseMode) (*Message, ParseIssues, error) {
+	var msg Message
+
+	issues, err := parse(data, &msg, mode)
+	if err != nil {
+		return nil, issues, err
+	}
+
+	return &msg, issues, nil
+}
+
+// parse decodes the given JSON into the value pointed to by v, recording
+// unknown properties and types.
+func parse(data []byte, v interface{}, mode ParseMode) (ParseIssues, error) {
+	if err := json.Unmarshal(data, v); err != nil {
+		return nil, fmt.Errorf("failed to decode Adaptive Card JSON: %w", err)
+	}
+
+	p := parser{}
+	p.walk(data, reflect.ValueOf(v).Elem(), "$")
+
+	if mode == ParseModeStrict && len(p.issues) > 0 {
+		return p.issues, &ParseError{Issues: p.issues}
+	}
+
+	return p.issues, nil
+}
+
+// parser records issues found while walking decoded JSON alongside the
+// value it was decoded into.
+type parser struct {
+	issues ParseIssues
+}
+
+var (
+	elementReflectType      = reflect.TypeOf(Element{})
+	actionReflectType       = reflect.TypeOf(Action{})
+	selectActionReflectType = reflect.TypeOf(ISelectAction{})
+	unknownFieldsType       = reflect.TypeOf(map[string]json.RawMessage{})
+	unmarshalerType         = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+)
+
+// walk compares the given raw JSON with the value it was decoded into,
+// recording unknown properties and types. Unknown properties are stored in
+// the UnknownFields field of the value if it has one.
+func (p *parser) walk(raw json.RawMessage, v reflect.Value, path string) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if !v.IsNil() {
+			p.walk(raw, v.Elem(), path)
+		}
+
+	case reflect.Slice:
+		var items []json.RawMessage
+		if err := json.Unmarshal(raw, &items); err != nil {
+			return
+		}
+		for i := 0; i < len(items) && i < v.Len(); i++ {
+			p.walk(items[i], v.Index(i), fmt.Sprintf("%s[%d]", path, i))
+		}
+
+	case reflect.Struct:
+		if reflect.PtrTo(v.Type()).Implements(unmarshalerType) {
+			return
+		}
+		p.walkStruct(raw, v, path)
+	}
+}
+
+func (p *parser) walkStruct(raw json.RawMessage, v reflect.Value, path string) {
+	var props map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &props); err != nil {
+		return
+	}
+
+	p.checkType(v, props, path)
+
+	fields := jsonFields(v.Type())
+	unknown := make(map[string]json.RawMessage)
+
+	keys := make([]string, 0, len(props))
+	for key := range props {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		propPath := path + "." + key
+
+		name, index, ok := lookupField(fields, key)
+		if !ok {
+			unknown[key] = props[key]
+			p.issues = append(p.issues, ParseIssue{
+				Kind: ParseIssueUnknownProperty,
+				Path: propPath,
+				Name: key,
+			})
+			continue
+		}
+
+		field := v.FieldByIndex(index)
+
+		// Element and Column fallback values are decoded as generic JSON;
+		// walk them as an Element so that issues are still reported.
+		if field.Kind() == reflect.Interface && name == "fallback" {
+			fallback := reflect.New(elementReflectType).Elem()
+			if err := json.Unmarshal(props[key], fallback.Addr().Interface()); err == nil {
+				p.walk(props[key], fallback, propPath)
+			}
+			continue
+		}
+
+		p.walk(props[key], field, propPath)
+	}
+
+	if len(unknown) > 0 {
+		if f := v.FieldByName("UnknownFields"); f.IsValid() && f.Type() == unknownFieldsType {
+			f.Set(reflect.ValueOf(unknown))
+		}
+	}
+}
+
+// checkType records an issue if the given element or action specifies an
+// unsupported type.
+func (p *parser) checkType(v reflect.Value, props map[string]json.RawMessage, path string) {
+	var typ string
+	if err := json.Unmarshal(props["type"], &typ); err != nil {
+		return
+	}
+
+	var kind ParseIssueKind
+	var supported []string
+
+	switch v.Type() {
+	case elementReflectType:
+		kind, supported = ParseIssueUnknownElementType, supportedElementTypes()
+	case actionReflectType:
+		kind, supported = ParseIssueUnknownActionType, supportedActionValues(AdaptiveCardMaxVersion)
+	case selectActionReflectType:
+		kind, supported = ParseIssueUnknownActionType, supportedISelectActionValues(AdaptiveCardMaxVersion)
+	default:
+		return
+	}
+
+	if !goteamsnotify.InList(typ, supported, false) {
+		p.issues = append(p.issues, ParseIssue{
+			Kind: kind,
+			Path: path + ".type",
+			Name: typ,
+		})
+	}
+}
+
+// jsonFields returns the JSON property names of the given struct type along
+// with the index of the field each is decoded into. Fields of embedded
+// structs without a JSON name are promoted.
+func jsonFields(t reflect.Type) map[string][]int {
+	fields := make(map[string][]int)
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name := strings.Split(tag, ",")[0]
+
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			for embedded, index := range jsonFields(f.Type) {
+				if _, ok := fields[embedded]; !ok {
+					fields[embedded] = append([]int{i}, index...)
+				}
+			}
+			continue
+		}
+
+		if f.PkgPath != "" {
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+		fields[name] = []int{i}
+	}
+
+	return fields
+}
+
+// lookupField returns the JSON property name and field index of the given
+// fields matching the given key. As with encoding/json, an exact match is
+// preferred over a case-insensitive match.
+func lookupField(fields map[string][]int, key string) (string, []int, bool) {
+	if index, ok := fields[key]; ok {
+		return key, index, true
+	}
+
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if strings.EqualFold(name, key) {
+			return name, fields[name], true
+		}
+	}
+
+	return "", nil, false
+}
+
+// hasFoldedKey indicates whether the given properties include the given key
+// using a case-insensitive match.
+func hasFoldedKey(props map[string]json.RawMessage, key string) bool {
+	if _, ok := props[key]; ok {
+		return true
+	}
+
+	for name := range props {
+		if strings.EqualFold(name, key) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// marshalWithUnknownFields encodes the given value as a JSON object and
+// appends the given unknown fields which are not already present (using a
+// case-insensitive match, as encoding/json does when decoding). This is
+// used by the MarshalJSON methods of types retaining properties found by
+// ParseCard or ParseMessage.
+func marshalWithUnknownFields(v interface{}, unknown map[string]json.RawMessage) ([]byte, error) {
+	var buf bytes.Buffer
+
+	// HTML escaping is applied (or not) by the caller's encoder.
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+
+	encoded := bytes.TrimRight(buf.Bytes(), "\n")
+	if len(unknown) == 0 {
+		return encoded, nil
+	}
+
+	var known map[string]json.RawMessage
+	if err := json.Unmarshal(encoded, &known); err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(unknown))
+	for key := range unknown {
+		if !hasFoldedKey(known, key) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	result := bytes.NewBuffer(encoded[:len(encoded)-1])
+	for i, key := range keys {
+		if len(known) > 0 || i > 0 {
+			result.WriteByte(',')
+		}
+
+		name, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Write(name)
+		result.WriteByte(':')
+		result.Write(unknown[key])
+	}
+	result.WriteByte('}')
+
+	return result.Bytes(), nil
+}
+
+// Types with the same fields as those retaining UnknownFields, but without
+// their MarshalJSON methods. Values are converted to these types before
+// being passed to marshalWithUnknownFields so that the default encoding is
+// applied instead of recursing into MarshalJSON.
+type (
+	messageJSON               Message
+	attachmentJSON            Attachment
+	cardJSON                  Card
+	msTeamsJSON               MSTeams
+	mentionJSON               Mention
+	mentionedJSON             Mentioned
+	refreshJSON               Refresh
+	authenticationJSON        Authentication
+	tokenExchangeResourceJSON TokenExchangeResource
+	authCardButtonJSON        AuthCardButton
+	backgroundImageJSON       BackgroundImage
+	elementJSON               Element
+	mediaSourceJSON           MediaSource
+	captionSourceJSON         CaptionSource
+	choiceJSON                Choice
+	columnJSON                Column
+	factJSON                  Fact
+	tableCellJSON             TableCell
+	tableRowJSON              TableRow
+	actionJSON                Action
+	targetElementJSON         TargetElement
+	iSelectActionJSON         ISelectAction
+)
+
+// MarshalJSON implements the json.Marshaler interface.
+func (m Message) MarshalJSON() ([]byte, error) {
+	return marshalWithUnknownFields(messageJSON(m), m.UnknownFields)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (a Attachment) MarshalJSON() ([]byte, error) {
+	return marshalWithUnknownFields(attachmentJSON(a), a.UnknownFields)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (c Card) MarshalJSON() ([]byte, error) {
+	return marshalWithUnknownFields(cardJSON(c), c.UnknownFields)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (mst MSTeams) MarshalJSON() ([]byte, error) {
+	return marshalWithUnknownFields(msTeamsJSON(mst), mst.UnknownFields)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (m Mention) MarshalJSON() ([]byte, error) {
+	return marshalWithUnknownFields(mentionJSON(m), m.UnknownFields)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (m Mentioned) MarshalJSON() ([]byte, error) {
+	return marshalWithUnknownFields(mentionedJSON(m), m.UnknownFields)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (r Refresh) MarshalJSON() ([]byte, error) {
+	return marshalWithUnknownFields(refreshJSON(r), r.UnknownFields)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (a Authentication) MarshalJSON() ([]byte, error) {
+	return marshalWithUnknownFields(authenticationJSON(a), a.UnknownFields)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (ter TokenExchangeResource) MarshalJSON() ([]byte, error) {
+	return marshalWithUnknownFields(tokenExchangeResourceJSON(ter), ter.UnknownFields)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (acb AuthCardButton) MarshalJSON() ([]byte, error) {
+	return marshalWithUnknownFields(authCardButtonJSON(acb), acb.UnknownFields)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (bi BackgroundImage) MarshalJSON() ([]byte, error) {
+	return marshalWithUnknownFields(backgroundImageJSON(bi), bi.UnknownFields)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (e Element) MarshalJSON() ([]byte, error) {
+	return marshalWithUnknownFields(elementJSON(e), e.UnknownFields)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (ms MediaSource) MarshalJSON() ([]byte, error) {
+	return marshalWithUnknownFields(mediaSourceJSON(ms), ms.UnknownFields)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (cs CaptionSource) MarshalJSON() ([]byte, error) {
+	return marshalWithUnknownFields(captionSourceJSON(cs), cs.UnknownFields)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (c Choice) MarshalJSON() ([]byte, error) {
+	return marshalWithUnknownFields(choiceJSON(c), c.UnknownFields)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (c Column) MarshalJSON() ([]byte, error) {
+	return marshalWithUnknownFields(columnJSON(c), c.UnknownFields)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (f Fact) MarshalJSON() ([]byte, error) {
+	return marshalWithUnknownFields(factJSON(f), f.UnknownFields)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (tc TableCell) MarshalJSON() ([]byte, error) {
+	return marshalWithUnknownFields(tableCellJSON(tc), tc.UnknownFields)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (tr TableRow) MarshalJSON() ([]byte, error) {
+	return marshalWithUnknownFields(tableRowJSON(tr), tr.UnknownFields)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (a Action) MarshalJSON() ([]byte, error) {
+	return marshalWithUnknownFields(actionJSON(a), a.UnknownFields)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (te TargetElement) MarshalJSON() ([]byte, error) {
+	return marshalWithUnknownFields(targetElementJSON(te), te.UnknownFields)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (isa ISelectAction) MarshalJSON() ([]byte, error) {
+	return marshalWithUnknownFields(iSelectActionJSON(isa), isa.UnknownFields)
+}
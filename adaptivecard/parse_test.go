@@ -0,0 +1,169 @@
+// Copyright 2024 Adam Chalkley
+//
+// https://github.com/atc0005/go-teams-notify
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package adaptivecard
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCard(t *testing.T) {
+	tests := map[string]struct {
+		input      string
+		wantIssues ParseIssues
+	}{
+		"known properties": {
+			input: `{"type":"AdaptiveCard","version":"1.5","body":[{"type":"TextBlock","text":"hi","wrap":true}]}`,
+		},
+		"case-insensitive property names": {
+			input: `{"type":"AdaptiveCard","version":"1.5","body":[{"type":"TextBlock","Text":"hi","WRAP":true}]}`,
+		},
+		"unknown card property": {
+			input: `{"type":"AdaptiveCard","version":"1.5","speak":"hello"}`,
+			wantIssues: ParseIssues{
+				{Kind: ParseIssueUnknownProperty, Path: "$.speak", Name: "speak"},
+			},
+		},
+		"unknown nested properties": {
+			input: `{"type":"AdaptiveCard","version":"1.5","body":[{"type":"Container","items":[{"type":"TextBlock","text":"hi","foo":1}]}],"actions":[{"type":"Action.OpenUrl","url":"https://example.com","bar":true}]}`,
+			wantIssues: ParseIssues{
+				{Kind: ParseIssueUnknownProperty, Path: "$.actions[0].bar", Name: "bar"},
+				{Kind: ParseIssueUnknownProperty, Path: "$.body[0].items[0].foo", Name: "foo"},
+			},
+		},
+		"unknown element type": {
+			input: `{"type":"AdaptiveCard","version":"1.5","body":[{"type":"Carousel"}]}`,
+			wantIssues: ParseIssues{
+				{Kind: ParseIssueUnknownElementType, Path: "$.body[0].type", Name: "Carousel"},
+			},
+		},
+		"unknown action type": {
+			input: `{"type":"AdaptiveCard","version":"1.5","actions":[{"type":"Action.Popover","title":"a"}]}`,
+			wantIssues: ParseIssues{
+				{Kind: ParseIssueUnknownActionType, Path: "$.actions[0].type", Name: "Action.Popover"},
+			},
+		},
+		"unknown select action type": {
+			input: `{"type":"AdaptiveCard","version":"1.5","body":[{"type":"Container","selectAction":{"type":"Action.ShowCard"}}]}`,
+			wantIssues: ParseIssues{
+				{Kind: ParseIssueUnknownActionType, Path: "$.body[0].selectAction.type", Name: "Action.ShowCard"},
+			},
+		},
+		"unknown property within fallback": {
+			input: `{"type":"AdaptiveCard","version":"1.5","body":[{"type":"Table","fallback":{"type":"TextBlock","text":"a","baz":1}}]}`,
+			wantIssues: ParseIssues{
+				{Kind: ParseIssueUnknownProperty, Path: "$.body[0].fallback.baz", Name: "baz"},
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		name, tt := name, tt
+
+		t.Run(name, func(t *testing.T) {
+			card, issues, err := ParseCard([]byte(tt.input), ParseModeLenient)
+			mustNoError(t, err)
+			assert.Equal(t, tt.wantIssues, issues)
+			assert.Equal(t, "1.5", card.Version)
+
+			_, issues, err = ParseCard([]byte(tt.input), ParseModeStrict)
+			if len(tt.wantIssues) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+
+			var parseErr *ParseError
+			if assert.True(t, errors.As(err, &parseErr)) {
+				assert.Equal(t, tt.wantIssues, parseErr.Issues)
+			}
+			assert.Equal(t, tt.wantIssues, issues)
+
+			switch tt.wantIssues[0].Kind {
+			case ParseIssueUnknownProperty:
+				assert.True(t, errors.Is(err, ErrUnknownProperty))
+			default:
+				assert.True(t, errors.Is(err, ErrInvalidType))
+			}
+		})
+	}
+}
+
+func TestParseCardCaseInsensitiveProperties(t *testing.T) {
+	card, issues, err := ParseCard([]byte(`{"type":"AdaptiveCard","body":[{"type":"TextBlock","Text":"hi"}]}`), ParseModeStrict)
+	mustNoError(t, err)
+	assert.Empty(t, issues)
+
+	if assert.Len(t, card.Body, 1) {
+		assert.Equal(t, "hi", card.Body[0].Text)
+		assert.Empty(t, card.Body[0].UnknownFields)
+	}
+
+	b, err := json.Marshal(card.Body[0])
+	mustNoError(t, err)
+	assert.Equal(t, `{"type":"TextBlock","text":"hi"}`, string(b))
+
+	// Unknown fields matching a known property are not encoded twice.
+	element := NewTextBlock("hi", false)
+	element.UnknownFields = map[string]json.RawMessage{"Text": json.RawMessage(`"other"`)}
+
+	b, err = json.Marshal(element)
+	mustNoError(t, err)
+	assert.Equal(t, `{"type":"TextBlock","text":"hi"}`, string(b))
+}
+
+func TestParseRoundTrip(t *testing.T) {
+	tests := map[string]string{
+		"card": `{"type":"AdaptiveCard","$schema":"http://adaptivecards.io/schemas/adaptive-card.json","version":"1.5",` +
+			`"body":[{"type":"TextBlock","text":"hi","wrap":true},` +
+			`{"type":"Container","items":[{"type":"Image","url":"https://example.com/a.png","foo":{"a":[1,2]}}]}],` +
+			`"actions":[{"type":"Action.OpenUrl","title":"Open","url":"https://example.com"}],` +
+			`"msteams":{"width":"Full"},"speak":"hello"}`,
+		"message": `{"type":"message","attachments":[{"contentType":"application/vnd.microsoft.card.adaptive",` +
+			`"content":{"type":"AdaptiveCard","$schema":"http://adaptivecards.io/schemas/adaptive-card.json","version":"1.5",` +
+			`"body":[{"type":"TextBlock","text":"a < b & c"}],"msteams":{},"extra":null},"name":"card"}],"summary":"x"}`,
+	}
+
+	for name, input := range tests {
+		name, input := name, input
+
+		t.Run(name, func(t *testing.T) {
+			var v interface{}
+
+			switch name {
+			case "message":
+				msg, _, err := ParseMessage([]byte(input), ParseModeLenient)
+				mustNoError(t, err)
+				v = msg
+
+			default:
+				card, _, err := ParseCard([]byte(input), ParseModeLenient)
+				mustNoError(t, err)
+				v = card
+			}
+
+			var buf bytes.Buffer
+			enc := json.NewEncoder(&buf)
+			enc.SetEscapeHTML(false)
+			mustNoError(t, enc.Encode(v))
+
+			assert.Equal(t, input+"\n", buf.String())
+		})
+	}
+}
+
+func TestParseCardInvalidJSON(t *testing.T) {
+	_, _, err := ParseCard([]byte(`{"type":`), ParseModeLenient)
+	assert.Error(t, err)
+
+	_, _, err = ParseMessage([]byte(`[]`), ParseModeStrict)
+	assert.Error(t, err)
+}
@@ -0,0 +1,154 @@
+// Copyright 2024 Adam Chalkley
+//
+// https://github.com/atc0005/go-teams-notify
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package presets
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	goteamsnotify "github.com/flashcatcloud/go-teams-notify/v2"
+	"github.com/flashcatcloud/go-teams-notify/v2/adaptivecard"
+)
+
+// Status is the status of a deployment, pipeline or scheduled job.
+type Status string
+
+// Supported Status values.
+const (
+	StatusSucceeded  Status = "succeeded"
+	StatusFailed     Status = "failed"
+	StatusRunning    Status = "running"
+	StatusCancelled  Status = "cancelled"
+	StatusRolledBack Status = "rolled back"
+	StatusSkipped    Status = "skipped"
+)
+
+// Deployment describes the result of deploying a service.
+type Deployment struct {
+	// Service is required; the name of the deployed service.
+	Service string
+
+	// Environment is the target environment (e.g., "production").
+	Environment string
+
+	// Version is the deployed version.
+	Version string
+
+	// PreviousVersion is the version replaced by the deployment.
+	PreviousVersion string
+
+	// Status is required; controls the style of the card header.
+	Status Status
+
+	// Initiator is the user or system which started the deployment.
+	Initiator string
+
+	// StartedAt is when the deployment started.
+	StartedAt time.Time
+
+	// Duration is how long the deployment took.
+	Duration time.Duration
+
+	// Facts are additional name/value pairs describing the deployment.
+	Facts []adaptivecard.Fact
+
+	// Details are collapsible sections with further information (e.g.,
+	// changelog).
+	Details []Section
+
+	// Links are buttons to related information (e.g., release notes).
+	Links []Link
+
+	// Owners are mentioned on the card.
+	Owners []Owner
+}
+
+// supportedStatusValues returns a list of valid Status values. This list is
+// intended to be used for validation and display purposes.
+func supportedStatusValues() []string {
+	return []string{
+		string(StatusSucceeded),
+		string(StatusFailed),
+		string(StatusRunning),
+		string(StatusCancelled),
+		string(StatusRolledBack),
+		string(StatusSkipped),
+	}
+}
+
+// style returns the header style for the status.
+func (s Status) style() badgeStyle {
+	switch s {
+	case StatusSucceeded:
+		return styleGood
+	case StatusFailed:
+		return styleAttention
+	case StatusCancelled, StatusRolledBack:
+		return styleWarning
+	case StatusRunning:
+		return styleAccent
+	default:
+		return styleDefault
+	}
+}
+
+// validate asserts that the status is supported.
+func (s Status) validate() error {
+	if !goteamsnotify.InList(string(s), supportedStatusValues(), false) {
+		return fmt.Errorf(
+			"invalid status %q; expected one of %v: %w",
+			s,
+			supportedStatusValues(),
+			adaptivecard.ErrInvalidFieldValue,
+		)
+	}
+
+	return nil
+}
+
+// NewDeploymentCard creates a Card for the given Deployment. The card header
+// is styled using the status: good for succeeded, attention for failed,
+// warning for cancelled and rolled back and accent for running.
+func NewDeploymentCard(deployment Deployment) (adaptivecard.Card, error) {
+	if err := deployment.Status.validate(); err != nil {
+		return adaptivecard.Card{}, err
+	}
+
+	if strings.TrimSpace(deployment.Service) == "" {
+		return adaptivecard.Card{}, fmt.Errorf(
+			"required service name is empty: %w",
+			adaptivecard.ErrMissingValue,
+		)
+	}
+
+	title := "Deployment of " + deployment.Service
+	if deployment.Environment != "" {
+		title += " to " + deployment.Environment
+	}
+
+	var facts []adaptivecard.Fact
+	facts = appendFact(facts, "Service", deployment.Service)
+	facts = appendFact(facts, "Environment", deployment.Environment)
+	facts = appendFact(facts, "Version", deployment.Version)
+	facts = appendFact(facts, "Previous version", deployment.PreviousVersion)
+	facts = appendFact(facts, "Initiated by", deployment.Initiator)
+	facts = appendFact(facts, "Started", formatTime(deployment.StartedAt))
+	facts = appendFact(facts, "Duration", formatDuration(deployment.Duration))
+	facts = append(facts, deployment.Facts...)
+
+	return layout{
+		title:    title,
+		badge:    string(deployment.Status),
+		style:    deployment.Status.style(),
+		facts:    facts,
+		sections: deployment.Details,
+		links:    deployment.Links,
+		owners:   deployment.Owners,
+	}.card()
+}
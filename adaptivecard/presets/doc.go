@@ -0,0 +1,28 @@
+// Copyright 2024 Adam Chalkley
+//
+// https://github.com/atc0005/go-teams-notify
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+/*
+Package presets provides parameterized constructors for common notification
+card layouts: incidents, deployments, CI/CD pipeline results and scheduled
+(cron) job results.
+
+Each card uses the same general layout:
+
+  - a header Container styled to match the severity or status (e.g.,
+    attention for critical incidents or failed deployments) holding the title
+    and a severity/status badge
+  - optional summary text
+  - a FactSet of the given facts
+  - collapsible detail sections, each hidden by default and shown using an
+    Action.ToggleVisibility button
+  - Action.OpenUrl buttons for the given links (e.g., runbook, dashboard)
+  - a mention of each owner (e.g., the on-call engineer)
+
+The returned Card is validated and may be sent using
+adaptivecard.NewMessageFromCard.
+*/
+package presets
@@ -0,0 +1,119 @@
+// Copyright 2024 Adam Chalkley
+//
+// https://github.com/atc0005/go-teams-notify
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package presets
+
+import (
+	"fmt"
+	"time"
+
+	goteamsnotify "github.com/flashcatcloud/go-teams-notify/v2"
+	"github.com/flashcatcloud/go-teams-notify/v2/adaptivecard"
+)
+
+// Severity is the severity of an Incident.
+type Severity string
+
+// Supported Incident severities.
+const (
+	SeverityCritical Severity = "critical"
+	SeverityHigh     Severity = "high"
+	SeverityMedium   Severity = "medium"
+	SeverityLow      Severity = "low"
+	SeverityInfo     Severity = "info"
+	SeverityResolved Severity = "resolved"
+)
+
+// Incident describes an alert or incident notification.
+type Incident struct {
+	// Severity is required; controls the style of the card header.
+	Severity Severity
+
+	// Title is required; a short description of the incident.
+	Title string
+
+	// Summary is optional text displayed after the header. Markdown is
+	// supported.
+	Summary string
+
+	// Source is the system or monitor raising the incident.
+	Source string
+
+	// StartedAt is when the incident started.
+	StartedAt time.Time
+
+	// Facts are additional name/value pairs describing the incident.
+	Facts []adaptivecard.Fact
+
+	// Details are collapsible sections with further information (e.g.,
+	// check output).
+	Details []Section
+
+	// Links are buttons to related information (e.g., runbook, dashboard).
+	Links []Link
+
+	// Owners are mentioned on the card (e.g., on-call engineers).
+	Owners []Owner
+}
+
+// supportedSeverityValues returns a list of valid Severity values. This list
+// is intended to be used for validation and display purposes.
+func supportedSeverityValues() []string {
+	return []string{
+		string(SeverityCritical),
+		string(SeverityHigh),
+		string(SeverityMedium),
+		string(SeverityLow),
+		string(SeverityInfo),
+		string(SeverityResolved),
+	}
+}
+
+// style returns the header style for the severity.
+func (s Severity) style() badgeStyle {
+	switch s {
+	case SeverityCritical, SeverityHigh:
+		return styleAttention
+	case SeverityMedium:
+		return styleWarning
+	case SeverityResolved:
+		return styleGood
+	default:
+		return styleAccent
+	}
+}
+
+// NewIncidentCard creates a Card for the given Incident. The card header is
+// styled using the severity: attention for critical and high, warning for
+// medium, good for resolved and accent otherwise.
+func NewIncidentCard(incident Incident) (adaptivecard.Card, error) {
+	if !goteamsnotify.InList(string(incident.Severity), supportedSeverityValues(), false) {
+		return adaptivecard.Card{}, fmt.Errorf(
+			"invalid severity %q; expected one of %v: %w",
+			incident.Severity,
+			supportedSeverityValues(),
+			adaptivecard.ErrInvalidFieldValue,
+		)
+	}
+
+	var facts []adaptivecard.Fact
+	facts = appendFact(facts, "Severity", string(incident.Severity))
+	facts = appendFact(facts, "Source", incident.Source)
+	facts = appendFact(facts, "Started", formatTime(incident.StartedAt))
+	facts = append(facts, incident.Facts...)
+
+	return layout{
+		title:    incident.Title,
+		badge:    string(incident.Severity),
+		style:    incident.Severity.style(),
+		summary:  incident.Summary,
+		facts:    facts,
+		sections: incident.Details,
+		links:    incident.Links,
+		owners:   incident.Owners,
+	}.card()
+}
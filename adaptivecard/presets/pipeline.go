@@ -0,0 +1,187 @@
+// Copyright 2024 Adam Chalkley
+//
+// https://github.com/atc0005/go-teams-notify
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package presets
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/flashcatcloud/go-teams-notify/v2/adaptivecard"
+)
+
+// Pipeline describes the result of a CI/CD pipeline run.
+type Pipeline struct {
+	// Name is required; the name of the pipeline.
+	Name string
+
+	// RunID identifies the pipeline run (e.g., build number).
+	RunID string
+
+	// Repository is the repository the pipeline ran for.
+	Repository string
+
+	// Branch is the branch the pipeline ran for.
+	Branch string
+
+	// Commit is the commit the pipeline ran for.
+	Commit string
+
+	// Author is the author of the commit or the user which triggered the
+	// pipeline.
+	Author string
+
+	// Status is required; controls the style of the card header.
+	Status Status
+
+	// Duration is how long the pipeline took.
+	Duration time.Duration
+
+	// Facts are additional name/value pairs describing the run (e.g., test
+	// counts).
+	Facts []adaptivecard.Fact
+
+	// Details are collapsible sections with further information (e.g.,
+	// failed jobs or test output).
+	Details []Section
+
+	// Links are buttons to related information (e.g., build log).
+	Links []Link
+
+	// Owners are mentioned on the card.
+	Owners []Owner
+}
+
+// CronJob describes the result of a scheduled job run.
+type CronJob struct {
+	// Name is required; the name of the job.
+	Name string
+
+	// Host is the host the job ran on.
+	Host string
+
+	// Schedule is the schedule of the job (e.g., "0 2 * * *").
+	Schedule string
+
+	// Status is required; controls the style of the card header.
+	Status Status
+
+	// ExitCode is the exit code of the job. This is omitted for jobs with
+	// the running or skipped status.
+	ExitCode int
+
+	// StartedAt is when the job started.
+	StartedAt time.Time
+
+	// Duration is how long the job took.
+	Duration time.Duration
+
+	// NextRun is when the job is next scheduled to run.
+	NextRun time.Time
+
+	// Output is the (truncated) job output, displayed within a collapsible
+	// section.
+	Output string
+
+	// Facts are additional name/value pairs describing the run.
+	Facts []adaptivecard.Fact
+
+	// Details are collapsible sections with further information.
+	Details []Section
+
+	// Links are buttons to related information.
+	Links []Link
+
+	// Owners are mentioned on the card.
+	Owners []Owner
+}
+
+// NewPipelineCard creates a Card for the given Pipeline run. The card header
+// is styled using the status; see NewDeploymentCard.
+func NewPipelineCard(pipeline Pipeline) (adaptivecard.Card, error) {
+	if err := pipeline.Status.validate(); err != nil {
+		return adaptivecard.Card{}, err
+	}
+
+	if strings.TrimSpace(pipeline.Name) == "" {
+		return adaptivecard.Card{}, fmt.Errorf(
+			"required pipeline name is empty: %w",
+			adaptivecard.ErrMissingValue,
+		)
+	}
+
+	title := "Pipeline " + pipeline.Name
+	if pipeline.RunID != "" {
+		title += " #" + pipeline.RunID
+	}
+
+	var facts []adaptivecard.Fact
+	facts = appendFact(facts, "Repository", pipeline.Repository)
+	facts = appendFact(facts, "Branch", pipeline.Branch)
+	facts = appendFact(facts, "Commit", pipeline.Commit)
+	facts = appendFact(facts, "Author", pipeline.Author)
+	facts = appendFact(facts, "Duration", formatDuration(pipeline.Duration))
+	facts = append(facts, pipeline.Facts...)
+
+	return layout{
+		title:    title,
+		badge:    string(pipeline.Status),
+		style:    pipeline.Status.style(),
+		facts:    facts,
+		sections: pipeline.Details,
+		links:    pipeline.Links,
+		owners:   pipeline.Owners,
+	}.card()
+}
+
+// NewCronJobCard creates a Card for the given scheduled job run. The card
+// header is styled using the status; see NewDeploymentCard. Job output is
+// displayed within a collapsible section preceding any other Details.
+func NewCronJobCard(job CronJob) (adaptivecard.Card, error) {
+	if err := job.Status.validate(); err != nil {
+		return adaptivecard.Card{}, err
+	}
+
+	if strings.TrimSpace(job.Name) == "" {
+		return adaptivecard.Card{}, fmt.Errorf(
+			"required job name is empty: %w",
+			adaptivecard.ErrMissingValue,
+		)
+	}
+
+	var facts []adaptivecard.Fact
+	facts = appendFact(facts, "Host", job.Host)
+	facts = appendFact(facts, "Schedule", job.Schedule)
+	if job.Status != StatusRunning && job.Status != StatusSkipped {
+		facts = appendFact(facts, "Exit code", fmt.Sprint(job.ExitCode))
+	}
+	facts = appendFact(facts, "Started", formatTime(job.StartedAt))
+	facts = appendFact(facts, "Duration", formatDuration(job.Duration))
+	facts = appendFact(facts, "Next run", formatTime(job.NextRun))
+	facts = append(facts, job.Facts...)
+
+	sections := job.Details
+	if strings.TrimSpace(job.Output) != "" {
+		output := Section{
+			Title:     "Show output",
+			Text:      job.Output,
+			Monospace: true,
+		}
+		sections = append([]Section{output}, job.Details...)
+	}
+
+	return layout{
+		title:    "Scheduled job " + job.Name,
+		badge:    string(job.Status),
+		style:    job.Status.style(),
+		facts:    facts,
+		sections: sections,
+		links:    job.Links,
+		owners:   job.Owners,
+	}.card()
+}
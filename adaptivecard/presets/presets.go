@@ -0,0 +1,234 @@
+// Copyright 2024 Adam Chalkley
+//
+// https://github.com/atc0005/go-teams-notify
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package presets
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/flashcatcloud/go-teams-notify/v2/adaptivecard"
+)
+
+// TimeFormat is the layout used for timestamps included as facts.
+const TimeFormat string = time.RFC1123
+
+// sectionIDFormat is the format used to generate the ID of the Container
+// holding each collapsible detail section.
+const sectionIDFormat string = "presetDetails%d"
+
+// Link is rendered as an Action.OpenUrl button (e.g., a runbook or
+// dashboard link).
+type Link struct {
+	// Title is the button text.
+	Title string
+
+	// URL is the link target.
+	URL string
+}
+
+// Owner is a user mentioned on the card (e.g., the on-call engineer).
+type Owner struct {
+	// DisplayName is the name of the user.
+	DisplayName string
+
+	// ID is the object ID or UserPrincipalName of the user.
+	ID string
+}
+
+// Section is a collapsible detail section. Sections are hidden by default
+// and shown using an Action.ToggleVisibility button.
+type Section struct {
+	// Title is the text of the button used to show or hide the section.
+	Title string
+
+	// Text is displayed within the section. Markdown is supported.
+	Text string
+
+	// Monospace displays Text using a monospace font if true (e.g., for
+	// command output).
+	Monospace bool
+
+	// Facts are displayed within the section after Text.
+	Facts []adaptivecard.Fact
+}
+
+// badgeStyle controls how the header of a card is displayed.
+type badgeStyle struct {
+	containerStyle string
+	color          string
+}
+
+var (
+	styleAttention = badgeStyle{adaptivecard.ContainerStyleAttention, adaptivecard.ColorAttention}
+	styleWarning   = badgeStyle{adaptivecard.ContainerStyleWarning, adaptivecard.ColorWarning}
+	styleGood      = badgeStyle{adaptivecard.ContainerStyleGood, adaptivecard.ColorGood}
+	styleAccent    = badgeStyle{adaptivecard.ContainerStyleAccent, adaptivecard.ColorAccent}
+	styleDefault   = badgeStyle{adaptivecard.ContainerStyleEmphasis, adaptivecard.ColorDefault}
+)
+
+// layout holds the content shared by each of the preset cards.
+type layout struct {
+	title    string
+	badge    string
+	style    badgeStyle
+	summary  string
+	facts    []adaptivecard.Fact
+	sections []Section
+	links    []Link
+	owners   []Owner
+}
+
+// card assembles and validates a Card from the layout.
+func (l layout) card() (adaptivecard.Card, error) {
+	if strings.TrimSpace(l.title) == "" {
+		return adaptivecard.Card{}, fmt.Errorf(
+			"required title is empty: %w",
+			adaptivecard.ErrMissingValue,
+		)
+	}
+
+	header, err := l.header()
+	if err != nil {
+		return adaptivecard.Card{}, err
+	}
+
+	b := adaptivecard.Build().Element(header)
+
+	if l.summary != "" {
+		b.Text(l.summary)
+	}
+
+	if len(l.facts) > 0 {
+		b.Facts(l.facts...)
+	}
+
+	for i, section := range l.sections {
+		elements, err := section.elements()
+		if err != nil {
+			return adaptivecard.Card{}, fmt.Errorf("section %d: %w", i, err)
+		}
+		b.Toggle(section.Title, fmt.Sprintf(sectionIDFormat, i+1), elements...)
+	}
+
+	for _, link := range l.links {
+		b.Button(link.Title, link.URL)
+	}
+
+	if len(l.owners) > 0 {
+		mentions := make([]adaptivecard.Mention, 0, len(l.owners))
+		for _, owner := range l.owners {
+			mention, err := adaptivecard.NewMention(owner.DisplayName, owner.ID)
+			if err != nil {
+				return adaptivecard.Card{}, fmt.Errorf(
+					"owner %q: %w",
+					owner.DisplayName,
+					err,
+				)
+			}
+			mentions = append(mentions, mention)
+		}
+		b.MentionUsers(mentions...)
+	}
+
+	return b.FullWidth().Card()
+}
+
+// header returns a Container holding the title and badge, styled using the
+// badge style.
+func (l layout) header() (adaptivecard.Element, error) {
+	container := adaptivecard.NewContainer()
+	container.Style = l.style.containerStyle
+	container.Bleed = true
+
+	if err := container.AddElement(false, adaptivecard.NewTitleTextBlock(l.title, true)); err != nil {
+		return adaptivecard.Element{}, err
+	}
+
+	if l.badge != "" {
+		badge := adaptivecard.NewTextBlock(strings.ToUpper(l.badge), false)
+		badge.Color = l.style.color
+		badge.Weight = adaptivecard.WeightBolder
+		badge.Size = adaptivecard.SizeSmall
+		badge.Spacing = adaptivecard.SpacingNone
+
+		if err := container.AddElement(false, badge); err != nil {
+			return adaptivecard.Element{}, err
+		}
+	}
+
+	return adaptivecard.Element(container), nil
+}
+
+// elements returns the Elements displayed within the section.
+func (s Section) elements() ([]adaptivecard.Element, error) {
+	if strings.TrimSpace(s.Title) == "" {
+		return nil, fmt.Errorf(
+			"required section title is empty: %w",
+			adaptivecard.ErrMissingValue,
+		)
+	}
+
+	var elements []adaptivecard.Element
+
+	if s.Text != "" {
+		textBlock := adaptivecard.NewTextBlock(s.Text, true)
+		if s.Monospace {
+			textBlock.FontType = adaptivecard.FontTypeMonospace
+		}
+		elements = append(elements, textBlock)
+	}
+
+	if len(s.Facts) > 0 {
+		factSet := adaptivecard.NewFactSet()
+		if err := factSet.AddFact(s.Facts...); err != nil {
+			return nil, err
+		}
+		elements = append(elements, adaptivecard.Element(factSet))
+	}
+
+	if len(elements) == 0 {
+		return nil, fmt.Errorf(
+			"section %q has no text or facts: %w",
+			s.Title,
+			adaptivecard.ErrMissingValue,
+		)
+	}
+
+	return elements, nil
+}
+
+// appendFact appends a Fact with the given title and value to the given
+// facts if the value is not empty.
+func appendFact(facts []adaptivecard.Fact, title string, value string) []adaptivecard.Fact {
+	if strings.TrimSpace(value) == "" {
+		return facts
+	}
+
+	return append(facts, adaptivecard.Fact{Title: title, Value: value})
+}
+
+// formatTime formats the given time for use as a fact value. The zero value
+// is formatted as an empty string.
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.Format(TimeFormat)
+}
+
+// formatDuration formats the given duration for use as a fact value. A
+// duration of zero is formatted as an empty string.
+func formatDuration(d time.Duration) string {
+	if d == 0 {
+		return ""
+	}
+
+	return d.Round(time.Second).String()
+}
@@ -0,0 +1,161 @@
+// Copyright 2024 Adam Chalkley
+//
+// https://github.com/atc0005/go-teams-notify
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package presets
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/flashcatcloud/go-teams-notify/v2/adaptivecard"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewIncidentCard(t *testing.T) {
+	incident := Incident{
+		Severity:  SeverityCritical,
+		Title:     "Disk usage high on db01",
+		Summary:   "Usage of **/var** exceeds 95%.",
+		Source:    "nagios",
+		StartedAt: time.Date(2024, time.March, 5, 14, 7, 0, 0, time.UTC),
+		Facts:     []adaptivecard.Fact{{Title: "Host", Value: "db01"}},
+		Details:   []Section{{Title: "Check output", Text: "DISK CRITICAL - /var 97%", Monospace: true}},
+		Links:     []Link{{Title: "Runbook", URL: "https://example.com/runbook"}},
+		Owners:    []Owner{{DisplayName: "John Doe", ID: "john.doe@example.com"}},
+	}
+
+	card, err := NewIncidentCard(incident)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// Header, summary, facts, details container and mention text.
+	if !assert.Len(t, card.Body, 5) {
+		return
+	}
+
+	header := card.Body[0]
+	assert.Equal(t, adaptivecard.ContainerStyleAttention, header.Style)
+	assert.Equal(t, incident.Title, header.Items[0].Text)
+	assert.Equal(t, "CRITICAL", header.Items[1].Text)
+	assert.Equal(t, adaptivecard.ColorAttention, header.Items[1].Color)
+
+	assert.Equal(t, []adaptivecard.Fact{
+		{Title: "Severity", Value: "critical"},
+		{Title: "Source", Value: "nagios"},
+		{Title: "Started", Value: "Tue, 05 Mar 2024 14:07:00 UTC"},
+		{Title: "Host", Value: "db01"},
+	}, card.Body[2].Facts)
+
+	details := card.Body[3]
+	assert.Equal(t, "presetDetails1", details.ID)
+	if assert.NotNil(t, details.Visible) {
+		assert.False(t, *details.Visible)
+	}
+	assert.Equal(t, adaptivecard.FontTypeMonospace, details.Items[0].FontType)
+
+	if assert.Len(t, card.Actions, 2) {
+		assert.Equal(t, adaptivecard.TypeActionToggleVisibility, card.Actions[0].Type)
+		assert.Equal(t, "presetDetails1", card.Actions[0].TargetElements[0].ElementID)
+		assert.Equal(t, adaptivecard.TypeActionOpenURL, card.Actions[1].Type)
+	}
+
+	assert.Len(t, card.MSTeams.Entities, 1)
+}
+
+func TestSeverityStyles(t *testing.T) {
+	tests := map[Severity]string{
+		SeverityCritical: adaptivecard.ContainerStyleAttention,
+		SeverityHigh:     adaptivecard.ContainerStyleAttention,
+		SeverityMedium:   adaptivecard.ContainerStyleWarning,
+		SeverityLow:      adaptivecard.ContainerStyleAccent,
+		SeverityInfo:     adaptivecard.ContainerStyleAccent,
+		SeverityResolved: adaptivecard.ContainerStyleGood,
+	}
+
+	for severity, want := range tests {
+		card, err := NewIncidentCard(Incident{Severity: severity, Title: "x"})
+		if assert.NoError(t, err, severity) {
+			assert.Equal(t, want, card.Body[0].Style, severity)
+		}
+	}
+
+	_, err := NewIncidentCard(Incident{Severity: "urgent", Title: "x"})
+	assert.True(t, errors.Is(err, adaptivecard.ErrInvalidFieldValue))
+
+	_, err = NewIncidentCard(Incident{Severity: SeverityLow})
+	assert.True(t, errors.Is(err, adaptivecard.ErrMissingValue))
+}
+
+func TestNewDeploymentCard(t *testing.T) {
+	card, err := NewDeploymentCard(Deployment{
+		Service:     "billing",
+		Environment: "production",
+		Version:     "v1.4.2",
+		Status:      StatusRolledBack,
+		Duration:    90*time.Second + 400*time.Millisecond,
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, "Deployment of billing to production", card.Body[0].Items[0].Text)
+	assert.Equal(t, adaptivecard.ContainerStyleWarning, card.Body[0].Style)
+	assert.Contains(t, card.Body[1].Facts, adaptivecard.Fact{Title: "Duration", Value: "1m30s"})
+
+	_, err = NewDeploymentCard(Deployment{Service: "billing", Status: "done"})
+	assert.True(t, errors.Is(err, adaptivecard.ErrInvalidFieldValue))
+
+	_, err = NewDeploymentCard(Deployment{Status: StatusSucceeded})
+	assert.True(t, errors.Is(err, adaptivecard.ErrMissingValue))
+}
+
+func TestNewPipelineCard(t *testing.T) {
+	card, err := NewPipelineCard(Pipeline{
+		Name:    "build",
+		RunID:   "42",
+		Branch:  "main",
+		Status:  StatusFailed,
+		Details: []Section{{Title: "Failed jobs", Facts: []adaptivecard.Fact{{Title: "test", Value: "3 failures"}}}},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, "Pipeline build #42", card.Body[0].Items[0].Text)
+	assert.Equal(t, adaptivecard.ContainerStyleAttention, card.Body[0].Style)
+	assert.Equal(t, adaptivecard.TypeElementFactSet, card.Body[2].Items[0].Type)
+
+	_, err = NewPipelineCard(Pipeline{Name: "build", Status: StatusSucceeded, Details: []Section{{Title: "empty"}}})
+	assert.True(t, errors.Is(err, adaptivecard.ErrMissingValue))
+}
+
+func TestNewCronJobCard(t *testing.T) {
+	card, err := NewCronJobCard(CronJob{
+		Name:     "backup",
+		Schedule: "0 2 * * *",
+		Status:   StatusSucceeded,
+		Output:   "backup complete",
+		Details:  []Section{{Title: "More", Text: "details"}},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, adaptivecard.ContainerStyleGood, card.Body[0].Style)
+	assert.Contains(t, card.Body[1].Facts, adaptivecard.Fact{Title: "Exit code", Value: "0"})
+
+	// Output precedes the other detail sections.
+	assert.Equal(t, "backup complete", card.Body[2].Items[0].Text)
+	assert.Equal(t, "details", card.Body[3].Items[0].Text)
+
+	card, err = NewCronJobCard(CronJob{Name: "backup", Schedule: "@hourly", Status: StatusRunning})
+	if assert.NoError(t, err) {
+		assert.NotContains(t, card.Body[1].Facts, adaptivecard.Fact{Title: "Exit code", Value: "0"})
+	}
+}
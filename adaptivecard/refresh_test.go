@@ -0,0 +1,148 @@
+// Copyright 2024 Adam Chalkley
+//
+// https://github.com/atc0005/go-teams-notify
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package adaptivecard
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// mustNoError stops the test if the given error is non-nil.
+func mustNoError(t *testing.T, err error) {
+	t.Helper()
+
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+}
+
+// userIDs returns n unique user IDs.
+func userIDs(n int) []string {
+	ids := make([]string, n)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("user-%d", i)
+	}
+
+	return ids
+}
+
+func TestNewRefresh(t *testing.T) {
+	refresh, err := NewRefresh("refresh", map[string]interface{}{"id": 1}, userIDs(RefreshUserIDsLimit)...)
+	if assert.NoError(t, err) {
+		assert.Equal(t, TypeActionExecute, refresh.Action.Type)
+		assert.Equal(t, "refresh", refresh.Action.Verb)
+		assert.Len(t, refresh.UserIDs, RefreshUserIDsLimit)
+	}
+
+	_, err = NewRefresh("refresh", nil, userIDs(RefreshUserIDsLimit+1)...)
+	assert.True(t, errors.Is(err, ErrInvalidFieldValue))
+
+	_, err = NewRefresh("refresh", nil, "user-1", " ")
+	assert.True(t, errors.Is(err, ErrMissingValue))
+
+	_, err = NewRefresh("refresh", 42)
+	assert.True(t, errors.Is(err, ErrInvalidFieldValue))
+}
+
+func TestRefreshValidation(t *testing.T) {
+	tests := map[string]struct {
+		refresh Refresh
+		wantErr error
+	}{
+		"Action.Execute": {
+			refresh: Refresh{Action: Action{Type: TypeActionExecute, Verb: "refresh"}},
+		},
+		"Action.Submit": {
+			refresh: Refresh{Action: Action{Type: TypeActionSubmit}},
+			wantErr: ErrInvalidType,
+		},
+		"missing action": {
+			refresh: Refresh{},
+			wantErr: ErrInvalidType,
+		},
+		"user IDs at limit": {
+			refresh: Refresh{Action: Action{Type: TypeActionExecute}, UserIDs: userIDs(RefreshUserIDsLimit)},
+		},
+		"user IDs over limit": {
+			refresh: Refresh{Action: Action{Type: TypeActionExecute}, UserIDs: userIDs(RefreshUserIDsLimit + 1)},
+			wantErr: ErrInvalidFieldValue,
+		},
+	}
+
+	for name, tt := range tests {
+		name, tt := name, tt
+
+		t.Run(name, func(t *testing.T) {
+			err := tt.refresh.Validate()
+
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.True(t, errors.Is(err, tt.wantErr), "got error: %v", err)
+		})
+	}
+}
+
+func TestAuthenticationValidation(t *testing.T) {
+	auth, err := NewAuthentication(
+		"Sign in",
+		"oauth",
+		AuthCardButton{Type: AuthCardButtonTypeSignIn, Value: "https://example.com/signin"},
+	)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "oauth", auth.ConnectionName)
+		assert.Len(t, auth.Buttons, 1)
+	}
+
+	_, err = NewAuthentication("Sign in", "oauth", AuthCardButton{Type: AuthCardButtonTypeSignIn})
+	assert.True(t, errors.Is(err, ErrMissingValue))
+
+	auth.TokenExchangeResource = &TokenExchangeResource{ID: "id", URI: "api://example"}
+	assert.True(t, errors.Is(auth.Validate(), ErrMissingValue))
+
+	auth.TokenExchangeResource.ProviderID = "provider"
+	assert.NoError(t, auth.Validate())
+}
+
+func TestCardSetRefreshAndAuthentication(t *testing.T) {
+	refresh, err := NewRefresh("refresh", nil, "user-1")
+	mustNoError(t, err)
+
+	auth, err := NewAuthentication("Sign in", "oauth")
+	mustNoError(t, err)
+
+	card := NewCard()
+	card.Version = "1.2"
+
+	assert.NoError(t, card.SetRefresh(refresh))
+	assert.Equal(t, "1.4", card.Version)
+
+	card.Version = "1.2"
+	assert.NoError(t, card.SetAuthentication(auth))
+	assert.Equal(t, "1.4", card.Version)
+
+	// A later Card version is left as-is.
+	card.Version = "1.5"
+	assert.NoError(t, card.SetRefresh(refresh))
+	assert.Equal(t, "1.5", card.Version)
+
+	assert.Error(t, card.SetRefresh(Refresh{Action: Action{Type: TypeActionSubmit}}))
+
+	// Top-level Cards declaring an earlier version than required fail
+	// validation.
+	card.Version = "1.2"
+	assert.Error(t, TopLevelCard{card}.Validate())
+
+	card.Version = "1.4"
+	assert.NoError(t, TopLevelCard{card}.Validate())
+}
@@ -0,0 +1,799 @@
+// Copyright 2024 Adam Chalkley
+//
+// https://github.com/atc0005/go-teams-notify
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package adaptivecard
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/flashcatcloud/go-teams-notify/v2/internal/urlsafe"
+)
+
+// renderFormat indicates the output format produced when rendering a Card.
+type renderFormat int
+
+const (
+	renderFormatText renderFormat = iota
+	renderFormatMarkdown
+	renderFormatHTML
+)
+
+// Regular expressions used to convert the subset of Markdown supported by
+// TextBlock text and to replace mention placeholders.
+//
+// https://learn.microsoft.com/en-us/adaptive-cards/authoring-cards/text-features
+var (
+	renderMentionRegex = regexp.MustCompile(`<at>(.*?)</at>`)
+	renderLinkRegex    = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	renderBoldRegex    = regexp.MustCompile(`\*\*(.+?)\*\*`)
+	renderItalicRegex  = regexp.MustCompile(`(^|[\s(])_([^_\s](?:[^_]*[^_\s])?)_($|[\s).,;:!?])`)
+)
+
+// PlainText renders the Card as plain text suitable for logs, CLI output or
+// the body of a plain text email.
+//
+// Supported Markdown is stripped from TextBlock text, user mentions are
+// rendered as @name and Action.OpenUrl buttons are rendered with their URL.
+// Hidden elements are omitted unless they are the target of an
+// Action.ToggleVisibility button, in which case they are rendered after the
+// title of that button. Action.Submit and Action.Execute buttons are omitted.
+func (c Card) PlainText() string {
+	return newCardRenderer(c, renderFormatText).card(c)
+}
+
+// Markdown renders the Card as Markdown suitable for chat clients, issue
+// trackers and README style previews. Hidden elements are handled as
+// described for PlainText.
+func (c Card) Markdown() string {
+	return newCardRenderer(c, renderFormatMarkdown).card(c)
+}
+
+// HTML renders the Card as an HTML fragment suitable for the body of an
+// email or a preview page.
+//
+// All text is escaped; only the subset of Markdown supported by TextBlock
+// text is converted to markup. Links and images are only rendered for http,
+// https and mailto URLs (and data URIs for images). Hidden elements which
+// are the target of an Action.ToggleVisibility button are rendered within a
+// collapsed details element; other hidden elements are omitted.
+func (c Card) HTML() string {
+	return newCardRenderer(c, renderFormatHTML).card(c)
+}
+
+// SetFallbackText sets the FallbackText field to the plain text rendering of
+// the Card if the field is empty. See PlainText for details.
+func (c *Card) SetFallbackText() {
+	if strings.TrimSpace(c.FallbackText) == "" {
+		c.FallbackText = c.PlainText()
+	}
+}
+
+// cardRenderer renders Cards, Elements and Actions in a specific format.
+type cardRenderer struct {
+	format renderFormat
+
+	// toggles maps the ID of each element targeted by an
+	// Action.ToggleVisibility to the title of that action.
+	toggles map[string]string
+}
+
+// newCardRenderer returns a cardRenderer for the given Card and format.
+func newCardRenderer(c Card, format renderFormat) cardRenderer {
+	r := cardRenderer{
+		format:  format,
+		toggles: make(map[string]string),
+	}
+
+	for _, action := range cardActions(c) {
+		if action.Type != TypeActionToggleVisibility {
+			continue
+		}
+
+		for _, target := range action.TargetElements {
+			if _, ok := r.toggles[target.ElementID]; !ok {
+				r.toggles[target.ElementID] = action.Title
+			}
+		}
+	}
+
+	return r
+}
+
+// card renders the body and actions of the given Card.
+func (r cardRenderer) card(c Card) string {
+	blocks := r.elements(c.Body)
+	if actions := r.actions(c.Actions); actions != "" {
+		blocks = append(blocks, actions)
+	}
+
+	if r.format != renderFormatHTML {
+		return strings.Join(blocks, "\n\n")
+	}
+
+	return "<div class=\"adaptivecard\">\n" + strings.Join(blocks, "\n") + "\n</div>"
+}
+
+// join combines rendered blocks into a single block.
+func (r cardRenderer) join(blocks []string) string {
+	if r.format == renderFormatHTML {
+		return strings.Join(blocks, "\n")
+	}
+
+	return strings.Join(blocks, "\n\n")
+}
+
+// elements renders each of the given Elements, returning one block for each
+// Element which produced output.
+func (r cardRenderer) elements(elements []Element) []string {
+	blocks := make([]string, 0, len(elements))
+
+	for _, element := range elements {
+		if block := r.element(element); block != "" {
+			blocks = append(blocks, block)
+		}
+	}
+
+	return blocks
+}
+
+// elementPointers renders each of the given Element pointers. See elements.
+func (r cardRenderer) elementPointers(elements []*Element) []string {
+	blocks := make([]string, 0, len(elements))
+
+	for _, element := range elements {
+		if element == nil {
+			continue
+		}
+
+		if block := r.element(*element); block != "" {
+			blocks = append(blocks, block)
+		}
+	}
+
+	return blocks
+}
+
+// element renders the given Element, handling hidden elements as described
+// for Card.PlainText.
+func (r cardRenderer) element(e Element) string {
+	if e.Visible != nil && !*e.Visible {
+		title, ok := r.toggles[e.ID]
+		if !ok || e.ID == "" {
+			return ""
+		}
+
+		return r.toggled(title, r.visibleElement(e))
+	}
+
+	return r.visibleElement(e)
+}
+
+// toggled renders content which is shown using the button with the given
+// title.
+func (r cardRenderer) toggled(title string, content string) string {
+	if content == "" {
+		return ""
+	}
+
+	switch r.format {
+	case renderFormatHTML:
+		return fmt.Sprintf(
+			"<details>\n<summary>%s</summary>\n%s\n</details>",
+			html.EscapeString(title),
+			content,
+		)
+
+	case renderFormatMarkdown:
+		return fmt.Sprintf("**%s**\n\n%s", title, content)
+
+	default:
+		return title + ":\n" + content
+	}
+}
+
+// visibleElement renders the given Element based on its type.
+func (r cardRenderer) visibleElement(e Element) string {
+	switch e.Type {
+	case TypeElementTextBlock:
+		return r.textBlock(e)
+
+	case TypeElementRichTextBlock:
+		return r.richTextBlock(e)
+
+	case TypeElementFactSet:
+		return r.factSet(e.Facts)
+
+	case TypeElementTable:
+		return r.table(e)
+
+	case TypeElementColumnSet:
+		return r.columnSet(e.Columns)
+
+	case TypeElementContainer:
+		return r.container(e.Style, r.elements(e.Items))
+
+	case TypeElementImage:
+		return r.image(e)
+
+	case TypeElementImageSet:
+		return r.join(r.elements(e.Images))
+
+	case TypeElementMedia:
+		return r.media(e)
+
+	case TypeElementActionSet:
+		return r.actions(e.Actions)
+
+	case TypeElementInputText, TypeElementInputNumber, TypeElementInputDate,
+		TypeElementInputTime, TypeElementInputToggle, TypeElementInputChoiceSet:
+		return r.input(e)
+
+	default:
+		// Render the fallback content for element types unknown to this
+		// package.
+		switch fallback := e.Fallback.(type) {
+		case Element:
+			return r.element(fallback)
+		case *Element:
+			if fallback != nil {
+				return r.element(*fallback)
+			}
+		}
+
+		return ""
+	}
+}
+
+// textBlock renders a TextBlock. Headings (and large text) are rendered as
+// headings and monospace text is rendered as preformatted text.
+func (r cardRenderer) textBlock(e Element) string {
+	text := strings.TrimSpace(e.Text)
+	if text == "" {
+		return ""
+	}
+
+	heading := e.Style == TextBlockStyleHeading ||
+		e.Size == SizeLarge ||
+		e.Size == SizeExtraLarge
+
+	monospace := e.FontType == FontTypeMonospace
+
+	switch r.format {
+	case renderFormatHTML:
+		switch {
+		case monospace:
+			return "<pre>" + html.EscapeString(renderMentions(text)) + "</pre>"
+		case heading:
+			return "<h3>" + r.inline(text) + "</h3>"
+		default:
+			return "<p>" + r.inline(text) + "</p>"
+		}
+
+	case renderFormatMarkdown:
+		switch {
+		case monospace:
+			return "```\n" + renderMentions(text) + "\n```"
+		case heading:
+			return "### " + strings.Join(strings.Fields(r.inline(text)), " ")
+		default:
+			return r.inline(text)
+		}
+
+	default:
+		if monospace {
+			return renderMentions(text)
+		}
+
+		return r.inline(text)
+	}
+}
+
+// richTextBlock renders the TextRun elements of a RichTextBlock as a single
+// paragraph.
+func (r cardRenderer) richTextBlock(e Element) string {
+	var b strings.Builder
+
+	for _, run := range e.Inlines {
+		text := run.Text
+		if text == "" {
+			continue
+		}
+
+		switch r.format {
+		case renderFormatHTML:
+			text = html.EscapeString(renderMentions(text))
+			if run.FontType == FontTypeMonospace {
+				text = "<code>" + text + "</code>"
+			}
+			if run.Weight == WeightBolder {
+				text = "<strong>" + text + "</strong>"
+			}
+			if run.Italic {
+				text = "<em>" + text + "</em>"
+			}
+			if run.Strikethrough {
+				text = "<s>" + text + "</s>"
+			}
+			if run.Underline {
+				text = "<u>" + text + "</u>"
+			}
+			if run.Highlight {
+				text = "<mark>" + text + "</mark>"
+			}
+
+		case renderFormatMarkdown:
+			text = renderMentions(text)
+			if run.FontType == FontTypeMonospace {
+				text = "`" + text + "`"
+			}
+			if run.Weight == WeightBolder {
+				text = "**" + text + "**"
+			}
+			if run.Italic {
+				text = "_" + text + "_"
+			}
+			if run.Strikethrough {
+				text = "~~" + text + "~~"
+			}
+
+		default:
+			text = renderMentions(text)
+		}
+
+		b.WriteString(text)
+	}
+
+	if b.Len() == 0 {
+		return ""
+	}
+
+	if r.format == renderFormatHTML {
+		return "<p>" + b.String() + "</p>"
+	}
+
+	return b.String()
+}
+
+// factSet renders the given Facts as a list of title and value pairs.
+func (r cardRenderer) factSet(facts []Fact) string {
+	if len(facts) == 0 {
+		return ""
+	}
+
+	lines := make([]string, 0, len(facts)+2)
+
+	if r.format == renderFormatHTML {
+		lines = append(lines, "<table class=\"factset\">")
+	}
+
+	for _, fact := range facts {
+		switch r.format {
+		case renderFormatHTML:
+			lines = append(lines, fmt.Sprintf(
+				"<tr><th>%s</th><td>%s</td></tr>",
+				r.inline(fact.Title),
+				r.inline(fact.Value),
+			))
+
+		case renderFormatMarkdown:
+			lines = append(lines, fmt.Sprintf(
+				"- **%s**: %s",
+				r.inline(fact.Title),
+				r.inline(fact.Value),
+			))
+
+		default:
+			lines = append(lines, r.inline(fact.Title)+": "+r.inline(fact.Value))
+		}
+	}
+
+	if r.format == renderFormatHTML {
+		lines = append(lines, "</table>")
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// table renders a Table. The first row is rendered as a header row unless
+// FirstRowAsHeaders is explicitly disabled.
+func (r cardRenderer) table(e Element) string {
+	if len(e.Rows) == 0 {
+		return ""
+	}
+
+	headers := e.FirstRowAsHeaders == nil || *e.FirstRowAsHeaders
+
+	if r.format == renderFormatHTML {
+		return r.htmlTable(e.Rows, headers)
+	}
+
+	rows := make([][]string, 0, len(e.Rows))
+	numCols := 0
+	for _, row := range e.Rows {
+		cells := make([]string, 0, len(row.Cells))
+		for _, cell := range row.Cells {
+			cells = append(cells, r.cellText(cell))
+		}
+		rows = append(rows, cells)
+
+		if len(cells) > numCols {
+			numCols = len(cells)
+		}
+	}
+
+	// Pad short rows so that each row has the same number of columns.
+	for i := range rows {
+		for len(rows[i]) < numCols {
+			rows[i] = append(rows[i], "")
+		}
+	}
+
+	if r.format == renderFormatMarkdown {
+		return markdownTable(rows, numCols, headers)
+	}
+
+	return textTable(rows, numCols, headers)
+}
+
+// cellText renders the items of a TableCell as a single line of text.
+func (r cardRenderer) cellText(cell TableCell) string {
+	text := strings.Join(
+		strings.Fields(strings.Join(r.elementPointers(cell.Items), " ")),
+		" ",
+	)
+
+	if r.format == renderFormatMarkdown {
+		text = strings.ReplaceAll(text, "|", `\|`)
+	}
+
+	return text
+}
+
+// htmlTable renders the given TableRows as an HTML table.
+func (r cardRenderer) htmlTable(rows []TableRow, headers bool) string {
+	lines := make([]string, 0, len(rows)+2)
+	lines = append(lines, "<table>")
+
+	for i, row := range rows {
+		tag := "td"
+		if i == 0 && headers {
+			tag = "th"
+		}
+
+		var b strings.Builder
+		b.WriteString("<tr>")
+		for _, cell := range row.Cells {
+			fmt.Fprintf(&b, "<%s>%s</%s>", tag, r.join(r.elementPointers(cell.Items)), tag)
+		}
+		b.WriteString("</tr>")
+
+		lines = append(lines, b.String())
+	}
+
+	lines = append(lines, "</table>")
+
+	return strings.Join(lines, "\n")
+}
+
+// markdownTable renders the given cell values as a Markdown table. Markdown
+// tables require a header row; an empty header row is used if headers is
+// false.
+func markdownTable(rows [][]string, numCols int, headers bool) string {
+	if !headers {
+		rows = append([][]string{make([]string, numCols)}, rows...)
+	}
+
+	lines := make([]string, 0, len(rows)+1)
+	for i, row := range rows {
+		lines = append(lines, "| "+strings.Join(row, " | ")+" |")
+
+		if i == 0 {
+			lines = append(lines, "|"+strings.Repeat(" --- |", numCols))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// textTable renders the given cell values as a plain text table with
+// aligned columns. If headers is true the first row is underlined.
+func textTable(rows [][]string, numCols int, headers bool) string {
+	widths := make([]int, numCols)
+	for _, row := range rows {
+		for i, value := range row {
+			if n := utf8.RuneCountInString(value); n > widths[i] {
+				widths[i] = n
+			}
+		}
+	}
+
+	lines := make([]string, 0, len(rows)+1)
+	for i, row := range rows {
+		cols := make([]string, numCols)
+		for j, value := range row {
+			cols[j] = value + strings.Repeat(" ", widths[j]-utf8.RuneCountInString(value))
+		}
+		lines = append(lines, strings.TrimRight(strings.Join(cols, "  "), " "))
+
+		if i == 0 && headers {
+			underline := make([]string, numCols)
+			for j, width := range widths {
+				underline[j] = strings.Repeat("-", width)
+			}
+			lines = append(lines, strings.Join(underline, "  "))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// columnSet renders the items of each visible Column. Columns are rendered
+// side by side for HTML and one after another otherwise.
+func (r cardRenderer) columnSet(columns []Column) string {
+	blocks := make([]string, 0, len(columns))
+
+	for _, column := range columns {
+		if column.Visible != nil && !*column.Visible {
+			continue
+		}
+
+		content := r.join(r.elementPointers(column.Items))
+		if content == "" {
+			continue
+		}
+
+		if r.format == renderFormatHTML {
+			content = "<div class=\"column\" style=\"flex: 1\">\n" + content + "\n</div>"
+		}
+
+		blocks = append(blocks, content)
+	}
+
+	if len(blocks) == 0 {
+		return ""
+	}
+
+	if r.format == renderFormatHTML {
+		return "<div class=\"columnset\" style=\"display: flex; gap: 1em\">\n" +
+			strings.Join(blocks, "\n") + "\n</div>"
+	}
+
+	return r.join(blocks)
+}
+
+// container renders the given rendered child blocks of a Container. For HTML
+// the style of the Container (if any) is included as an additional class
+// (e.g., "style-attention").
+func (r cardRenderer) container(style string, blocks []string) string {
+	if len(blocks) == 0 {
+		return ""
+	}
+
+	if r.format == renderFormatHTML {
+		class := "container"
+		if style != "" {
+			class += " style-" + html.EscapeString(style)
+		}
+
+		return "<div class=\"" + class + "\">\n" + strings.Join(blocks, "\n") + "\n</div>"
+	}
+
+	return r.join(blocks)
+}
+
+// image renders an Image using its alternate text and URL.
+func (r cardRenderer) image(e Element) string {
+	switch r.format {
+	case renderFormatHTML:
+		if !urlsafe.Image(e.URL) {
+			if e.AltText == "" {
+				return ""
+			}
+			return "<p>" + html.EscapeString(e.AltText) + "</p>"
+		}
+
+		return fmt.Sprintf(
+			"<img src=\"%s\" alt=\"%s\">",
+			html.EscapeString(e.URL),
+			html.EscapeString(e.AltText),
+		)
+
+	case renderFormatMarkdown:
+		if e.URL == "" {
+			return e.AltText
+		}
+		return fmt.Sprintf("![%s](%s)", e.AltText, e.URL)
+
+	default:
+		switch {
+		case e.AltText == "":
+			return e.URL
+		case e.URL == "" || strings.HasPrefix(e.URL, "data:"):
+			return "[" + e.AltText + "]"
+		default:
+			return fmt.Sprintf("[%s] (%s)", e.AltText, e.URL)
+		}
+	}
+}
+
+// media renders a Media element as a link to its first source.
+func (r cardRenderer) media(e Element) string {
+	if len(e.Sources) == 0 {
+		return ""
+	}
+
+	title := e.AltText
+	if title == "" {
+		title = "Media"
+	}
+
+	return r.link(title, e.Sources[0].URL)
+}
+
+// input renders an input element using its label and current value.
+func (r cardRenderer) input(e Element) string {
+	label := e.Label
+	if label == "" {
+		label = e.Title
+	}
+
+	value := ""
+	if e.Value != nil {
+		value = fmt.Sprint(e.Value)
+	}
+
+	// Display the title of each selected choice instead of its value.
+	if e.Type == TypeElementInputChoiceSet && value != "" {
+		selected := strings.Split(value, ",")
+		for i, v := range selected {
+			for _, choice := range e.Choices {
+				if choice.Value == v {
+					selected[i] = choice.Title
+				}
+			}
+		}
+		value = strings.Join(selected, ", ")
+	}
+
+	switch {
+	case label == "" && value == "":
+		return ""
+	case value == "":
+		return r.fact(label, e.Placeholder)
+	default:
+		return r.fact(label, value)
+	}
+}
+
+// fact renders a single title and value pair.
+func (r cardRenderer) fact(title string, value string) string {
+	return r.factSet([]Fact{{Title: title, Value: value}})
+}
+
+// actions renders the given Actions. Action.OpenUrl buttons are rendered as
+// links and Action.ShowCard buttons are rendered along with the body of
+// their Card. Other action types have no meaningful representation outside
+// of a Card and are omitted.
+func (r cardRenderer) actions(actions []Action) string {
+	var links []string
+	var cards []string
+
+	for _, action := range actions {
+		switch action.Type {
+		case TypeActionOpenURL:
+			title := action.Title
+			if title == "" {
+				title = action.URL
+			}
+			if link := r.link(title, action.URL); link != "" {
+				links = append(links, link)
+			}
+
+		case TypeActionShowCard:
+			if action.Card == nil {
+				continue
+			}
+
+			content := r.join(r.elements(action.Card.Body))
+			if nested := r.actions(action.Card.Actions); nested != "" {
+				content = r.join([]string{content, nested})
+			}
+			if block := r.toggled(action.Title, content); block != "" {
+				cards = append(cards, block)
+			}
+		}
+	}
+
+	var blocks []string
+
+	if len(links) > 0 {
+		switch r.format {
+		case renderFormatHTML:
+			blocks = append(blocks, "<p class=\"actions\">"+strings.Join(links, " | ")+"</p>")
+		case renderFormatMarkdown:
+			blocks = append(blocks, "- "+strings.Join(links, "\n- "))
+		default:
+			blocks = append(blocks, strings.Join(links, "\n"))
+		}
+	}
+
+	return r.join(append(blocks, cards...))
+}
+
+// link renders a link with the given title and URL. For HTML the title is
+// rendered without a link if the URL is not considered safe.
+func (r cardRenderer) link(title string, url string) string {
+	switch r.format {
+	case renderFormatHTML:
+		if !urlsafe.Link(url) {
+			return html.EscapeString(title)
+		}
+		return fmt.Sprintf(
+			"<a href=\"%s\">%s</a>",
+			html.EscapeString(url),
+			html.EscapeString(title),
+		)
+
+	case renderFormatMarkdown:
+		return fmt.Sprintf("[%s](%s)", title, url)
+
+	default:
+		if title == url {
+			return url
+		}
+		return title + ": " + url
+	}
+}
+
+// inline renders TextBlock (or Fact) text. User mention placeholders are
+// replaced for all formats; Markdown is kept as-is for Markdown output,
+// converted to markup for HTML output and stripped for plain text output.
+func (r cardRenderer) inline(text string) string {
+	text = renderMentions(text)
+
+	switch r.format {
+	case renderFormatHTML:
+		text = html.EscapeString(text)
+
+		text = renderLinkRegex.ReplaceAllStringFunc(text, func(match string) string {
+			parts := renderLinkRegex.FindStringSubmatch(match)
+			if !urlsafe.Link(html.UnescapeString(parts[2])) {
+				return parts[1]
+			}
+			return fmt.Sprintf("<a href=\"%s\">%s</a>", parts[2], parts[1])
+		})
+		text = renderBoldRegex.ReplaceAllString(text, "<strong>$1</strong>")
+		text = renderItalicRegex.ReplaceAllString(text, "$1<em>$2</em>$3")
+
+		return strings.ReplaceAll(text, "\n", "<br>\n")
+
+	case renderFormatMarkdown:
+		return text
+
+	default:
+		text = renderLinkRegex.ReplaceAllStringFunc(text, func(match string) string {
+			parts := renderLinkRegex.FindStringSubmatch(match)
+			if parts[1] == parts[2] {
+				return parts[2]
+			}
+			return fmt.Sprintf("%s (%s)", parts[1], parts[2])
+		})
+		text = renderBoldRegex.ReplaceAllString(text, "$1")
+
+		return renderItalicRegex.ReplaceAllString(text, "$1$2$3")
+	}
+}
+
+// renderMentions replaces user mention placeholders (e.g., <at>John
+// Doe</at>) with the mentioned name prefixed with an @ symbol.
+func renderMentions(text string) string {
+	return renderMentionRegex.ReplaceAllString(text, "@$1")
+}
@@ -0,0 +1,115 @@
+// Copyright 2024 Adam Chalkley
+//
+// https://github.com/atc0005/go-teams-notify
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package adaptivecard
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCardHTMLDropsUnsafeURLs(t *testing.T) {
+	card := NewCard()
+	card.Body = []Element{
+		NewTextBlock("[click](javascript:alert%281%29) and [docs](https://example.com/docs)", false),
+		{Type: TypeElementImage, URL: "javascript:alert(1)", AltText: "Chart"},
+		{Type: TypeElementImage, URL: "data:text/html;base64,PHNjcmlwdD4="},
+		{Type: TypeElementImage, URL: "data:image/png;base64,iVBORw0KGgo=", AltText: "Graph"},
+	}
+	card.Actions = []Action{
+		{Type: TypeActionOpenURL, Title: "Run", URL: "javascript:alert(1)"},
+		{Type: TypeActionOpenURL, Title: "Email", URL: "mailto:ops@example.com"},
+	}
+
+	got := card.HTML()
+
+	assert.NotContains(t, got, "javascript:")
+	assert.NotContains(t, got, "data:text/html")
+	assert.Contains(t, got, "click and <a href=\"https://example.com/docs\">docs</a>")
+	assert.Contains(t, got, "<p>Chart</p>")
+	assert.Contains(t, got, "<img src=\"data:image/png;base64,iVBORw0KGgo=\" alt=\"Graph\">")
+	assert.Contains(t, got, "Run | <a href=\"mailto:ops@example.com\">Email</a>")
+}
+
+func TestCardHTMLEscapesText(t *testing.T) {
+	card := NewCard()
+	card.Body = []Element{
+		NewTextBlock("<script>alert(1)</script> **bold**", false),
+		{Type: TypeElementFactSet, Facts: []Fact{{Title: "<b>Host</b>", Value: "<script>alert(2)</script>"}}},
+		{Type: TypeElementTextBlock, Text: "<img src=x onerror=alert(3)>", FontType: FontTypeMonospace},
+	}
+
+	got := card.HTML()
+
+	assert.NotContains(t, got, "<script>")
+	assert.NotContains(t, got, "<img")
+	assert.NotContains(t, got, "<b>")
+	assert.Contains(t, got, "<p>&lt;script&gt;alert(1)&lt;/script&gt; <strong>bold</strong></p>")
+	assert.Contains(t, got, "<tr><th>&lt;b&gt;Host&lt;/b&gt;</th><td>&lt;script&gt;alert(2)&lt;/script&gt;</td></tr>")
+	assert.Contains(t, got, "<pre>&lt;img src=x onerror=alert(3)&gt;</pre>")
+}
+
+func TestCardRenderHiddenElements(t *testing.T) {
+	hidden := false
+
+	card := NewCard()
+	card.Body = []Element{
+		NewTextBlock("Summary", false),
+		{Type: TypeElementTextBlock, ID: "details", Text: "Details <here>", Visible: &hidden},
+		{Type: TypeElementTextBlock, ID: "secret", Text: "Secret", Visible: &hidden},
+	}
+	card.Actions = []Action{
+		{
+			Type:           TypeActionToggleVisibility,
+			Title:          "Show <details>",
+			TargetElements: []TargetElement{{ElementID: "details"}},
+		},
+	}
+
+	tests := map[string]struct {
+		render func(Card) string
+		want   string
+	}{
+		"html": {
+			render: Card.HTML,
+			want: "<div class=\"adaptivecard\">\n<p>Summary</p>\n" +
+				"<details>\n<summary>Show &lt;details&gt;</summary>\n<p>Details &lt;here&gt;</p>\n</details>\n</div>",
+		},
+		"markdown": {
+			render: Card.Markdown,
+			want:   "Summary\n\n**Show <details>**\n\nDetails <here>",
+		},
+		"plain text": {
+			render: Card.PlainText,
+			want:   "Summary\n\nShow <details>:\nDetails <here>",
+		},
+	}
+
+	for name, tt := range tests {
+		name, tt := name, tt
+
+		t.Run(name, func(t *testing.T) {
+			got := tt.render(card)
+
+			assert.Equal(t, tt.want, got)
+			assert.NotContains(t, got, "Secret")
+		})
+	}
+}
+
+func TestCardSetFallbackText(t *testing.T) {
+	card := NewCard()
+	card.Body = []Element{NewTextBlock("Build **#42** failed", false)}
+
+	card.SetFallbackText()
+	assert.Equal(t, "Build #42 failed", card.FallbackText)
+
+	card.FallbackText = "Custom fallback"
+	card.SetFallbackText()
+	assert.Equal(t, "Custom fallback", card.FallbackText)
+}
@@ -0,0 +1,122 @@
+// Copyright 2024 Adam Chalkley
+//
+// https://github.com/atc0005/go-teams-notify
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package adaptivecard
+
+// RichText is a builder used to assemble the formatted TextRun elements of a
+// RichTextBlock element. Methods may be chained to apply different formatting
+// to individual words or phrases:
+//
+//	block, err := adaptivecard.NewRichText().
+//		Bold("ERROR").
+//		Text(" in ").
+//		Code("svc-a").
+//		Element()
+//
+// The zero value is ready to use.
+type RichText struct {
+	inlines []Element
+}
+
+// NewRichText creates a new, empty RichText builder.
+func NewRichText() *RichText {
+	return &RichText{}
+}
+
+// Run appends the given TextRun element as-is. This is useful for applying
+// formatting not provided by other RichText methods.
+func (rt *RichText) Run(textRun Element) *RichText {
+	rt.inlines = append(rt.inlines, textRun)
+
+	return rt
+}
+
+// Text appends unformatted text.
+func (rt *RichText) Text(text string) *RichText {
+	return rt.Run(NewTextRun(text))
+}
+
+// Bold appends text using a bolder weight.
+func (rt *RichText) Bold(text string) *RichText {
+	textRun := NewTextRun(text)
+	textRun.Weight = WeightBolder
+
+	return rt.Run(textRun)
+}
+
+// Italic appends italicized text.
+func (rt *RichText) Italic(text string) *RichText {
+	textRun := NewTextRun(text)
+	textRun.Italic = true
+
+	return rt.Run(textRun)
+}
+
+// Strikethrough appends struck through text.
+func (rt *RichText) Strikethrough(text string) *RichText {
+	textRun := NewTextRun(text)
+	textRun.Strikethrough = true
+
+	return rt.Run(textRun)
+}
+
+// Underline appends underlined text.
+func (rt *RichText) Underline(text string) *RichText {
+	textRun := NewTextRun(text)
+	textRun.Underline = true
+
+	return rt.Run(textRun)
+}
+
+// Highlight appends highlighted text.
+func (rt *RichText) Highlight(text string) *RichText {
+	textRun := NewTextRun(text)
+	textRun.Highlight = true
+
+	return rt.Run(textRun)
+}
+
+// Code appends text using a monospace font.
+func (rt *RichText) Code(text string) *RichText {
+	textRun := NewTextRun(text)
+	textRun.FontType = FontTypeMonospace
+
+	return rt.Run(textRun)
+}
+
+// Color appends text using the given color (e.g., ColorAttention).
+func (rt *RichText) Color(text string, color string) *RichText {
+	textRun := NewTextRun(text)
+	textRun.Color = color
+
+	return rt.Run(textRun)
+}
+
+// Link appends text which opens the given URL when selected.
+func (rt *RichText) Link(text string, url string) *RichText {
+	textRun := NewTextRun(text)
+	textRun.SelectAction = &ISelectAction{
+		Type: TypeActionOpenURL,
+		URL:  url,
+	}
+
+	return rt.Run(textRun)
+}
+
+// Inlines returns the TextRun elements assembled so far.
+func (rt *RichText) Inlines() []Element {
+	inlines := make([]Element, len(rt.inlines))
+	copy(inlines, rt.inlines)
+
+	return inlines
+}
+
+// Element returns a RichTextBlock element using the TextRun elements
+// assembled so far. An error is returned if the result fails validation.
+func (rt *RichText) Element() (Element, error) {
+	return NewRichTextBlock(rt.Inlines()...)
+}
@@ -0,0 +1,134 @@
+// Copyright 2024 Adam Chalkley
+//
+// https://github.com/atc0005/go-teams-notify
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package adaptivecard
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRichTextBuilder(t *testing.T) {
+	block, err := NewRichText().
+		Text("plain").
+		Bold("bold").
+		Italic("italic").
+		Strikethrough("struck").
+		Underline("underlined").
+		Highlight("highlighted").
+		Code("code").
+		Color("colored", ColorAttention).
+		Link("link", "https://example.com").
+		Element()
+	mustNoError(t, err)
+
+	assert.Equal(t, TypeElementRichTextBlock, block.Type)
+
+	if !assert.Len(t, block.Inlines, 9) {
+		return
+	}
+
+	for _, inline := range block.Inlines {
+		assert.Equal(t, TypeElementTextRun, inline.Type)
+	}
+
+	assert.Equal(t, "plain", block.Inlines[0].Text)
+	assert.Equal(t, WeightBolder, block.Inlines[1].Weight)
+	assert.True(t, block.Inlines[2].Italic)
+	assert.True(t, block.Inlines[3].Strikethrough)
+	assert.True(t, block.Inlines[4].Underline)
+	assert.True(t, block.Inlines[5].Highlight)
+	assert.Equal(t, FontTypeMonospace, block.Inlines[6].FontType)
+	assert.Equal(t, ColorAttention, block.Inlines[7].Color)
+
+	if assert.NotNil(t, block.Inlines[8].SelectAction) {
+		assert.Equal(t, TypeActionOpenURL, block.Inlines[8].SelectAction.Type)
+		assert.Equal(t, "https://example.com", block.Inlines[8].SelectAction.URL)
+	}
+}
+
+func TestRichTextInlinesCopy(t *testing.T) {
+	rt := NewRichText().Text("one")
+
+	inlines := rt.Inlines()
+	inlines[0].Text = "changed"
+
+	assert.Equal(t, "one", rt.Inlines()[0].Text)
+
+	textRun := NewTextRun("two")
+	textRun.Size = SizeLarge
+	rt.Run(textRun)
+
+	if assert.Len(t, rt.Inlines(), 2) {
+		assert.Equal(t, SizeLarge, rt.Inlines()[1].Size)
+	}
+}
+
+func TestRichTextBlockValidation(t *testing.T) {
+	_, err := NewRichTextBlock()
+	assert.True(t, errors.Is(err, ErrMissingValue))
+
+	_, err = NewRichText().Element()
+	assert.True(t, errors.Is(err, ErrMissingValue))
+
+	assert.True(t, errors.Is(Element{Type: TypeElementRichTextBlock}.Validate(), ErrMissingValue))
+
+	_, err = NewRichTextBlock(NewTextBlock("not inline", false))
+	assert.True(t, errors.Is(err, ErrInvalidType))
+
+	_, err = NewRichText().Color("text", "pink").Element()
+	assert.True(t, errors.Is(err, ErrInvalidFieldValue))
+
+	_, err = NewRichText().Link("link", "").Element()
+	assert.True(t, errors.Is(err, ErrMissingValue))
+}
+
+func TestTextRunOutsideInlines(t *testing.T) {
+	textRun := NewTextRun("inline")
+
+	tests := map[string]struct {
+		validate func() error
+	}{
+		"card body": {
+			validate: func() error {
+				card := NewCard()
+				card.Body = []Element{textRun}
+
+				return card.Validate()
+			},
+		},
+		"container items": {
+			validate: func() error {
+				return Element{Type: TypeElementContainer, Items: []Element{textRun}}.Validate()
+			},
+		},
+		"column items": {
+			validate: func() error {
+				return Element{
+					Type:    TypeElementColumnSet,
+					Columns: []Column{{Type: TypeColumn, Items: []*Element{&textRun}}},
+				}.Validate()
+			},
+		},
+		"table cell items": {
+			validate: func() error {
+				return TableCell{Type: TypeTableCell, Items: []*Element{&textRun}}.Validate()
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		name, tt := name, tt
+
+		t.Run(name, func(t *testing.T) {
+			err := tt.validate()
+			assert.True(t, errors.Is(err, ErrInvalidType), "got error: %v", err)
+		})
+	}
+}
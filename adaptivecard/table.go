@@ -0,0 +1,676 @@
+// Copyright 2024 Adam Chalkley
+//
+// https://github.com/atc0005/go-teams-notify
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package adaptivecard
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// TableRowsLimit is the default maximum number of rows (excluding the
+	// header row) included in a Table created by NewTableFromStructs,
+	// NewTableFromStrings or NewTableFromCSV. Large tables quickly exceed
+	// the (approximately 28 KB) message size limit of Microsoft Teams and
+	// are difficult to read on mobile clients.
+	TableRowsLimit int = 50
+
+	// TableCellTextLimit is the default maximum number of characters of
+	// text included in each table cell. Longer values are truncated.
+	TableCellTextLimit int = 200
+
+	// TableStructTag is the struct tag key used by NewTableFromStructs.
+	TableStructTag string = "table"
+)
+
+// tableTruncationSuffix is appended to truncated table cell values.
+const tableTruncationSuffix string = "…"
+
+// TableOverflow controls how rows in excess of the maximum number of rows
+// for a table are handled.
+type TableOverflow int
+
+const (
+	// TableOverflowTruncate omits rows in excess of the maximum. A TextBlock
+	// noting the number of omitted rows is added after the table.
+	TableOverflowTruncate TableOverflow = iota
+
+	// TableOverflowPaginate splits rows across multiple tables, each
+	// repeating the header row.
+	TableOverflowPaginate
+)
+
+// TableColumn controls how a column is displayed by NewTableFromStructs,
+// NewTableFromStrings and NewTableFromCSV. Zero values are ignored.
+type TableColumn struct {
+	// Header is the text displayed in the header row.
+	Header string
+
+	// HorizontalAlignment is the alignment of the column content (e.g.,
+	// HorizontalAlignmentRight). Numeric columns are right aligned by
+	// default, other columns are left aligned.
+	HorizontalAlignment string
+
+	// Width is the width of the column relative to other columns.
+	Width int
+
+	// Format is the fmt package format string used for column values (e.g.,
+	// "%.2f"). For time.Time values Format is a time package layout.
+	Format string
+
+	// MaxLength is the maximum number of characters of text in each cell of
+	// the column, overriding TableOptions.MaxCellLength.
+	MaxLength int
+}
+
+// TableOptions controls the tables created by NewTableFromStructs,
+// NewTableFromStrings and NewTableFromCSV.
+type TableOptions struct {
+	// Columns holds column settings keyed by column header, overriding those
+	// given by struct tags or detected automatically.
+	Columns map[string]TableColumn
+
+	// MaxRows is the maximum number of rows (excluding the header row) per
+	// table. If zero, TableRowsLimit is used. If negative, no limit is
+	// applied.
+	MaxRows int
+
+	// Overflow controls how rows in excess of MaxRows are handled.
+	Overflow TableOverflow
+
+	// MaxCellLength is the maximum number of characters of text in each
+	// cell. If zero, TableCellTextLimit is used. If negative, no limit is
+	// applied.
+	MaxCellLength int
+
+	// RowStyle, if set, is called for each row and returns the style for
+	// that row (e.g., ContainerStyleAttention for failures) or an empty
+	// string for the default style. The row value is the struct (for
+	// NewTableFromStructs) or the []string row values.
+	RowStyle func(index int, row interface{}) string
+
+	// HideGridLines hides the grid lines of the table if true.
+	HideGridLines bool
+}
+
+// tableColumn is a column of a table being assembled.
+type tableColumn struct {
+	TableColumn
+
+	// numeric indicates whether the values of the column are numeric.
+	numeric bool
+
+	// order and index (the struct field index) are used to sort struct
+	// columns.
+	order int
+	index []int
+}
+
+// NewTableFromStructs creates one or more Table elements from the given
+// slice (or array) of structs or struct pointers. Nil elements are skipped.
+//
+// Each exported field is a column, using the field name as the header
+// unless overridden by the "table" struct tag. The tag value is the header
+// followed by optional comma separated settings:
+//
+//	type Result struct {
+//		Name     string        `table:"Check"`
+//		Duration time.Duration `table:"Took,order=2,align=right"`
+//		Score    float64       `table:",order=1,format=%.1f,width=2"`
+//		Started  time.Time     `table:"Started,format=Jan 2 15:04"`
+//		Internal string        `table:"-"`
+//	}
+//
+// Supported settings are order, align, width, format and maxlen (see
+// TableColumn). Columns are sorted by their order value; columns with equal
+// order values (including the default of zero) retain the field order.
+// Fields of embedded structs are included as if they were fields of the
+// outer struct.
+//
+// More than one Table is returned if rows are paginated. If rows are
+// truncated a TextBlock noting the number of omitted rows is also returned.
+// See TableOptions for details.
+func NewTableFromStructs(slice interface{}, opts TableOptions) ([]Element, error) {
+	v := reflect.ValueOf(slice)
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, fmt.Errorf(
+			"unsupported value of type %T; expected slice of structs: %w",
+			slice,
+			ErrInvalidType,
+		)
+	}
+
+	elemType := v.Type().Elem()
+	if elemType.Kind() == reflect.Ptr {
+		elemType = elemType.Elem()
+	}
+
+	if elemType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf(
+			"unsupported element type %s; expected struct: %w",
+			elemType,
+			ErrInvalidType,
+		)
+	}
+
+	columns, err := structTableColumns(elemType, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(columns) == 0 {
+		return nil, fmt.Errorf(
+			"no exported fields found for type %s: %w",
+			elemType,
+			ErrMissingValue,
+		)
+	}
+
+	sort.SliceStable(columns, func(i, j int) bool {
+		return columns[i].order < columns[j].order
+	})
+
+	columns = applyTableColumnOptions(columns, opts)
+
+	var rows [][]string
+	var rowValues []interface{}
+	for i := 0; i < v.Len(); i++ {
+		item := v.Index(i)
+		if item.Kind() == reflect.Ptr {
+			if item.IsNil() {
+				continue
+			}
+			item = item.Elem()
+		}
+
+		row := make([]string, len(columns))
+		for j, column := range columns {
+			row[j] = formatTableValue(fieldByIndex(item, column.index), column.Format)
+		}
+
+		rows = append(rows, row)
+		rowValues = append(rowValues, item.Interface())
+	}
+
+	return newTables(columns, rows, rowValues, opts)
+}
+
+// NewTableFromStrings creates one or more Table elements from the given
+// rows of values. The first row is used as the header row. Columns whose
+// values are all numeric are right aligned by default and the Format of a
+// numeric column (see TableColumn) is applied to the parsed values.
+//
+// See NewTableFromStructs for details of the returned Elements.
+func NewTableFromStrings(rows [][]string, opts TableOptions) ([]Element, error) {
+	if len(rows) == 0 || len(rows[0]) == 0 {
+		return nil, fmt.Errorf("no header row provided: %w", ErrMissingValue)
+	}
+
+	columns := make([]tableColumn, len(rows[0]))
+	for i, header := range rows[0] {
+		columns[i].Header = header
+		columns[i].numeric = isNumericColumn(rows[1:], i)
+	}
+
+	columns = applyTableColumnOptions(columns, opts)
+
+	body := make([][]string, 0, len(rows)-1)
+	rowValues := make([]interface{}, 0, len(rows)-1)
+	for _, row := range rows[1:] {
+		values := make([]string, len(columns))
+		for i, column := range columns {
+			if i >= len(row) {
+				continue
+			}
+
+			values[i] = row[i]
+			if column.numeric && column.Format != "" {
+				values[i] = formatNumericString(row[i], column.Format)
+			}
+		}
+
+		body = append(body, values)
+		rowValues = append(rowValues, row)
+	}
+
+	return newTables(columns, body, rowValues, opts)
+}
+
+// NewTableFromCSV creates one or more Table elements from CSV data read from
+// the given Reader. The first record is used as the header row. Records may
+// have a varying number of fields.
+//
+// See NewTableFromStrings for details.
+func NewTableFromCSV(r io.Reader, opts TableOptions) ([]Element, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV data: %w", err)
+	}
+
+	return NewTableFromStrings(records, opts)
+}
+
+// structTableColumns returns the columns for the exported fields of the
+// given struct type, including those of embedded structs. The index is the
+// field index of the struct within the outermost struct.
+func structTableColumns(t reflect.Type, index []int) ([]tableColumn, error) {
+	var columns []tableColumn
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get(TableStructTag)
+
+		fieldIndex := make([]int, len(index)+1)
+		copy(fieldIndex, index)
+		fieldIndex[len(index)] = i
+
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+
+		switch {
+		case tag == "-":
+			continue
+
+		case field.Anonymous && tag == "" && fieldType.Kind() == reflect.Struct:
+			embedded, err := structTableColumns(fieldType, fieldIndex)
+			if err != nil {
+				return nil, err
+			}
+			columns = append(columns, embedded...)
+			continue
+
+		case field.PkgPath != "":
+			// Unexported field.
+			continue
+		}
+
+		column, err := parseTableTag(tag)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", field.Name, err)
+		}
+
+		if column.Header == "" {
+			column.Header = field.Name
+		}
+		column.index = fieldIndex
+		column.numeric = isNumericType(fieldType)
+
+		columns = append(columns, column)
+	}
+
+	return columns, nil
+}
+
+// parseTableTag parses the value of a table struct tag. Setting values may
+// contain commas (e.g., a time layout of "Jan 2, 2006"); a segment which
+// does not start with a known setting name is treated as part of the
+// previous setting value.
+func parseTableTag(tag string) (tableColumn, error) {
+	var column tableColumn
+	if tag == "" {
+		return column, nil
+	}
+
+	segments := strings.Split(tag, ",")
+	column.Header = segments[0]
+
+	var settings [][2]string
+	for _, segment := range segments[1:] {
+		parts := strings.SplitN(segment, "=", 2)
+
+		switch {
+		case len(parts) == 2 && isTableTagSetting(parts[0]):
+			settings = append(settings, [2]string{parts[0], parts[1]})
+
+		case len(settings) > 0:
+			settings[len(settings)-1][1] += "," + segment
+
+		default:
+			return column, fmt.Errorf(
+				"invalid table tag setting %q: %w",
+				segment,
+				ErrInvalidFieldValue,
+			)
+		}
+	}
+
+	for _, setting := range settings {
+		name, value := setting[0], setting[1]
+
+		switch name {
+		case "align":
+			column.HorizontalAlignment = value
+		case "format":
+			column.Format = value
+		case "order", "width", "maxlen":
+			num, err := strconv.Atoi(value)
+			if err != nil {
+				return column, fmt.Errorf(
+					"invalid table tag %s value %q: %w",
+					name,
+					value,
+					ErrInvalidFieldValue,
+				)
+			}
+
+			switch name {
+			case "order":
+				column.order = num
+			case "width":
+				column.Width = num
+			default:
+				column.MaxLength = num
+			}
+		}
+	}
+
+	return column, nil
+}
+
+// isTableTagSetting indicates whether the given value is a supported table
+// struct tag setting name.
+func isTableTagSetting(name string) bool {
+	switch name {
+	case "order", "align", "width", "format", "maxlen":
+		return true
+	default:
+		return false
+	}
+}
+
+// applyTableColumnOptions applies the column settings from the given
+// TableOptions to the given columns.
+func applyTableColumnOptions(columns []tableColumn, opts TableOptions) []tableColumn {
+	for i := range columns {
+		override, ok := opts.Columns[columns[i].Header]
+		if !ok {
+			continue
+		}
+
+		if override.Header != "" {
+			columns[i].Header = override.Header
+		}
+		if override.HorizontalAlignment != "" {
+			columns[i].HorizontalAlignment = override.HorizontalAlignment
+		}
+		if override.Width != 0 {
+			columns[i].Width = override.Width
+		}
+		if override.Format != "" {
+			columns[i].Format = override.Format
+		}
+		if override.MaxLength != 0 {
+			columns[i].MaxLength = override.MaxLength
+		}
+	}
+
+	return columns
+}
+
+// newTables assembles Table elements from the given columns and rows,
+// applying the row limits, cell text limits and row styles from the given
+// TableOptions.
+func newTables(columns []tableColumn, rows [][]string, rowValues []interface{}, opts TableOptions) ([]Element, error) {
+	maxRows := opts.MaxRows
+	if maxRows == 0 {
+		maxRows = TableRowsLimit
+	}
+
+	var omitted int
+	if maxRows > 0 && len(rows) > maxRows && opts.Overflow == TableOverflowTruncate {
+		omitted = len(rows) - maxRows
+		rows = rows[:maxRows]
+	}
+
+	pageSize := len(rows)
+	if maxRows > 0 && maxRows < pageSize {
+		pageSize = maxRows
+	}
+
+	var tables []Element
+	for start := 0; start == 0 || start < len(rows); start += pageSize {
+		end := start + pageSize
+		if end > len(rows) {
+			end = len(rows)
+		}
+
+		table, err := newTable(columns, rows[start:end], start, rowValues, opts)
+		if err != nil {
+			return nil, err
+		}
+		tables = append(tables, table)
+
+		if pageSize == 0 {
+			break
+		}
+	}
+
+	if omitted > 0 {
+		noun := "rows"
+		if omitted == 1 {
+			noun = "row"
+		}
+
+		note := NewTextBlock(fmt.Sprintf("%d more %s not shown", omitted, noun), true)
+		note.IsSubtle = true
+		note.Size = SizeSmall
+		tables = append(tables, note)
+	}
+
+	return tables, nil
+}
+
+// newTable assembles a single Table element with a header row from the given
+// columns and rows. The offset is the index of the first row, used when
+// calling the RowStyle function.
+func newTable(columns []tableColumn, rows [][]string, offset int, rowValues []interface{}, opts TableOptions) (Element, error) {
+	maxCellLength := opts.MaxCellLength
+	if maxCellLength == 0 {
+		maxCellLength = TableCellTextLimit
+	}
+
+	cells := make([][]TableCell, 0, len(rows)+1)
+
+	header := make([]string, len(columns))
+	for i, column := range columns {
+		header[i] = column.Header
+	}
+
+	for _, row := range append([][]string{header}, rows...) {
+		items := make([]interface{}, len(columns))
+		for i, column := range columns {
+			limit := maxCellLength
+			if column.MaxLength != 0 {
+				limit = column.MaxLength
+			}
+			items[i] = truncateTableText(row[i], limit)
+		}
+
+		rowCells, err := NewTableCellsWithTextBlock(items)
+		if err != nil {
+			return Element{}, err
+		}
+
+		for _, cell := range rowCells {
+			for _, item := range cell.Items {
+				item.Wrap = true
+			}
+		}
+
+		cells = append(cells, rowCells)
+	}
+
+	table, err := NewTableFromTableCells(cells, len(columns), true, !opts.HideGridLines)
+	if err != nil {
+		return Element{}, err
+	}
+
+	for i, column := range columns {
+		alignment := column.HorizontalAlignment
+		if alignment == "" {
+			alignment = HorizontalAlignmentLeft
+			if column.numeric {
+				alignment = HorizontalAlignmentRight
+			}
+		}
+		table.Columns[i].HorizontalCellContentAlignment = alignment
+
+		if column.Width != 0 {
+			table.Columns[i].Width = column.Width
+		}
+	}
+
+	if opts.RowStyle != nil {
+		for i := range rows {
+			table.Rows[i+1].Style = opts.RowStyle(offset+i, rowValues[offset+i])
+		}
+	}
+
+	if err := table.Validate(); err != nil {
+		return Element{}, err
+	}
+
+	return table, nil
+}
+
+// fieldByIndex returns the field of the given struct value with the given
+// index, or the zero Value if a nil embedded struct pointer is encountered.
+func fieldByIndex(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+
+	return v
+}
+
+// formatTableValue formats the given value for display in a table cell
+// using the given (optional) format. Nil values are formatted as an empty
+// string.
+func formatTableValue(v reflect.Value, format string) string {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+
+	if !v.IsValid() {
+		return ""
+	}
+
+	value := v.Interface()
+
+	if t, ok := value.(time.Time); ok {
+		switch {
+		case t.IsZero():
+			return ""
+		case format != "":
+			return t.Format(format)
+		default:
+			return t.Format(time.RFC3339)
+		}
+	}
+
+	if format != "" {
+		return fmt.Sprintf(format, value)
+	}
+
+	return fmt.Sprint(value)
+}
+
+// formatNumericString formats the given numeric string value using the given
+// format. Integer values are formatted as int64 values, other values as
+// float64 values. Values which cannot be parsed are returned as-is.
+func formatNumericString(value string, format string) string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return value
+	}
+
+	if i, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if strings.ContainsAny(format, "eEfFgG") {
+			return fmt.Sprintf(format, float64(i))
+		}
+		return fmt.Sprintf(format, i)
+	}
+
+	if f, err := strconv.ParseFloat(value, 64); err == nil {
+		return fmt.Sprintf(format, f)
+	}
+
+	return value
+}
+
+// isNumericType indicates whether the given type is a numeric type (e.g.,
+// int or float64). Durations are considered numeric.
+func isNumericType(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// isNumericColumn indicates whether all non-empty values of the column with
+// the given index are numeric. Columns without values are not numeric.
+func isNumericColumn(rows [][]string, index int) bool {
+	var found bool
+
+	for _, row := range rows {
+		if index >= len(row) || strings.TrimSpace(row[index]) == "" {
+			continue
+		}
+
+		if _, err := strconv.ParseFloat(strings.TrimSpace(row[index]), 64); err != nil {
+			return false
+		}
+		found = true
+	}
+
+	return found
+}
+
+// truncateTableText truncates the given text to the given maximum number of
+// characters (including the truncation suffix). A negative limit disables
+// truncation.
+func truncateTableText(text string, limit int) string {
+	if limit < 0 || utf8.RuneCountInString(text) <= limit {
+		return text
+	}
+
+	if limit <= utf8.RuneCountInString(tableTruncationSuffix) {
+		return string([]rune(text)[:limit])
+	}
+
+	return string([]rune(text)[:limit-utf8.RuneCountInString(tableTruncationSuffix)]) + tableTruncationSuffix
+}
@@ -0,0 +1,399 @@
+// Copyright 2024 Adam Chalkley
+//
+// https://github.com/atc0005/go-teams-notify
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package adaptivecard
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type tableTestResult struct {
+	Name     string        `table:"Check"`
+	Duration time.Duration `table:"Took,order=2,align=right"`
+	Score    float64       `table:",order=1,format=%.1f,width=2"`
+	Started  time.Time     `table:"Started,format=Jan 2, 2006"`
+	Internal string        `table:"-"`
+	hidden   string
+}
+
+// tableText returns the text of each cell of each row of the given Table.
+func tableText(t *testing.T, table Element) [][]string {
+	t.Helper()
+
+	rows := make([][]string, 0, len(table.Rows))
+	for _, row := range table.Rows {
+		values := make([]string, 0, len(row.Cells))
+		for _, cell := range row.Cells {
+			var value string
+			if len(cell.Items) > 0 && cell.Items[0] != nil {
+				value = cell.Items[0].Text
+			}
+			values = append(values, value)
+		}
+		rows = append(rows, values)
+	}
+
+	return rows
+}
+
+func TestNewTableFromStructs(t *testing.T) {
+	started := time.Date(2024, time.March, 5, 10, 0, 0, 0, time.UTC)
+
+	// Columns are sorted by their order value; Check and Started share the
+	// default order.
+
+	results := []*tableTestResult{
+		{Name: "disk", Duration: 2 * time.Second, Score: 9.87, Started: started, Internal: "x", hidden: "y"},
+		nil,
+		{Name: "cpu", Duration: time.Second, Score: 5},
+	}
+
+	elements, err := NewTableFromStructs(results, TableOptions{})
+	mustNoError(t, err)
+
+	if !assert.Len(t, elements, 1) {
+		return
+	}
+
+	table := elements[0]
+	assert.Equal(t, TypeElementTable, table.Type)
+	assert.Equal(t, [][]string{
+		{"Check", "Started", "Score", "Took"},
+		{"disk", "Mar 5, 2024", "9.9", "2s"},
+		{"cpu", "", "5.0", "1s"},
+	}, tableText(t, table))
+
+	if assert.Len(t, table.Columns, 4) {
+		assert.Equal(t, HorizontalAlignmentLeft, table.Columns[0].HorizontalCellContentAlignment)
+		assert.Equal(t, HorizontalAlignmentLeft, table.Columns[1].HorizontalCellContentAlignment)
+		assert.Equal(t, HorizontalAlignmentRight, table.Columns[2].HorizontalCellContentAlignment)
+		assert.Equal(t, 2, table.Columns[2].Width)
+		assert.Equal(t, HorizontalAlignmentRight, table.Columns[3].HorizontalCellContentAlignment)
+	}
+}
+
+func TestNewTableFromStructsErrors(t *testing.T) {
+	type badTag struct {
+		Name string `table:"Name,order=first"`
+	}
+
+	type unknownSetting struct {
+		Name string `table:"Name,color=red"`
+	}
+
+	type unexported struct {
+		name string
+	}
+
+	tests := map[string]struct {
+		value   interface{}
+		wantErr error
+	}{
+		"not a slice":       {value: tableTestResult{}, wantErr: ErrInvalidType},
+		"slice of strings":  {value: []string{"a"}, wantErr: ErrInvalidType},
+		"invalid order":     {value: []badTag{{}}, wantErr: ErrInvalidFieldValue},
+		"unknown setting":   {value: []unknownSetting{{}}, wantErr: ErrInvalidFieldValue},
+		"no exported field": {value: []unexported{{name: "a"}}, wantErr: ErrMissingValue},
+	}
+
+	for name, tt := range tests {
+		name, tt := name, tt
+
+		t.Run(name, func(t *testing.T) {
+			_, err := NewTableFromStructs(tt.value, TableOptions{})
+			assert.True(t, errors.Is(err, tt.wantErr), "got error: %v", err)
+		})
+	}
+}
+
+func TestParseTableTag(t *testing.T) {
+	tests := map[string]struct {
+		tag     string
+		want    tableColumn
+		wantErr bool
+	}{
+		"empty": {},
+		"header only": {
+			tag:  "Took",
+			want: tableColumn{TableColumn: TableColumn{Header: "Took"}},
+		},
+		"header and alignment": {
+			tag:  "Took,align=right",
+			want: tableColumn{TableColumn: TableColumn{Header: "Took", HorizontalAlignment: HorizontalAlignmentRight}},
+		},
+		"all settings": {
+			tag: ",order=1,width=2,maxlen=10,format=%.2f",
+			want: tableColumn{
+				TableColumn: TableColumn{Width: 2, MaxLength: 10, Format: "%.2f"},
+				order:       1,
+			},
+		},
+		"format containing commas": {
+			tag:  "Started,format=Jan 2, 2006,align=left",
+			want: tableColumn{TableColumn: TableColumn{Header: "Started", Format: "Jan 2, 2006", HorizontalAlignment: "left"}},
+		},
+		"format containing equals sign": {
+			tag:  "Ratio,format=a=%d",
+			want: tableColumn{TableColumn: TableColumn{Header: "Ratio", Format: "a=%d"}},
+		},
+		"invalid width":   {tag: "A,width=wide", wantErr: true},
+		"unknown setting": {tag: "A,colour=red", wantErr: true},
+	}
+
+	for name, tt := range tests {
+		name, tt := name, tt
+
+		t.Run(name, func(t *testing.T) {
+			got, err := parseTableTag(tt.tag)
+
+			if tt.wantErr {
+				assert.True(t, errors.Is(err, ErrInvalidFieldValue), "got error: %v", err)
+				return
+			}
+
+			mustNoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNewTableFromStrings(t *testing.T) {
+	rows := [][]string{
+		{"Host", "Load", "Note"},
+		{"web01", "0.5", "ok"},
+		{"db01", "12", ""},
+		{"cache01"},
+	}
+
+	elements, err := NewTableFromStrings(rows, TableOptions{
+		Columns: map[string]TableColumn{
+			"Load": {Format: "%.2f"},
+			"Note": {Header: "Notes", HorizontalAlignment: HorizontalAlignmentCenter},
+		},
+	})
+	mustNoError(t, err)
+
+	if !assert.Len(t, elements, 1) {
+		return
+	}
+
+	assert.Equal(t, [][]string{
+		{"Host", "Load", "Notes"},
+		{"web01", "0.50", "ok"},
+		{"db01", "12.00", ""},
+		{"cache01", "", ""},
+	}, tableText(t, elements[0]))
+
+	columns := elements[0].Columns
+	assert.Equal(t, HorizontalAlignmentLeft, columns[0].HorizontalCellContentAlignment)
+	assert.Equal(t, HorizontalAlignmentRight, columns[1].HorizontalCellContentAlignment)
+	assert.Equal(t, HorizontalAlignmentCenter, columns[2].HorizontalCellContentAlignment)
+
+	_, err = NewTableFromStrings(nil, TableOptions{})
+	assert.True(t, errors.Is(err, ErrMissingValue))
+
+	_, err = NewTableFromStrings([][]string{{}}, TableOptions{})
+	assert.True(t, errors.Is(err, ErrMissingValue))
+}
+
+func TestNewTableFromCSV(t *testing.T) {
+	input := "Host,Status\nweb01,\"up, healthy\"\ndb01,down,extra\n"
+
+	elements, err := NewTableFromCSV(strings.NewReader(input), TableOptions{})
+	mustNoError(t, err)
+
+	if assert.Len(t, elements, 1) {
+		assert.Equal(t, [][]string{
+			{"Host", "Status"},
+			{"web01", "up, healthy"},
+			{"db01", "down"},
+		}, tableText(t, elements[0]))
+	}
+
+	_, err = NewTableFromCSV(strings.NewReader("a,\"b\n"), TableOptions{})
+	assert.Error(t, err)
+
+	_, err = NewTableFromCSV(strings.NewReader(""), TableOptions{})
+	assert.True(t, errors.Is(err, ErrMissingValue))
+}
+
+func TestNewTableOverflow(t *testing.T) {
+	rows := [][]string{{"N"}}
+	for _, n := range []string{"1", "2", "3", "4", "5"} {
+		rows = append(rows, []string{n})
+	}
+
+	tests := map[string]struct {
+		opts       TableOptions
+		wantTables [][][]string
+		wantNote   string
+	}{
+		"truncate": {
+			opts: TableOptions{MaxRows: 2},
+			wantTables: [][][]string{
+				{{"N"}, {"1"}, {"2"}},
+			},
+			wantNote: "3 more rows not shown",
+		},
+		"truncate single row": {
+			opts: TableOptions{MaxRows: 4},
+			wantTables: [][][]string{
+				{{"N"}, {"1"}, {"2"}, {"3"}, {"4"}},
+			},
+			wantNote: "1 more row not shown",
+		},
+		"paginate": {
+			opts: TableOptions{MaxRows: 2, Overflow: TableOverflowPaginate},
+			wantTables: [][][]string{
+				{{"N"}, {"1"}, {"2"}},
+				{{"N"}, {"3"}, {"4"}},
+				{{"N"}, {"5"}},
+			},
+		},
+		"no limit": {
+			opts: TableOptions{MaxRows: -1},
+			wantTables: [][][]string{
+				{{"N"}, {"1"}, {"2"}, {"3"}, {"4"}, {"5"}},
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		name, tt := name, tt
+
+		t.Run(name, func(t *testing.T) {
+			elements, err := NewTableFromStrings(rows, tt.opts)
+			mustNoError(t, err)
+
+			var tables [][][]string
+			var note string
+			for _, element := range elements {
+				switch element.Type {
+				case TypeElementTable:
+					tables = append(tables, tableText(t, element))
+				case TypeElementTextBlock:
+					note = element.Text
+				}
+			}
+
+			assert.Equal(t, tt.wantTables, tables)
+			assert.Equal(t, tt.wantNote, note)
+		})
+	}
+}
+
+func TestNewTableRowStyleWithPagination(t *testing.T) {
+	rows := [][]string{{"Check", "Status"}, {"a", "ok"}, {"b", "fail"}, {"c", "fail"}}
+
+	elements, err := NewTableFromStrings(rows, TableOptions{
+		MaxRows:  2,
+		Overflow: TableOverflowPaginate,
+		RowStyle: func(index int, row interface{}) string {
+			if row.([]string)[1] == "fail" {
+				return ContainerStyleAttention
+			}
+			return ""
+		},
+	})
+	mustNoError(t, err)
+
+	if assert.Len(t, elements, 2) {
+		assert.Equal(t, "", elements[0].Rows[1].Style)
+		assert.Equal(t, ContainerStyleAttention, elements[0].Rows[2].Style)
+		assert.Equal(t, ContainerStyleAttention, elements[1].Rows[1].Style)
+	}
+}
+
+func TestNewTableCellTruncation(t *testing.T) {
+	rows := [][]string{{"Message", "Code"}, {"abcdefghij", "0123456789"}}
+
+	tests := map[string]struct {
+		opts TableOptions
+		want []string
+	}{
+		"cell limit": {
+			opts: TableOptions{MaxCellLength: 5},
+			want: []string{"abcd…", "0123…"},
+		},
+		"column limit overrides cell limit": {
+			opts: TableOptions{MaxCellLength: 5, Columns: map[string]TableColumn{"Code": {MaxLength: 8}}},
+			want: []string{"abcd…", "0123456…"},
+		},
+		"limit shorter than suffix": {
+			opts: TableOptions{MaxCellLength: 1},
+			want: []string{"a", "0"},
+		},
+		"no limit": {
+			opts: TableOptions{MaxCellLength: -1},
+			want: []string{"abcdefghij", "0123456789"},
+		},
+	}
+
+	for name, tt := range tests {
+		name, tt := name, tt
+
+		t.Run(name, func(t *testing.T) {
+			elements, err := NewTableFromStrings(rows, tt.opts)
+			mustNoError(t, err)
+
+			assert.Equal(t, tt.want, tableText(t, elements[0])[1])
+		})
+	}
+
+	assert.Equal(t, "héllo", truncateTableText("héllo", 5))
+	assert.Equal(t, "hé…", truncateTableText("héllo", 3))
+}
+
+func TestNewTableWithGridFromTableCells(t *testing.T) {
+	cells, err := NewTableCellsWithTextBlock([]interface{}{"a", "b", "c", "d", "e"})
+	mustNoError(t, err)
+
+	tests := map[string]struct {
+		perRow   int
+		wantRows []int
+		wantErr  error
+	}{
+		"even rows":   {perRow: 5, wantRows: []int{5}},
+		"ragged rows": {perRow: 2, wantRows: []int{2, 2, 1}},
+		"one per row": {perRow: 1, wantRows: []int{1, 1, 1, 1, 1}},
+		"more than cells": {
+			perRow:   8,
+			wantRows: []int{5},
+		},
+		"zero per row":     {perRow: 0, wantErr: ErrInvalidFieldValue},
+		"negative per row": {perRow: -1, wantErr: ErrInvalidFieldValue},
+	}
+
+	for name, tt := range tests {
+		name, tt := name, tt
+
+		t.Run(name, func(t *testing.T) {
+			table, err := NewTableWithGridFromTableCells(cells, tt.perRow)
+
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "got error: %v", err)
+				return
+			}
+
+			mustNoError(t, err)
+
+			rowLengths := make([]int, 0, len(table.Rows))
+			for _, row := range table.Rows {
+				rowLengths = append(rowLengths, len(row.Cells))
+			}
+			assert.Equal(t, tt.wantRows, rowLengths)
+		})
+	}
+
+	_, err = NewTableWithGridFromTableCells(nil, 2)
+	assert.True(t, errors.Is(err, ErrMissingValue))
+}
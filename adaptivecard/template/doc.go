@@ -0,0 +1,38 @@
+// Copyright 2024 Adam Chalkley
+//
+// https://github.com/atc0005/go-teams-notify
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+/*
+Package template implements the Adaptive Cards Templating language, allowing
+card layouts authored in the Adaptive Cards Designer to be expanded using a
+data object into a validated adaptivecard.Card.
+
+The following templating features are supported:
+
+  - ${expression} binding within any string value; a string consisting of a
+    single binding retains the type of the value (e.g., number, boolean,
+    object or array)
+  - $data to change the data context of an object; an object within an
+    array whose $data evaluates to an array is repeated once per item
+  - $when to conditionally drop an object
+  - the reserved $root, $data and $index identifiers
+  - member access (a.b), indexing (a[0], a['b']) and the usual arithmetic,
+    comparison and logical operators
+  - the commonly used built-in expression functions, including string
+    (concat, toUpper, substring, ...), logical (if, equals, exists, ...),
+    math (add, round, max, ...), conversion (int, string, json, ...),
+    collection (count, first, where, select, ...) and date (formatDateTime,
+    formatEpoch, addDays, ...) functions
+
+Properties not referenced by a binding expression evaluate to null.
+
+See the following resources for more information:
+
+  - https://docs.microsoft.com/en-us/adaptive-cards/templating/
+  - https://docs.microsoft.com/en-us/adaptive-cards/templating/language
+  - https://docs.microsoft.com/en-us/azure/bot-service/adaptive-expressions/adaptive-expressions-prebuilt-functions
+*/
+package template
@@ -0,0 +1,787 @@
+// Copyright 2024 Adam Chalkley
+//
+// https://github.com/atc0005/go-teams-notify
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package template
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Reserved identifiers available to binding expressions.
+const (
+	// IdentRoot refers to the root data object given to Expand.
+	IdentRoot string = "$root"
+
+	// IdentData refers to the current data context.
+	IdentData string = "$data"
+
+	// IdentIndex refers to the index of the current item when repeating an
+	// element using an array $data value.
+	IdentIndex string = "$index"
+)
+
+// scope is the context used to evaluate an expression.
+type scope struct {
+	root   interface{}
+	data   interface{}
+	index  interface{}
+	locals map[string]interface{}
+}
+
+// with returns a copy of the scope with the given local variable set. This is
+// used by functions which accept a lambda expression (e.g., where).
+func (s *scope) with(name string, value interface{}) *scope {
+	locals := make(map[string]interface{}, len(s.locals)+1)
+	for k, v := range s.locals {
+		locals[k] = v
+	}
+	locals[name] = value
+
+	return &scope{
+		root:   s.root,
+		data:   s.data,
+		index:  s.index,
+		locals: locals,
+	}
+}
+
+// lookup resolves the given identifier within the scope. Unknown identifiers
+// resolve to nil.
+func (s *scope) lookup(name string) interface{} {
+	if v, ok := s.locals[name]; ok {
+		return v
+	}
+
+	switch name {
+	case IdentRoot:
+		return s.root
+	case IdentData:
+		return s.data
+	case IdentIndex:
+		return s.index
+	}
+
+	return member(s.data, name)
+}
+
+// node is a parsed expression.
+type node interface {
+	eval(s *scope) (interface{}, error)
+}
+
+type literalNode struct {
+	value interface{}
+}
+
+type identNode struct {
+	name string
+}
+
+type memberNode struct {
+	object node
+	name   string
+}
+
+type indexNode struct {
+	object node
+	index  node
+}
+
+type callNode struct {
+	name string
+	args []node
+}
+
+type unaryNode struct {
+	op      string
+	operand node
+}
+
+type binaryNode struct {
+	op          string
+	left, right node
+}
+
+func (n literalNode) eval(*scope) (interface{}, error) {
+	return n.value, nil
+}
+
+func (n identNode) eval(s *scope) (interface{}, error) {
+	return s.lookup(n.name), nil
+}
+
+func (n memberNode) eval(s *scope) (interface{}, error) {
+	obj, err := n.object.eval(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return member(obj, n.name), nil
+}
+
+func (n indexNode) eval(s *scope) (interface{}, error) {
+	obj, err := n.object.eval(s)
+	if err != nil {
+		return nil, err
+	}
+
+	idx, err := n.index.eval(s)
+	if err != nil {
+		return nil, err
+	}
+
+	switch v := obj.(type) {
+	case []interface{}:
+		i, ok := toNumber(idx)
+		if !ok || i < 0 || int(i) >= len(v) {
+			return nil, nil
+		}
+		return v[int(i)], nil
+
+	case map[string]interface{}:
+		return v[toString(idx)], nil
+
+	case string:
+		i, ok := toNumber(idx)
+		if !ok || i < 0 || int(i) >= len(v) {
+			return nil, nil
+		}
+		return string(v[int(i)]), nil
+	}
+
+	return nil, nil
+}
+
+func (n callNode) eval(s *scope) (interface{}, error) {
+	// Functions accepting a lambda expression require access to the
+	// unevaluated arguments.
+	if fn, ok := lambdaFunctions[n.name]; ok {
+		return fn(s, n.args)
+	}
+
+	fn, ok := functions[n.name]
+	if !ok {
+		return nil, fmt.Errorf("function %q: %w", n.name, ErrUnknownFunction)
+	}
+
+	args := make([]interface{}, 0, len(n.args))
+	for _, arg := range n.args {
+		v, err := arg.eval(s)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, v)
+	}
+
+	result, err := fn(args...)
+	if err != nil {
+		return nil, fmt.Errorf("function %q: %w", n.name, err)
+	}
+
+	return result, nil
+}
+
+func (n unaryNode) eval(s *scope) (interface{}, error) {
+	v, err := n.operand.eval(s)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.op {
+	case "!":
+		return !truthy(v), nil
+
+	case "-":
+		num, ok := toNumber(v)
+		if !ok {
+			return nil, fmt.Errorf(
+				"operator %q requires a number, got %T: %w",
+				n.op, v, ErrInvalidExpression,
+			)
+		}
+		return -num, nil
+	}
+
+	return nil, fmt.Errorf("unsupported operator %q: %w", n.op, ErrInvalidExpression)
+}
+
+func (n binaryNode) eval(s *scope) (interface{}, error) {
+	left, err := n.left.eval(s)
+	if err != nil {
+		return nil, err
+	}
+
+	// Logical operators short-circuit.
+	switch n.op {
+	case "&&":
+		if !truthy(left) {
+			return false, nil
+		}
+		right, err := n.right.eval(s)
+		if err != nil {
+			return nil, err
+		}
+		return truthy(right), nil
+
+	case "||":
+		if truthy(left) {
+			return true, nil
+		}
+		right, err := n.right.eval(s)
+		if err != nil {
+			return nil, err
+		}
+		return truthy(right), nil
+	}
+
+	right, err := n.right.eval(s)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.op {
+	case "==":
+		return equal(left, right), nil
+
+	case "!=":
+		return !equal(left, right), nil
+
+	case "<", "<=", ">", ">=":
+		cmp, err := compare(left, right)
+		if err != nil {
+			return nil, err
+		}
+
+		switch n.op {
+		case "<":
+			return cmp < 0, nil
+		case "<=":
+			return cmp <= 0, nil
+		case ">":
+			return cmp > 0, nil
+		default:
+			return cmp >= 0, nil
+		}
+
+	case "+":
+		_, leftIsString := left.(string)
+		_, rightIsString := right.(string)
+		if leftIsString || rightIsString {
+			return toString(left) + toString(right), nil
+		}
+	}
+
+	l, lok := toNumber(left)
+	r, rok := toNumber(right)
+	if !lok || !rok {
+		return nil, fmt.Errorf(
+			"operator %q requires numbers, got %T and %T: %w",
+			n.op, left, right, ErrInvalidExpression,
+		)
+	}
+
+	switch n.op {
+	case "+":
+		return l + r, nil
+	case "-":
+		return l - r, nil
+	case "*":
+		return l * r, nil
+	case "/":
+		if r == 0 {
+			return nil, fmt.Errorf("division by zero: %w", ErrInvalidExpression)
+		}
+		return l / r, nil
+	case "%":
+		if r == 0 {
+			return nil, fmt.Errorf("division by zero: %w", ErrInvalidExpression)
+		}
+		return math.Mod(l, r), nil
+	case "^":
+		return math.Pow(l, r), nil
+	}
+
+	return nil, fmt.Errorf("unsupported operator %q: %w", n.op, ErrInvalidExpression)
+}
+
+// tokenKind identifies the kind of a lexical token.
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenNumber
+	tokenString
+	tokenIdent
+	tokenPunct
+)
+
+// token is a lexical token of an expression.
+type token struct {
+	kind  tokenKind
+	text  string
+	value interface{}
+	pos   int
+}
+
+// twoCharOperators are operators made up of two characters.
+var twoCharOperators = []string{"==", "!=", "<=", ">=", "&&", "||"}
+
+// tokenize splits the given expression into tokens.
+func tokenize(expr string) ([]token, error) {
+	var tokens []token
+
+	runes := []rune(expr)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+
+		case unicode.IsDigit(r) || (r == '.' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			text := string(runes[start:i])
+			num, err := strconv.ParseFloat(text, 64)
+			if err != nil {
+				return nil, fmt.Errorf(
+					"invalid number %q at position %d: %w",
+					text, start, ErrInvalidExpression,
+				)
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: text, value: num, pos: start})
+
+		case r == '\'' || r == '"':
+			start := i
+			quote := r
+			i++
+			var b strings.Builder
+			for {
+				if i >= len(runes) {
+					return nil, fmt.Errorf(
+						"unterminated string at position %d: %w",
+						start, ErrInvalidExpression,
+					)
+				}
+				if runes[i] == '\\' && i+1 < len(runes) {
+					b.WriteRune(unescape(runes[i+1]))
+					i += 2
+					continue
+				}
+				if runes[i] == quote {
+					i++
+					break
+				}
+				b.WriteRune(runes[i])
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenString, text: string(runes[start:i]), value: b.String(), pos: start})
+
+		case isIdentStart(r):
+			start := i
+			i++
+			for i < len(runes) && isIdentPart(runes[i]) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: string(runes[start:i]), pos: start})
+
+		default:
+			if i+1 < len(runes) {
+				pair := string(runes[i : i+2])
+				if inList(pair, twoCharOperators) {
+					tokens = append(tokens, token{kind: tokenPunct, text: pair, pos: i})
+					i += 2
+					continue
+				}
+			}
+
+			if !strings.ContainsRune("()[],.!+-*/%^<>", r) {
+				return nil, fmt.Errorf(
+					"unexpected character %q at position %d: %w",
+					r, i, ErrInvalidExpression,
+				)
+			}
+			tokens = append(tokens, token{kind: tokenPunct, text: string(r), pos: i})
+			i++
+		}
+	}
+
+	return append(tokens, token{kind: tokenEOF, pos: len(runes)}), nil
+}
+
+func isIdentStart(r rune) bool {
+	return unicode.IsLetter(r) || r == '_' || r == '$' || r == '@'
+}
+
+func isIdentPart(r rune) bool {
+	return isIdentStart(r) || unicode.IsDigit(r)
+}
+
+func unescape(r rune) rune {
+	switch r {
+	case 'n':
+		return '\n'
+	case 't':
+		return '\t'
+	case 'r':
+		return '\r'
+	default:
+		return r
+	}
+}
+
+// parser is a recursive descent parser for binding expressions.
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+// parseExpression parses the given expression text.
+func parseExpression(expr string) (node, error) {
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	p := parser{tokens: tokens}
+
+	n, err := p.parseBinary(0)
+	if err != nil {
+		return nil, err
+	}
+
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, fmt.Errorf(
+			"unexpected %q at position %d: %w",
+			tok.text, tok.pos, ErrInvalidExpression,
+		)
+	}
+
+	return n, nil
+}
+
+// binaryPrecedence lists binary operators from lowest to highest precedence.
+var binaryPrecedence = [][]string{
+	{"||"},
+	{"&&"},
+	{"==", "!="},
+	{"<", "<=", ">", ">="},
+	{"+", "-"},
+	{"*", "/", "%"},
+	{"^"},
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+
+	return tok
+}
+
+func (p *parser) expect(text string) error {
+	tok := p.next()
+	if tok.kind != tokenPunct || tok.text != text {
+		return fmt.Errorf(
+			"expected %q at position %d, got %q: %w",
+			text, tok.pos, tok.text, ErrInvalidExpression,
+		)
+	}
+
+	return nil
+}
+
+func (p *parser) parseBinary(level int) (node, error) {
+	if level >= len(binaryPrecedence) {
+		return p.parseUnary()
+	}
+
+	left, err := p.parseBinary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		tok := p.peek()
+		if tok.kind != tokenPunct || !inList(tok.text, binaryPrecedence[level]) {
+			return left, nil
+		}
+		p.next()
+
+		right, err := p.parseBinary(level + 1)
+		if err != nil {
+			return nil, err
+		}
+
+		left = binaryNode{op: tok.text, left: left, right: right}
+	}
+}
+
+func (p *parser) parseUnary() (node, error) {
+	tok := p.peek()
+	if tok.kind == tokenPunct && (tok.text == "!" || tok.text == "-") {
+		p.next()
+
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		return unaryNode{op: tok.text, operand: operand}, nil
+	}
+
+	return p.parsePostfix()
+}
+
+func (p *parser) parsePostfix() (node, error) {
+	n, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		tok := p.peek()
+		if tok.kind != tokenPunct {
+			return n, nil
+		}
+
+		switch tok.text {
+		case ".":
+			p.next()
+			name := p.next()
+			if name.kind != tokenIdent {
+				return nil, fmt.Errorf(
+					"expected property name at position %d: %w",
+					name.pos, ErrInvalidExpression,
+				)
+			}
+			n = memberNode{object: n, name: name.text}
+
+		case "[":
+			p.next()
+			idx, err := p.parseBinary(0)
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect("]"); err != nil {
+				return nil, err
+			}
+			n = indexNode{object: n, index: idx}
+
+		default:
+			return n, nil
+		}
+	}
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	tok := p.next()
+
+	switch tok.kind {
+	case tokenNumber, tokenString:
+		return literalNode{value: tok.value}, nil
+
+	case tokenIdent:
+		switch tok.text {
+		case "true":
+			return literalNode{value: true}, nil
+		case "false":
+			return literalNode{value: false}, nil
+		case "null":
+			return literalNode{value: nil}, nil
+		}
+
+		if next := p.peek(); next.kind == tokenPunct && next.text == "(" {
+			p.next()
+			args, err := p.parseArgs()
+			if err != nil {
+				return nil, err
+			}
+			return callNode{name: tok.text, args: args}, nil
+		}
+
+		return identNode{name: tok.text}, nil
+
+	case tokenPunct:
+		if tok.text == "(" {
+			n, err := p.parseBinary(0)
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			return n, nil
+		}
+	}
+
+	if tok.kind == tokenEOF {
+		return nil, fmt.Errorf("unexpected end of expression: %w", ErrInvalidExpression)
+	}
+
+	return nil, fmt.Errorf(
+		"unexpected %q at position %d: %w",
+		tok.text, tok.pos, ErrInvalidExpression,
+	)
+}
+
+func (p *parser) parseArgs() ([]node, error) {
+	var args []node
+
+	if tok := p.peek(); tok.kind == tokenPunct && tok.text == ")" {
+		p.next()
+		return args, nil
+	}
+
+	for {
+		arg, err := p.parseBinary(0)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+
+		tok := p.next()
+		if tok.kind == tokenPunct && tok.text == ")" {
+			return args, nil
+		}
+		if tok.kind != tokenPunct || tok.text != "," {
+			return nil, fmt.Errorf(
+				"expected \",\" or \")\" at position %d: %w",
+				tok.pos, ErrInvalidExpression,
+			)
+		}
+	}
+}
+
+// member returns the named property of the given object, or nil if the
+// object does not have the property.
+func member(obj interface{}, name string) interface{} {
+	switch v := obj.(type) {
+	case map[string]interface{}:
+		return v[name]
+
+	case []interface{}:
+		if name == "length" {
+			return float64(len(v))
+		}
+
+	case string:
+		if name == "length" {
+			return float64(len([]rune(v)))
+		}
+	}
+
+	return nil
+}
+
+// truthy reports whether the given value is considered true in a boolean
+// context. Only nil and false are considered false.
+func truthy(v interface{}) bool {
+	switch b := v.(type) {
+	case nil:
+		return false
+	case bool:
+		return b
+	default:
+		return true
+	}
+}
+
+// equal reports whether the given values are equal.
+func equal(a, b interface{}) bool {
+	an, aok := a.(float64)
+	bn, bok := b.(float64)
+	if aok && bok {
+		return an == bn
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+// compare compares the given numbers or strings, returning -1, 0 or 1.
+func compare(a, b interface{}) (int, error) {
+	if an, ok := a.(float64); ok {
+		if bn, ok := b.(float64); ok {
+			switch {
+			case an < bn:
+				return -1, nil
+			case an > bn:
+				return 1, nil
+			default:
+				return 0, nil
+			}
+		}
+	}
+
+	if as, ok := a.(string); ok {
+		if bs, ok := b.(string); ok {
+			return strings.Compare(as, bs), nil
+		}
+	}
+
+	return 0, fmt.Errorf(
+		"unable to compare %T and %T: %w",
+		a, b, ErrInvalidExpression,
+	)
+}
+
+// toNumber converts the given value to a number if possible.
+func toNumber(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case int:
+		return float64(n), true
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(n), 64)
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// toString converts the given value to its string representation.
+func toString(v interface{}) string {
+	switch s := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return s
+	case float64:
+		return strconv.FormatFloat(s, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(s)
+	default:
+		encoded, err := marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(encoded)
+	}
+}
+
+func inList(s string, list []string) bool {
+	for _, item := range list {
+		if s == item {
+			return true
+		}
+	}
+
+	return false
+}
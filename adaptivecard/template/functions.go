@@ -0,0 +1,1134 @@
+// Copyright 2024 Adam Chalkley
+//
+// https://github.com/atc0005/go-teams-notify
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package template
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultDateTimeFormat is the format used by formatDateTime and formatEpoch
+// when no format is given. The format uses .NET custom date and time format
+// specifiers as supported by the Adaptive Expressions language.
+const DefaultDateTimeFormat string = "yyyy-MM-ddTHH:mm:ss.fffZ"
+
+// function is a built-in function receiving evaluated arguments.
+type function func(args ...interface{}) (interface{}, error)
+
+// lambdaFunction is a built-in function receiving unevaluated arguments. This
+// is used by functions which evaluate an expression once per collection item.
+type lambdaFunction func(s *scope, args []node) (interface{}, error)
+
+// nowFunc returns the current time. This is overridden by tests.
+var nowFunc = time.Now
+
+// functions is the set of supported built-in functions.
+var functions map[string]function
+
+// lambdaFunctions is the set of supported built-in functions accepting a
+// lambda expression.
+var lambdaFunctions map[string]lambdaFunction
+
+func init() {
+	functions = map[string]function{
+		// String functions.
+		"concat":     fnConcat,
+		"length":     fnLength,
+		"toLower":    stringFunc(strings.ToLower),
+		"toUpper":    stringFunc(strings.ToUpper),
+		"trim":       stringFunc(strings.TrimSpace),
+		"substring":  fnSubstring,
+		"replace":    fnReplace,
+		"split":      fnSplit,
+		"join":       fnJoin,
+		"startsWith": stringPredicate(strings.HasPrefix),
+		"endsWith":   stringPredicate(strings.HasSuffix),
+		"contains":   fnContains,
+		"indexOf":    fnIndexOf,
+
+		// Logical functions.
+		"if":       fnIf,
+		"and":      fnAnd,
+		"or":       fnOr,
+		"not":      fnNot,
+		"equals":   fnEquals,
+		"exists":   fnExists,
+		"empty":    fnEmpty,
+		"coalesce": fnCoalesce,
+
+		// Math functions.
+		"add":     numberFunc(2, func(n []float64) float64 { return n[0] + n[1] }),
+		"sub":     numberFunc(2, func(n []float64) float64 { return n[0] - n[1] }),
+		"mul":     numberFunc(2, func(n []float64) float64 { return n[0] * n[1] }),
+		"div":     fnDiv,
+		"mod":     fnMod,
+		"min":     fnMin,
+		"max":     fnMax,
+		"round":   fnRound,
+		"floor":   numberFunc(1, func(n []float64) float64 { return math.Floor(n[0]) }),
+		"ceiling": numberFunc(1, func(n []float64) float64 { return math.Ceil(n[0]) }),
+		"abs":     numberFunc(1, func(n []float64) float64 { return math.Abs(n[0]) }),
+		"sum":     fnSum,
+		"average": fnAverage,
+
+		// Conversion functions.
+		"int":    fnInt,
+		"float":  fnFloat,
+		"string": fnString,
+		"bool":   fnBool,
+		"json":   fnJSON,
+
+		// Collection functions.
+		"count":   fnLength,
+		"first":   fnFirst,
+		"last":    fnLast,
+		"take":    fnTake,
+		"skip":    fnSkip,
+		"reverse": fnReverse,
+
+		// Date and time functions.
+		"utcNow":         fnUTCNow,
+		"formatDateTime": fnFormatDateTime,
+		"formatEpoch":    fnFormatEpoch,
+		"addDays":        addDuration(24 * time.Hour),
+		"addHours":       addDuration(time.Hour),
+		"addMinutes":     addDuration(time.Minute),
+		"addSeconds":     addDuration(time.Second),
+
+		// Formatting functions.
+		"formatNumber": fnFormatNumber,
+	}
+
+	lambdaFunctions = map[string]lambdaFunction{
+		"where":   lambdaWhere,
+		"select":  lambdaSelect,
+		"foreach": lambdaSelect,
+		"any":     lambdaAny,
+		"all":     lambdaAll,
+	}
+}
+
+// argCount returns an error if the number of given arguments is outside of
+// the given range.
+func argCount(args []interface{}, min int, max int) error {
+	if len(args) < min || (max >= 0 && len(args) > max) {
+		return fmt.Errorf(
+			"unexpected number of arguments %d: %w",
+			len(args), ErrInvalidArguments,
+		)
+	}
+
+	return nil
+}
+
+func stringArg(args []interface{}, i int) (string, error) {
+	s, ok := args[i].(string)
+	if !ok {
+		return "", fmt.Errorf(
+			"argument %d: expected string, got %T: %w",
+			i+1, args[i], ErrInvalidArguments,
+		)
+	}
+
+	return s, nil
+}
+
+func numberArg(args []interface{}, i int) (float64, error) {
+	n, ok := toNumber(args[i])
+	if !ok {
+		return 0, fmt.Errorf(
+			"argument %d: expected number, got %T: %w",
+			i+1, args[i], ErrInvalidArguments,
+		)
+	}
+
+	return n, nil
+}
+
+func arrayArg(args []interface{}, i int) ([]interface{}, error) {
+	switch v := args[i].(type) {
+	case []interface{}:
+		return v, nil
+	case nil:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf(
+			"argument %d: expected array, got %T: %w",
+			i+1, args[i], ErrInvalidArguments,
+		)
+	}
+}
+
+func stringFunc(fn func(string) string) function {
+	return func(args ...interface{}) (interface{}, error) {
+		if err := argCount(args, 1, 1); err != nil {
+			return nil, err
+		}
+
+		return fn(toString(args[0])), nil
+	}
+}
+
+func stringPredicate(fn func(string, string) bool) function {
+	return func(args ...interface{}) (interface{}, error) {
+		if err := argCount(args, 2, 2); err != nil {
+			return nil, err
+		}
+
+		return fn(toString(args[0]), toString(args[1])), nil
+	}
+}
+
+func numberFunc(count int, fn func([]float64) float64) function {
+	return func(args ...interface{}) (interface{}, error) {
+		if err := argCount(args, count, count); err != nil {
+			return nil, err
+		}
+
+		nums := make([]float64, 0, count)
+		for i := range args {
+			n, err := numberArg(args, i)
+			if err != nil {
+				return nil, err
+			}
+			nums = append(nums, n)
+		}
+
+		return fn(nums), nil
+	}
+}
+
+func fnConcat(args ...interface{}) (interface{}, error) {
+	var b strings.Builder
+	for _, arg := range args {
+		b.WriteString(toString(arg))
+	}
+
+	return b.String(), nil
+}
+
+func fnLength(args ...interface{}) (interface{}, error) {
+	if err := argCount(args, 1, 1); err != nil {
+		return nil, err
+	}
+
+	switch v := args[0].(type) {
+	case string:
+		return float64(len([]rune(v))), nil
+	case []interface{}:
+		return float64(len(v)), nil
+	case map[string]interface{}:
+		return float64(len(v)), nil
+	case nil:
+		return float64(0), nil
+	default:
+		return nil, fmt.Errorf(
+			"expected string or array, got %T: %w",
+			args[0], ErrInvalidArguments,
+		)
+	}
+}
+
+func fnSubstring(args ...interface{}) (interface{}, error) {
+	if err := argCount(args, 2, 3); err != nil {
+		return nil, err
+	}
+
+	s := []rune(toString(args[0]))
+
+	start, err := numberArg(args, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	length := float64(len(s)) - start
+	if len(args) == 3 {
+		if length, err = numberArg(args, 2); err != nil {
+			return nil, err
+		}
+	}
+
+	if start < 0 || length < 0 || int(start+length) > len(s) {
+		return nil, fmt.Errorf(
+			"range [%v:%v] out of bounds for length %d: %w",
+			start, start+length, len(s), ErrInvalidArguments,
+		)
+	}
+
+	return string(s[int(start):int(start+length)]), nil
+}
+
+func fnReplace(args ...interface{}) (interface{}, error) {
+	if err := argCount(args, 3, 3); err != nil {
+		return nil, err
+	}
+
+	return strings.ReplaceAll(
+		toString(args[0]), toString(args[1]), toString(args[2]),
+	), nil
+}
+
+func fnSplit(args ...interface{}) (interface{}, error) {
+	if err := argCount(args, 1, 2); err != nil {
+		return nil, err
+	}
+
+	var sep string
+	if len(args) == 2 {
+		sep = toString(args[1])
+	}
+
+	parts := strings.Split(toString(args[0]), sep)
+	result := make([]interface{}, 0, len(parts))
+	for _, part := range parts {
+		result = append(result, part)
+	}
+
+	return result, nil
+}
+
+func fnJoin(args ...interface{}) (interface{}, error) {
+	if err := argCount(args, 2, 3); err != nil {
+		return nil, err
+	}
+
+	items, err := arrayArg(args, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	sep := toString(args[1])
+	parts := make([]string, 0, len(items))
+	for _, item := range items {
+		parts = append(parts, toString(item))
+	}
+
+	// The optional third argument is used to join the last item.
+	if len(args) == 3 && len(parts) > 1 {
+		last := len(parts) - 1
+		return strings.Join(parts[:last], sep) + toString(args[2]) + parts[last], nil
+	}
+
+	return strings.Join(parts, sep), nil
+}
+
+func fnContains(args ...interface{}) (interface{}, error) {
+	if err := argCount(args, 2, 2); err != nil {
+		return nil, err
+	}
+
+	switch v := args[0].(type) {
+	case string:
+		return strings.Contains(v, toString(args[1])), nil
+	case []interface{}:
+		for _, item := range v {
+			if equal(item, args[1]) {
+				return true, nil
+			}
+		}
+		return false, nil
+	case map[string]interface{}:
+		_, ok := v[toString(args[1])]
+		return ok, nil
+	case nil:
+		return false, nil
+	default:
+		return nil, fmt.Errorf(
+			"expected string, array or object, got %T: %w",
+			args[0], ErrInvalidArguments,
+		)
+	}
+}
+
+func fnIndexOf(args ...interface{}) (interface{}, error) {
+	if err := argCount(args, 2, 2); err != nil {
+		return nil, err
+	}
+
+	if items, ok := args[0].([]interface{}); ok {
+		for i, item := range items {
+			if equal(item, args[1]) {
+				return float64(i), nil
+			}
+		}
+		return float64(-1), nil
+	}
+
+	s := toString(args[0])
+	idx := strings.Index(s, toString(args[1]))
+	if idx > 0 {
+		idx = len([]rune(s[:idx]))
+	}
+
+	return float64(idx), nil
+}
+
+func fnIf(args ...interface{}) (interface{}, error) {
+	if err := argCount(args, 3, 3); err != nil {
+		return nil, err
+	}
+
+	if truthy(args[0]) {
+		return args[1], nil
+	}
+
+	return args[2], nil
+}
+
+func fnAnd(args ...interface{}) (interface{}, error) {
+	if err := argCount(args, 1, -1); err != nil {
+		return nil, err
+	}
+
+	for _, arg := range args {
+		if !truthy(arg) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func fnOr(args ...interface{}) (interface{}, error) {
+	if err := argCount(args, 1, -1); err != nil {
+		return nil, err
+	}
+
+	for _, arg := range args {
+		if truthy(arg) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func fnNot(args ...interface{}) (interface{}, error) {
+	if err := argCount(args, 1, 1); err != nil {
+		return nil, err
+	}
+
+	return !truthy(args[0]), nil
+}
+
+func fnEquals(args ...interface{}) (interface{}, error) {
+	if err := argCount(args, 2, 2); err != nil {
+		return nil, err
+	}
+
+	return equal(args[0], args[1]), nil
+}
+
+func fnExists(args ...interface{}) (interface{}, error) {
+	if err := argCount(args, 1, 1); err != nil {
+		return nil, err
+	}
+
+	return args[0] != nil, nil
+}
+
+func fnEmpty(args ...interface{}) (interface{}, error) {
+	if err := argCount(args, 1, 1); err != nil {
+		return nil, err
+	}
+
+	switch v := args[0].(type) {
+	case nil:
+		return true, nil
+	case string:
+		return v == "", nil
+	case []interface{}:
+		return len(v) == 0, nil
+	case map[string]interface{}:
+		return len(v) == 0, nil
+	default:
+		return false, nil
+	}
+}
+
+func fnCoalesce(args ...interface{}) (interface{}, error) {
+	for _, arg := range args {
+		if arg != nil {
+			return arg, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func fnDiv(args ...interface{}) (interface{}, error) {
+	if err := argCount(args, 2, 2); err != nil {
+		return nil, err
+	}
+
+	a, err := numberArg(args, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := numberArg(args, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	if b == 0 {
+		return nil, fmt.Errorf("division by zero: %w", ErrInvalidArguments)
+	}
+
+	return a / b, nil
+}
+
+func fnMod(args ...interface{}) (interface{}, error) {
+	if err := argCount(args, 2, 2); err != nil {
+		return nil, err
+	}
+
+	a, err := numberArg(args, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := numberArg(args, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	if b == 0 {
+		return nil, fmt.Errorf("division by zero: %w", ErrInvalidArguments)
+	}
+
+	return math.Mod(a, b), nil
+}
+
+// numbers returns the numbers given either as separate arguments or as a
+// single array argument.
+func numbers(args []interface{}) ([]float64, error) {
+	if len(args) == 1 {
+		if items, ok := args[0].([]interface{}); ok {
+			args = items
+		}
+	}
+
+	nums := make([]float64, 0, len(args))
+	for i := range args {
+		n, err := numberArg(args, i)
+		if err != nil {
+			return nil, err
+		}
+		nums = append(nums, n)
+	}
+
+	return nums, nil
+}
+
+func fnMin(args ...interface{}) (interface{}, error) {
+	nums, err := numbers(args)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(nums) == 0 {
+		return nil, fmt.Errorf("no values given: %w", ErrInvalidArguments)
+	}
+
+	result := nums[0]
+	for _, n := range nums[1:] {
+		result = math.Min(result, n)
+	}
+
+	return result, nil
+}
+
+func fnMax(args ...interface{}) (interface{}, error) {
+	nums, err := numbers(args)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(nums) == 0 {
+		return nil, fmt.Errorf("no values given: %w", ErrInvalidArguments)
+	}
+
+	result := nums[0]
+	for _, n := range nums[1:] {
+		result = math.Max(result, n)
+	}
+
+	return result, nil
+}
+
+func fnRound(args ...interface{}) (interface{}, error) {
+	if err := argCount(args, 1, 2); err != nil {
+		return nil, err
+	}
+
+	n, err := numberArg(args, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var precision float64
+	if len(args) == 2 {
+		if precision, err = numberArg(args, 1); err != nil {
+			return nil, err
+		}
+	}
+
+	factor := math.Pow(10, precision)
+
+	return math.Round(n*factor) / factor, nil
+}
+
+func fnSum(args ...interface{}) (interface{}, error) {
+	nums, err := numbers(args)
+	if err != nil {
+		return nil, err
+	}
+
+	var total float64
+	for _, n := range nums {
+		total += n
+	}
+
+	return total, nil
+}
+
+func fnAverage(args ...interface{}) (interface{}, error) {
+	nums, err := numbers(args)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(nums) == 0 {
+		return nil, fmt.Errorf("no values given: %w", ErrInvalidArguments)
+	}
+
+	var total float64
+	for _, n := range nums {
+		total += n
+	}
+
+	return total / float64(len(nums)), nil
+}
+
+func fnInt(args ...interface{}) (interface{}, error) {
+	if err := argCount(args, 1, 1); err != nil {
+		return nil, err
+	}
+
+	n, err := numberArg(args, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return math.Trunc(n), nil
+}
+
+func fnFloat(args ...interface{}) (interface{}, error) {
+	if err := argCount(args, 1, 1); err != nil {
+		return nil, err
+	}
+
+	return numberArg(args, 0)
+}
+
+func fnString(args ...interface{}) (interface{}, error) {
+	if err := argCount(args, 1, 1); err != nil {
+		return nil, err
+	}
+
+	return toString(args[0]), nil
+}
+
+func fnBool(args ...interface{}) (interface{}, error) {
+	if err := argCount(args, 1, 1); err != nil {
+		return nil, err
+	}
+
+	switch v := args[0].(type) {
+	case string:
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return v != "", nil
+		}
+		return b, nil
+	case float64:
+		return v != 0, nil
+	default:
+		return truthy(v), nil
+	}
+}
+
+func fnJSON(args ...interface{}) (interface{}, error) {
+	if err := argCount(args, 1, 1); err != nil {
+		return nil, err
+	}
+
+	s, err := stringArg(args, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var v interface{}
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		return nil, fmt.Errorf("%v: %w", err, ErrInvalidArguments)
+	}
+
+	return v, nil
+}
+
+func fnFirst(args ...interface{}) (interface{}, error) {
+	if err := argCount(args, 1, 1); err != nil {
+		return nil, err
+	}
+
+	switch v := args[0].(type) {
+	case []interface{}:
+		if len(v) > 0 {
+			return v[0], nil
+		}
+	case string:
+		if r := []rune(v); len(r) > 0 {
+			return string(r[0]), nil
+		}
+	}
+
+	return nil, nil
+}
+
+func fnLast(args ...interface{}) (interface{}, error) {
+	if err := argCount(args, 1, 1); err != nil {
+		return nil, err
+	}
+
+	switch v := args[0].(type) {
+	case []interface{}:
+		if len(v) > 0 {
+			return v[len(v)-1], nil
+		}
+	case string:
+		if r := []rune(v); len(r) > 0 {
+			return string(r[len(r)-1]), nil
+		}
+	}
+
+	return nil, nil
+}
+
+func fnTake(args ...interface{}) (interface{}, error) {
+	if err := argCount(args, 2, 2); err != nil {
+		return nil, err
+	}
+
+	items, err := arrayArg(args, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := numberArg(args, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	count := int(math.Max(0, math.Min(n, float64(len(items)))))
+
+	return append([]interface{}{}, items[:count]...), nil
+}
+
+func fnSkip(args ...interface{}) (interface{}, error) {
+	if err := argCount(args, 2, 2); err != nil {
+		return nil, err
+	}
+
+	items, err := arrayArg(args, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := numberArg(args, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	count := int(math.Max(0, math.Min(n, float64(len(items)))))
+
+	return append([]interface{}{}, items[count:]...), nil
+}
+
+func fnReverse(args ...interface{}) (interface{}, error) {
+	if err := argCount(args, 1, 1); err != nil {
+		return nil, err
+	}
+
+	switch v := args[0].(type) {
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, item := range v {
+			result[len(v)-1-i] = item
+		}
+		return result, nil
+
+	case string:
+		r := []rune(v)
+		for i, j := 0, len(r)-1; i < j; i, j = i+1, j-1 {
+			r[i], r[j] = r[j], r[i]
+		}
+		return string(r), nil
+
+	default:
+		return nil, fmt.Errorf(
+			"expected string or array, got %T: %w",
+			args[0], ErrInvalidArguments,
+		)
+	}
+}
+
+func fnUTCNow(args ...interface{}) (interface{}, error) {
+	if err := argCount(args, 0, 1); err != nil {
+		return nil, err
+	}
+
+	format := DefaultDateTimeFormat
+	if len(args) == 1 {
+		format = toString(args[0])
+	}
+
+	return nowFunc().UTC().Format(dotNetLayout(format)), nil
+}
+
+// timestampArg parses the given argument as an ISO 8601 timestamp.
+func timestampArg(args []interface{}, i int) (time.Time, error) {
+	s, err := stringArg(args, i)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf(
+			"argument %d: %v: %w",
+			i+1, err, ErrInvalidArguments,
+		)
+	}
+
+	return t, nil
+}
+
+func fnFormatDateTime(args ...interface{}) (interface{}, error) {
+	if err := argCount(args, 1, 2); err != nil {
+		return nil, err
+	}
+
+	t, err := timestampArg(args, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	format := DefaultDateTimeFormat
+	if len(args) == 2 {
+		format = toString(args[1])
+	}
+
+	return t.UTC().Format(dotNetLayout(format)), nil
+}
+
+func fnFormatEpoch(args ...interface{}) (interface{}, error) {
+	if err := argCount(args, 1, 2); err != nil {
+		return nil, err
+	}
+
+	epoch, err := numberArg(args, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	format := DefaultDateTimeFormat
+	if len(args) == 2 {
+		format = toString(args[1])
+	}
+
+	sec, frac := math.Modf(epoch)
+	t := time.Unix(int64(sec), int64(frac*float64(time.Second)))
+
+	return t.UTC().Format(dotNetLayout(format)), nil
+}
+
+func addDuration(unit time.Duration) function {
+	return func(args ...interface{}) (interface{}, error) {
+		if err := argCount(args, 2, 3); err != nil {
+			return nil, err
+		}
+
+		t, err := timestampArg(args, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		n, err := numberArg(args, 1)
+		if err != nil {
+			return nil, err
+		}
+
+		format := DefaultDateTimeFormat
+		if len(args) == 3 {
+			format = toString(args[2])
+		}
+
+		t = t.Add(time.Duration(n * float64(unit)))
+
+		return t.UTC().Format(dotNetLayout(format)), nil
+	}
+}
+
+// dotNetSpecifiers maps .NET custom date and time format specifiers to their
+// Go layout equivalent. Longer specifiers are listed first.
+var dotNetSpecifiers = []struct {
+	specifier string
+	layout    string
+}{
+	{"yyyy", "2006"},
+	{"yy", "06"},
+	{"MMMM", "January"},
+	{"MMM", "Jan"},
+	{"MM", "01"},
+	{"M", "1"},
+	{"dddd", "Monday"},
+	{"ddd", "Mon"},
+	{"dd", "02"},
+	{"d", "2"},
+	{"HH", "15"},
+	{"hh", "03"},
+	{"h", "3"},
+	{"mm", "04"},
+	{"m", "4"},
+	{"ss", "05"},
+	{"s", "5"},
+	{"fff", "000"},
+	{"ff", "00"},
+	{"f", "0"},
+	{"tt", "PM"},
+	{"zzz", "-07:00"},
+	{"K", "Z07:00"},
+}
+
+// dotNetLayout converts the given .NET custom date and time format string to
+// a Go time layout. Text within single quotes is copied as-is.
+func dotNetLayout(format string) string {
+	var b strings.Builder
+
+	for i := 0; i < len(format); {
+		if format[i] == '\'' {
+			end := strings.IndexByte(format[i+1:], '\'')
+			if end < 0 {
+				b.WriteString(format[i+1:])
+				break
+			}
+			b.WriteString(format[i+1 : i+1+end])
+			i += end + 2
+			continue
+		}
+
+		// .NET uses H for the 24-hour clock without padding which has no Go
+		// equivalent; the padded form is used instead.
+		if format[i] == 'H' {
+			b.WriteString("15")
+			for i < len(format) && format[i] == 'H' {
+				i++
+			}
+			continue
+		}
+
+		matched := false
+		for _, spec := range dotNetSpecifiers {
+			if strings.HasPrefix(format[i:], spec.specifier) {
+				b.WriteString(spec.layout)
+				i += len(spec.specifier)
+				matched = true
+				break
+			}
+		}
+
+		if !matched {
+			b.WriteByte(format[i])
+			i++
+		}
+	}
+
+	return b.String()
+}
+
+func fnFormatNumber(args ...interface{}) (interface{}, error) {
+	if err := argCount(args, 1, 2); err != nil {
+		return nil, err
+	}
+
+	n, err := numberArg(args, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var precision float64
+	if len(args) == 2 {
+		if precision, err = numberArg(args, 1); err != nil {
+			return nil, err
+		}
+	}
+
+	formatted := strconv.FormatFloat(math.Abs(n), 'f', int(precision), 64)
+
+	intPart, fracPart := formatted, ""
+	if idx := strings.IndexByte(formatted, '.'); idx >= 0 {
+		intPart, fracPart = formatted[:idx], formatted[idx:]
+	}
+
+	var b strings.Builder
+	if n < 0 {
+		b.WriteByte('-')
+	}
+	for i, r := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(r)
+	}
+	b.WriteString(fracPart)
+
+	return b.String(), nil
+}
+
+// lambdaArgs evaluates the collection argument and returns the iterator name
+// and expression of a lambda function call.
+func lambdaArgs(s *scope, args []node) ([]interface{}, string, node, error) {
+	if len(args) != 3 {
+		return nil, "", nil, fmt.Errorf(
+			"unexpected number of arguments %d: %w",
+			len(args), ErrInvalidArguments,
+		)
+	}
+
+	collection, err := args[0].eval(s)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	var items []interface{}
+	switch v := collection.(type) {
+	case []interface{}:
+		items = v
+	case nil:
+	case map[string]interface{}:
+		for key, value := range v {
+			items = append(items, map[string]interface{}{
+				"key":   key,
+				"value": value,
+			})
+		}
+	default:
+		return nil, "", nil, fmt.Errorf(
+			"argument 1: expected array, got %T: %w",
+			collection, ErrInvalidArguments,
+		)
+	}
+
+	var name string
+	switch v := args[1].(type) {
+	case identNode:
+		name = v.name
+	case literalNode:
+		name, _ = v.value.(string)
+	}
+
+	if name == "" {
+		return nil, "", nil, fmt.Errorf(
+			"argument 2: expected iterator name: %w",
+			ErrInvalidArguments,
+		)
+	}
+
+	return items, name, args[2], nil
+}
+
+func lambdaWhere(s *scope, args []node) (interface{}, error) {
+	items, name, expr, err := lambdaArgs(s, args)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		v, err := expr.eval(s.with(name, item))
+		if err != nil {
+			return nil, err
+		}
+		if truthy(v) {
+			result = append(result, item)
+		}
+	}
+
+	return result, nil
+}
+
+func lambdaSelect(s *scope, args []node) (interface{}, error) {
+	items, name, expr, err := lambdaArgs(s, args)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		v, err := expr.eval(s.with(name, item))
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, v)
+	}
+
+	return result, nil
+}
+
+func lambdaAny(s *scope, args []node) (interface{}, error) {
+	items, name, expr, err := lambdaArgs(s, args)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range items {
+		v, err := expr.eval(s.with(name, item))
+		if err != nil {
+			return nil, err
+		}
+		if truthy(v) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func lambdaAll(s *scope, args []node) (interface{}, error) {
+	items, name, expr, err := lambdaArgs(s, args)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range items {
+		v, err := expr.eval(s.with(name, item))
+		if err != nil {
+			return nil, err
+		}
+		if !truthy(v) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
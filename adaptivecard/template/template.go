@@ -0,0 +1,495 @@
+// Copyright 2024 Adam Chalkley
+//
+// https://github.com/atc0005/go-teams-notify
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package template
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/flashcatcloud/go-teams-notify/v2/adaptivecard"
+)
+
+// Properties with special meaning to the templating language.
+const (
+	// PropertyData is the property used to change the data context of an
+	// object. If the value is an array and the object is an item of an
+	// array, the object is repeated once for each item.
+	PropertyData string = "$data"
+
+	// PropertyWhen is the property used to conditionally include an
+	// object. The object is dropped if the expression evaluates to false or
+	// null.
+	PropertyWhen string = "$when"
+)
+
+// Binding expression delimiters.
+const (
+	bindingStart string = "${"
+	bindingEnd   string = "}"
+)
+
+var (
+	// ErrInvalidTemplate indicates that the given template is not valid
+	// JSON or that it contains an invalid binding expression.
+	ErrInvalidTemplate = errors.New("invalid template")
+
+	// ErrInvalidExpression indicates that a binding expression is
+	// malformed or could not be evaluated.
+	ErrInvalidExpression = errors.New("invalid expression")
+
+	// ErrUnknownFunction indicates that a binding expression calls a
+	// function which is not supported.
+	ErrUnknownFunction = errors.New("unknown function")
+
+	// ErrInvalidArguments indicates that a function was called with an
+	// invalid number or type of arguments.
+	ErrInvalidArguments = errors.New("invalid arguments")
+
+	// ErrInvalidData indicates that the given data could not be converted
+	// to a JSON value.
+	ErrInvalidData = errors.New("invalid data")
+)
+
+// Template is a parsed Adaptive Card template. A Template is safe for
+// concurrent use once created.
+type Template struct {
+	// root is the decoded template JSON.
+	root interface{}
+
+	// bindings holds the parsed segments of each string in the template
+	// which contains a binding expression.
+	bindings map[string][]segment
+}
+
+// segment is part of a template string. A segment is either literal text
+// or a parsed binding expression.
+type segment struct {
+	text string
+	expr node
+}
+
+// New parses the given Adaptive Card template JSON, as produced by the
+// Adaptive Cards Designer. All binding expressions are parsed up front; an
+// error is returned if the template is not valid JSON or if any binding
+// expression is malformed.
+func New(templateJSON []byte) (*Template, error) {
+	var root interface{}
+
+	if err := json.Unmarshal(templateJSON, &root); err != nil {
+		return nil, fmt.Errorf("%v: %w", err, ErrInvalidTemplate)
+	}
+
+	t := Template{
+		root:     root,
+		bindings: make(map[string][]segment),
+	}
+
+	if err := t.compile(root, "$"); err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+// Expand expands the template using the given data and returns the
+// resulting card JSON. The data may be any value which can be encoded as
+// JSON; []byte and json.RawMessage values are treated as JSON text.
+func (t *Template) Expand(data interface{}) ([]byte, error) {
+	normalized, err := normalizeData(data)
+	if err != nil {
+		return nil, err
+	}
+
+	s := scope{root: normalized, data: normalized}
+
+	expanded, keep, err := t.expand(t.root, &s, "$")
+	if err != nil {
+		return nil, err
+	}
+
+	if !keep {
+		return nil, fmt.Errorf(
+			"%s evaluated to false for template root: %w",
+			PropertyWhen, ErrInvalidTemplate,
+		)
+	}
+
+	return marshal(expanded)
+}
+
+// ExpandCard expands the template using the given data and decodes the
+// result as a Card. The Card is validated before it is returned; if the
+// Version field is set the Card is validated as a top-level card.
+func (t *Template) ExpandCard(data interface{}) (adaptivecard.Card, error) {
+	expanded, err := t.Expand(data)
+	if err != nil {
+		return adaptivecard.Card{}, err
+	}
+
+	var card adaptivecard.Card
+	if err := json.Unmarshal(expanded, &card); err != nil {
+		return adaptivecard.Card{}, fmt.Errorf(
+			"failed to decode expanded template: %v: %w",
+			err, ErrInvalidTemplate,
+		)
+	}
+
+	if card.Version != "" {
+		err = adaptivecard.TopLevelCard{Card: card}.Validate()
+	} else {
+		err = card.Validate()
+	}
+
+	if err != nil {
+		return adaptivecard.Card{}, fmt.Errorf(
+			"expanded template failed validation: %w",
+			err,
+		)
+	}
+
+	return card, nil
+}
+
+// Expand is a convenience function which parses the given template JSON and
+// expands it into a validated Card using the given data.
+func Expand(templateJSON []byte, data interface{}) (adaptivecard.Card, error) {
+	t, err := New(templateJSON)
+	if err != nil {
+		return adaptivecard.Card{}, err
+	}
+
+	return t.ExpandCard(data)
+}
+
+// compile parses the binding expressions of every string within the given
+// template value.
+func (t *Template) compile(v interface{}, path string) error {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for key, item := range val {
+			if err := t.compile(item, path+"."+key); err != nil {
+				return err
+			}
+		}
+
+	case []interface{}:
+		for i, item := range val {
+			if err := t.compile(item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+
+	case string:
+		if _, ok := t.bindings[val]; ok || !strings.Contains(val, bindingStart) {
+			return nil
+		}
+
+		segments, err := parseSegments(val)
+		if err != nil {
+			return fmt.Errorf("%s: %v: %w", path, err, ErrInvalidTemplate)
+		}
+		t.bindings[val] = segments
+	}
+
+	return nil
+}
+
+// expand expands the given template value using the given scope. The
+// returned bool is false if the value is to be dropped because of a $when
+// property.
+func (t *Template) expand(v interface{}, s *scope, path string) (interface{}, bool, error) {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		return t.expandObject(val, s, path)
+
+	case []interface{}:
+		result, err := t.expandArray(val, s, path)
+		return result, true, err
+
+	case string:
+		result, err := t.expandString(val, s, path)
+		return result, true, err
+
+	default:
+		return val, true, nil
+	}
+}
+
+// expandArray expands each item of the given template array. Items with an
+// array $data value are repeated once per data item and items dropped by a
+// $when property are removed.
+func (t *Template) expandArray(items []interface{}, s *scope, path string) ([]interface{}, error) {
+	result := make([]interface{}, 0, len(items))
+
+	for i, item := range items {
+		itemPath := fmt.Sprintf("%s[%d]", path, i)
+
+		obj, ok := item.(map[string]interface{})
+		if !ok {
+			expanded, _, err := t.expand(item, s, itemPath)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, expanded)
+			continue
+		}
+
+		dataValue, hasData := obj[PropertyData]
+		if !hasData {
+			expanded, keep, err := t.expandObject(obj, s, itemPath)
+			if err != nil {
+				return nil, err
+			}
+			if keep {
+				result = append(result, expanded)
+			}
+			continue
+		}
+
+		data, _, err := t.expand(dataValue, s, itemPath+"."+PropertyData)
+		if err != nil {
+			return nil, err
+		}
+
+		repeat, isArray := data.([]interface{})
+		if !isArray {
+			expanded, keep, err := t.expandScoped(obj, s.withData(data, s.index), itemPath)
+			if err != nil {
+				return nil, err
+			}
+			if keep {
+				result = append(result, expanded)
+			}
+			continue
+		}
+
+		for idx, dataItem := range repeat {
+			expanded, keep, err := t.expandScoped(
+				obj,
+				s.withData(dataItem, float64(idx)),
+				fmt.Sprintf("%s[%d]", itemPath, idx),
+			)
+			if err != nil {
+				return nil, err
+			}
+			if keep {
+				result = append(result, expanded)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// expandObject expands the given template object, first applying any $data
+// property to the scope.
+func (t *Template) expandObject(obj map[string]interface{}, s *scope, path string) (interface{}, bool, error) {
+	if dataValue, ok := obj[PropertyData]; ok {
+		data, _, err := t.expand(dataValue, s, path+"."+PropertyData)
+		if err != nil {
+			return nil, false, err
+		}
+		s = s.withData(data, s.index)
+	}
+
+	return t.expandScoped(obj, s, path)
+}
+
+// expandScoped expands the properties of the given template object using
+// the given scope. The $data property is expected to have been applied
+// already.
+func (t *Template) expandScoped(obj map[string]interface{}, s *scope, path string) (interface{}, bool, error) {
+	if when, ok := obj[PropertyWhen]; ok {
+		result, _, err := t.expand(when, s, path+"."+PropertyWhen)
+		if err != nil {
+			return nil, false, err
+		}
+		if !truthy(result) {
+			return nil, false, nil
+		}
+	}
+
+	result := make(map[string]interface{}, len(obj))
+	for key, value := range obj {
+		if key == PropertyData || key == PropertyWhen {
+			continue
+		}
+
+		expanded, keep, err := t.expand(value, s, path+"."+key)
+		if err != nil {
+			return nil, false, err
+		}
+		if keep {
+			result[key] = expanded
+		}
+	}
+
+	return result, true, nil
+}
+
+// expandString evaluates the binding expressions within the given template
+// string. A string consisting of a single binding expression evaluates to
+// the value of the expression, retaining its type. Otherwise the values are
+// converted to strings and concatenated with the literal text.
+func (t *Template) expandString(str string, s *scope, path string) (interface{}, error) {
+	segments, ok := t.bindings[str]
+	if !ok {
+		return str, nil
+	}
+
+	if len(segments) == 1 && segments[0].expr != nil {
+		result, err := segments[0].expr.eval(s)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		return result, nil
+	}
+
+	var b strings.Builder
+	for _, seg := range segments {
+		if seg.expr == nil {
+			b.WriteString(seg.text)
+			continue
+		}
+
+		result, err := seg.expr.eval(s)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		b.WriteString(toString(result))
+	}
+
+	return b.String(), nil
+}
+
+// withData returns a copy of the scope using the given data context and
+// index.
+func (s *scope) withData(data interface{}, index interface{}) *scope {
+	return &scope{
+		root:   s.root,
+		data:   data,
+		index:  index,
+		locals: s.locals,
+	}
+}
+
+// parseSegments splits the given template string into literal text and
+// parsed binding expressions.
+func parseSegments(str string) ([]segment, error) {
+	var segments []segment
+
+	for len(str) > 0 {
+		start := strings.Index(str, bindingStart)
+		if start < 0 {
+			segments = append(segments, segment{text: str})
+			break
+		}
+
+		if start > 0 {
+			segments = append(segments, segment{text: str[:start]})
+		}
+
+		end := bindingEndIndex(str, start+len(bindingStart))
+		if end < 0 {
+			return nil, fmt.Errorf(
+				"unterminated binding expression %q: %w",
+				str[start:], ErrInvalidExpression,
+			)
+		}
+
+		exprText := str[start+len(bindingStart) : end]
+		expr, err := parseExpression(exprText)
+		if err != nil {
+			return nil, fmt.Errorf("%q: %w", exprText, err)
+		}
+
+		segments = append(segments, segment{expr: expr})
+		str = str[end+len(bindingEnd):]
+	}
+
+	return segments, nil
+}
+
+// bindingEndIndex returns the index of the closing brace of the binding
+// expression starting at the given offset, skipping over braces within
+// string literals. -1 is returned if the expression is not terminated.
+func bindingEndIndex(str string, offset int) int {
+	var quote byte
+	depth := 0
+
+	for i := offset; i < len(str); i++ {
+		c := str[i]
+
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+
+		case c == '\'' || c == '"':
+			quote = c
+
+		case c == '{':
+			depth++
+
+		case c == '}':
+			if depth == 0 {
+				return i
+			}
+			depth--
+		}
+	}
+
+	return -1
+}
+
+// normalizeData converts the given data to the generic JSON representation
+// used when evaluating binding expressions.
+func normalizeData(data interface{}) (interface{}, error) {
+	var raw []byte
+
+	switch v := data.(type) {
+	case nil:
+		return nil, nil
+	case []byte:
+		raw = v
+	case json.RawMessage:
+		raw = v
+	default:
+		encoded, err := json.Marshal(data)
+		if err != nil {
+			return nil, fmt.Errorf("%v: %w", err, ErrInvalidData)
+		}
+		raw = encoded
+	}
+
+	var normalized interface{}
+	if err := json.Unmarshal(raw, &normalized); err != nil {
+		return nil, fmt.Errorf("%v: %w", err, ErrInvalidData)
+	}
+
+	return normalized, nil
+}
+
+// marshal encodes the given value as JSON without escaping HTML characters.
+func marshal(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimRight(buf.Bytes(), "\n"), nil
+}
@@ -0,0 +1,317 @@
+// Copyright 2024 Adam Chalkley
+//
+// https://github.com/atc0005/go-teams-notify
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package template
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/flashcatcloud/go-teams-notify/v2/adaptivecard"
+	"github.com/stretchr/testify/assert"
+)
+
+const alertTemplate = `{
+	"type": "AdaptiveCard",
+	"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+	"version": "1.4",
+	"body": [
+		{
+			"type": "TextBlock",
+			"text": "${title}",
+			"size": "large",
+			"weight": "bolder",
+			"color": "${if(severity == 'critical', 'attention', 'warning')}"
+		},
+		{
+			"type": "TextBlock",
+			"text": "Resolved after ${duration} minutes",
+			"$when": "${resolved}"
+		},
+		{
+			"type": "FactSet",
+			"facts": [
+				{
+					"$data": "${labels}",
+					"title": "${$index + 1}. ${name}",
+					"value": "${toUpper(value)} (${$root.host})"
+				}
+			]
+		}
+	]
+}`
+
+type alert struct {
+	Title    string  `json:"title"`
+	Severity string  `json:"severity"`
+	Resolved bool    `json:"resolved"`
+	Duration int     `json:"duration"`
+	Host     string  `json:"host"`
+	Labels   []label `json:"labels"`
+}
+
+type label struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+func TestExpandCard(t *testing.T) {
+	tmpl, err := New([]byte(alertTemplate))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	data := alert{
+		Title:    "Disk usage high",
+		Severity: "critical",
+		Host:     "db01",
+		Labels: []label{
+			{Name: "mount", Value: "/var"},
+			{Name: "usage", Value: "97%"},
+		},
+	}
+
+	card, err := tmpl.ExpandCard(data)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// The "resolved" TextBlock is dropped by $when.
+	if !assert.Len(t, card.Body, 2) {
+		return
+	}
+
+	assert.Equal(t, "Disk usage high", card.Body[0].Text)
+	assert.Equal(t, adaptivecard.ColorAttention, card.Body[0].Color)
+
+	if assert.Len(t, card.Body[1].Facts, 2) {
+		assert.Equal(t, "1. mount", card.Body[1].Facts[0].Title)
+		assert.Equal(t, "/VAR (db01)", card.Body[1].Facts[0].Value)
+		assert.Equal(t, "2. usage", card.Body[1].Facts[1].Title)
+	}
+
+	data.Resolved = true
+	data.Duration = 42
+	data.Severity = "minor"
+
+	card, err = tmpl.ExpandCard(data)
+	if !assert.NoError(t, err) || !assert.Len(t, card.Body, 3) {
+		return
+	}
+
+	assert.Equal(t, adaptivecard.ColorWarning, card.Body[0].Color)
+	assert.Equal(t, "Resolved after 42 minutes", card.Body[1].Text)
+}
+
+func TestExpandCardValidation(t *testing.T) {
+	const tmpl = `{
+		"type": "AdaptiveCard",
+		"version": "1.4",
+		"body": [{"type": "TextBlock", "text": "${text}", "color": "${color}"}]
+	}`
+
+	_, err := Expand([]byte(tmpl), map[string]string{"text": "hi", "color": "pink"})
+	assert.True(t, errors.Is(err, adaptivecard.ErrInvalidFieldValue), err)
+
+	_, err = Expand([]byte(tmpl), map[string]string{"text": "hi", "color": "good"})
+	assert.NoError(t, err)
+}
+
+func TestExpandDataScope(t *testing.T) {
+	const tmpl = `{
+		"$data": "${owner}",
+		"name": "${name}",
+		"title": "${$root.title}",
+		"items": [
+			{"$data": [1, 2, 3], "n": "${$data * 10}", "i": "${$index}"}
+		],
+		"nested": {"$data": {"x": "y"}, "value": "${x}"}
+	}`
+
+	tm, err := New([]byte(tmpl))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	out, err := tm.Expand([]byte(`{"title": "T", "owner": {"name": "ops"}}`))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.JSONEq(t, `{
+		"name": "ops",
+		"title": "T",
+		"items": [{"n": 10, "i": 0}, {"n": 20, "i": 1}, {"n": 30, "i": 2}],
+		"nested": {"value": "y"}
+	}`, string(out))
+}
+
+func TestExpandTypedBinding(t *testing.T) {
+	tm, err := New([]byte(`{"n": "${count}", "b": "${ok}", "l": "${list}", "missing": "${nope}", "mixed": "[${nope}]"}`))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	out, err := tm.Expand(map[string]interface{}{
+		"count": 3,
+		"ok":    true,
+		"list":  []string{"a", "b"},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.JSONEq(t, `{"n": 3, "b": true, "l": ["a", "b"], "missing": null, "mixed": "[]"}`, string(out))
+}
+
+func TestNewInvalidTemplate(t *testing.T) {
+	tests := map[string]string{
+		"invalid JSON":          `{"type": `,
+		"unterminated binding":  `{"text": "${name"}`,
+		"invalid expression":    `{"text": "${name +}"}`,
+		"unterminated string":   `{"text": "${'abc}"}`,
+		"unexpected character":  `{"text": "${a # b}"}`,
+		"missing call argument": `{"text": "${concat(a,)}"}`,
+	}
+
+	for name, tmpl := range tests {
+		_, err := New([]byte(tmpl))
+		assert.True(t, errors.Is(err, ErrInvalidTemplate), "%s: %v", name, err)
+	}
+}
+
+func TestExpandErrors(t *testing.T) {
+	tests := map[string]struct {
+		expr string
+		err  error
+	}{
+		"unknown function": {expr: "nope(1)", err: ErrUnknownFunction},
+		"argument count":   {expr: "toUpper('a', 'b')", err: ErrInvalidArguments},
+		"division by zero": {expr: "div(1, 0)", err: ErrInvalidArguments},
+		"invalid operand":  {expr: "1 - 'a'", err: ErrInvalidExpression},
+		"invalid compare":  {expr: "1 < 'a'", err: ErrInvalidExpression},
+	}
+
+	for name, tt := range tests {
+		tm, err := New([]byte(`{"v": "${` + tt.expr + `}"}`))
+		if !assert.NoError(t, err, name) {
+			continue
+		}
+
+		_, err = tm.Expand(nil)
+		assert.True(t, errors.Is(err, tt.err), "%s: %v", name, err)
+	}
+
+	tm, err := New([]byte(`{"v": 1}`))
+	if assert.NoError(t, err) {
+		_, err = tm.Expand(func() {})
+		assert.True(t, errors.Is(err, ErrInvalidData), err)
+	}
+}
+
+func TestFunctions(t *testing.T) {
+	nowFunc = func() time.Time {
+		return time.Date(2024, time.March, 5, 14, 7, 9, 0, time.UTC)
+	}
+	defer func() { nowFunc = time.Now }()
+
+	data := map[string]interface{}{
+		"name":  "Disk Usage",
+		"nums":  []int{4, 1, 7},
+		"items": []map[string]interface{}{{"n": "a", "v": 1}, {"n": "b", "v": 5}},
+		"ts":    "2024-01-31T22:30:00Z",
+		"empty": "",
+	}
+
+	tests := map[string]interface{}{
+		"concat('a', 1, true)":                                       "a1true",
+		"length(name)":                                               float64(10),
+		"toLower(name)":                                              "disk usage",
+		"trim('  x ')":                                               "x",
+		"substring(name, 5)":                                         "Usage",
+		"substring(name, 0, 4)":                                      "Disk",
+		"replace(name, ' ', '_')":                                    "Disk_Usage",
+		"join(split('a,b,c', ','), ' | ')":                           "a | b | c",
+		"join(nums, ', ', ' and ')":                                  "4, 1 and 7",
+		"startsWith(name, 'Disk')":                                   true,
+		"endsWith(name, 'x')":                                        false,
+		"contains(nums, 7)":                                          true,
+		"indexOf(name, 'U')":                                         float64(5),
+		"if(empty(empty), 'none', 'some')":                           "none",
+		"and(true, 1, 'x')":                                          true,
+		"or(false, null)":                                            false,
+		"not(exists(missing))":                                       true,
+		"equals(1, 1.0)":                                             true,
+		"coalesce(missing, empty, 'x')":                              "",
+		"add(1, 2) * sub(5, 3) ^ 2":                                  float64(12),
+		"mul(2, 3) + div(9, 2) + mod(7, 4)":                          float64(13.5),
+		"min(nums) + max(3, 9)":                                      float64(10),
+		"round(2.345, 2)":                                            2.35,
+		"floor(2.7) + ceiling(2.1) + abs(-1)":                        float64(6),
+		"sum(nums) / count(nums) == average(nums)":                   true,
+		"int('42.9')":                                                float64(42),
+		"float('1.5')":                                               1.5,
+		"string(1.5) + string(true)":                                 "1.5true",
+		"bool('false') || bool(0)":                                   false,
+		"json('{\"a\": [1]}').a[0]":                                  float64(1),
+		"first(nums) + last(nums)":                                   float64(11),
+		"take(nums, 2)":                                              []interface{}{float64(4), float64(1)},
+		"skip(nums, 5)":                                              []interface{}{},
+		"reverse('abc')":                                             "cba",
+		"count(where(items, i, i.v > 2))":                            float64(1),
+		"join(select(items, i, i.n), '')":                            "ab",
+		"join(foreach(nums, x, x * 2), ',')":                         "8,2,14",
+		"any(items, i, i.n == 'b') && all(nums, x, x > 0)":           true,
+		"utcNow('yyyy-MM-dd HH:mm')":                                 "2024-03-05 14:07",
+		"utcNow()":                                                   "2024-03-05T14:07:09.000Z",
+		"formatDateTime(ts, 'dddd, MMMM d, yyyy h:mm tt')":           "Wednesday, January 31, 2024 10:30 PM",
+		"formatDateTime(addDays(ts, 1), 'MMM dd')":                   "Feb 01",
+		"addHours(ts, 2, \"yyyy-MM-dd'T'HH\")":                       "2024-02-01T00",
+		"formatEpoch(0, 'yyyy-MM-dd')":                               "1970-01-01",
+		"formatNumber(1234567.891, 2) + formatNumber(-1000)":         "1,234,567.89-1,000",
+		"name.length > 3 && !(nums[1] != 1) && items[0]['n'] == 'a'": true,
+		"'a' + 1 + \"b\"":                                            "a1b",
+		"-nums[0] % 3":                                               float64(-1),
+	}
+
+	for expr, want := range tests {
+		tm, err := New([]byte(`{"v": "${` + escapeJSON(expr) + `}"}`))
+		if !assert.NoError(t, err, expr) {
+			continue
+		}
+
+		out, err := tm.Expand(data)
+		if !assert.NoError(t, err, expr) {
+			continue
+		}
+
+		wantJSON, err := marshal(map[string]interface{}{"v": want})
+		if assert.NoError(t, err) {
+			assert.JSONEq(t, string(wantJSON), string(out), expr)
+		}
+	}
+}
+
+func TestDotNetLayout(t *testing.T) {
+	tests := map[string]string{
+		"yyyy-MM-dd":          "2006-01-02",
+		"HH:mm:ss":            "15:04:05",
+		"H:mm":                "15:04",
+		"'Day' d 'of' MMMM":   "Day 2 of January",
+		DefaultDateTimeFormat: "2006-01-02T15:04:05.000Z",
+	}
+
+	for format, want := range tests {
+		assert.Equal(t, want, dotNetLayout(format), format)
+	}
+}
+
+func escapeJSON(s string) string {
+	b, _ := marshal(s)
+	return string(b[1 : len(b)-1])
+}
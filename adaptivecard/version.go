@@ -0,0 +1,347 @@
+// Copyright 2024 Adam Chalkley
+//
+// https://github.com/atc0005/go-teams-notify
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package adaptivecard
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// versionRequirement records the minimum Adaptive Card schema version
+// required by an element, field or action used within a Card.
+type versionRequirement struct {
+	// feature describes the element, field or action (e.g., "element type
+	// Table").
+	feature string
+
+	// version is the minimum schema version required by the feature.
+	version float64
+
+	// baseErr is the sentinel error reported if the requirement is not met.
+	baseErr error
+}
+
+// versionRequirements is a collection of versionRequirement values.
+type versionRequirements []versionRequirement
+
+// fieldVersion records the schema version in which a field was introduced and
+// whether the field is set.
+type fieldVersion struct {
+	set     bool
+	name    string
+	version float64
+}
+
+// MinimumVersion returns the minimum Adaptive Card schema version required by
+// the elements, fields and actions used within this Card (including those
+// within nested Action.ShowCard Cards). Elements and actions which specify a
+// fallback do not raise the minimum version; the version required by the
+// fallback is used instead.
+func (c Card) MinimumVersion() float64 {
+	minVersion := AdaptiveCardMinVersion
+
+	for _, req := range cardVersionRequirements(c) {
+		if req.version > minVersion {
+			minVersion = req.version
+		}
+	}
+
+	return minVersion
+}
+
+// SetMinimumVersion sets the Card Version to the minimum Adaptive Card schema
+// version required by the elements, fields and actions used within this
+// Card. This allows the Card to be rendered by the widest range of clients.
+//
+// See also MinimumVersion.
+func (c *Card) SetMinimumVersion() {
+	c.Version = fmt.Sprintf(AdaptiveCardVersionTmpl, c.MinimumVersion())
+}
+
+// assertCardSupportedByVersion asserts that the elements, fields and actions
+// used within the given Card are supported by the given Adaptive Card schema
+// version.
+func assertCardSupportedByVersion(c Card, version string) error {
+	versionNum, err := strconv.ParseFloat(version, 64)
+	if err != nil {
+		// Version field validation is handled separately.
+		return nil
+	}
+
+	for _, req := range cardVersionRequirements(c) {
+		if req.version > versionNum {
+			return fmt.Errorf(
+				"%s requires Card Version %0.1f or later; Card Version is %s: %w",
+				req.feature,
+				req.version,
+				version,
+				req.baseErr,
+			)
+		}
+	}
+
+	return nil
+}
+
+// cardVersionRequirements returns the schema version requirements for the
+// given Card.
+func cardVersionRequirements(c Card) versionRequirements {
+	var reqs versionRequirements
+	reqs.addCard(c, 0)
+
+	return reqs
+}
+
+// add records a requirement for the given feature if the given version is
+// later than the minimum supported schema version.
+func (r *versionRequirements) add(version float64, baseErr error, feature string) {
+	if version <= AdaptiveCardMinVersion {
+		return
+	}
+
+	*r = append(*r, versionRequirement{
+		feature: feature,
+		version: version,
+		baseErr: baseErr,
+	})
+}
+
+// addFields records a requirement for each set field.
+func (r *versionRequirements) addFields(owner string, fields []fieldVersion) {
+	for _, field := range fields {
+		if field.set {
+			r.add(
+				field.version,
+				ErrInvalidFieldValue,
+				fmt.Sprintf("field %s for %s", field.name, owner),
+			)
+		}
+	}
+}
+
+// addCard records requirements for the given Card, its elements and actions.
+// The depth of nested Action.ShowCard Cards is tracked to guard against Card
+// values which (directly or indirectly) reference themselves.
+func (r *versionRequirements) addCard(c Card, depth int) {
+	r.addFields("card", []fieldVersion{
+		{c.VerticalContentAlignment != "", "VerticalContentAlignment", 1.1},
+		{c.MinHeight != "", "MinHeight", 1.2},
+		{c.Requires != nil, "Requires", 1.2},
+		{c.BackgroundImage != nil && (c.BackgroundImage.FillMode != "" ||
+			c.BackgroundImage.HorizontalAlignment != "" ||
+			c.BackgroundImage.VerticalAlignment != ""), "BackgroundImage", 1.2},
+		{c.Refresh != nil, "Refresh", UniversalActionsMinCardVersionRequired},
+		{c.Authentication != nil, "Authentication", UniversalActionsMinCardVersionRequired},
+		{c.RTL != nil, "RTL", 1.5},
+	})
+
+	if c.Refresh != nil {
+		r.addAction(c.Refresh.Action, depth)
+	}
+
+	r.addElements(c.Body, depth, 0)
+
+	for _, action := range c.Actions {
+		r.addAction(action, depth)
+	}
+}
+
+// addElements records requirements for the given elements and their child
+// elements.
+func (r *versionRequirements) addElements(elements []Element, depth int, fallbackDepth int) {
+	for _, element := range elements {
+		r.addElement(element, depth, fallbackDepth)
+	}
+}
+
+// addElementPointers is a helper function for addElements which accepts a
+// collection of Element pointers.
+func (r *versionRequirements) addElementPointers(elements []*Element, depth int, fallbackDepth int) {
+	for _, element := range elements {
+		if element != nil {
+			r.addElement(*element, depth, fallbackDepth)
+		}
+	}
+}
+
+// addElement records requirements for the given element and its child
+// elements. Child elements of an element with a fallback are not evaluated
+// as unsupported child elements cause the nearest fallback to be used; the
+// fallback element is evaluated instead. The depth of fallback elements is
+// tracked to guard against fallback values which (directly or indirectly)
+// reference themselves.
+func (r *versionRequirements) addElement(e Element, depth int, fallbackDepth int) {
+	if e.Fallback != nil {
+		r.addFields(e.Type, []fieldVersion{{true, "Fallback", 1.2}})
+
+		fallback, _, err := elementFallback(e.Fallback)
+		if err == nil && fallback != nil && fallbackDepth < FallbackMaxNestingDepth {
+			r.addElement(*fallback, depth, fallbackDepth+1)
+		}
+
+		return
+	}
+
+	r.add(
+		elementMinCardVersion(e.Type),
+		ErrMissingValue,
+		fmt.Sprintf("element type %s (without Fallback)", e.Type),
+	)
+
+	r.addFields(e.Type, elementFieldVersions(e))
+
+	if e.SelectAction != nil {
+		r.addSelectAction(*e.SelectAction)
+	}
+
+	for _, action := range e.Actions {
+		r.addAction(action, depth)
+	}
+
+	r.addElements(e.Items, depth, fallbackDepth)
+	r.addElements(e.Inlines, depth, fallbackDepth)
+	r.addElements(e.Images, depth, fallbackDepth)
+
+	for _, column := range e.Columns {
+		r.addColumn(column, depth, fallbackDepth)
+	}
+
+	for _, row := range e.Rows {
+		for _, cell := range row.Cells {
+			r.addElementPointers(cell.Items, depth, fallbackDepth)
+		}
+	}
+}
+
+// addColumn records requirements for the given Column and its child
+// elements. Child elements of a Column with a fallback are not evaluated;
+// the fallback Column is evaluated instead.
+func (r *versionRequirements) addColumn(c Column, depth int, fallbackDepth int) {
+	owner := c.Type
+	if owner == "" {
+		owner = TypeColumn
+	}
+
+	if c.Fallback != nil {
+		r.addFields(owner, []fieldVersion{{true, "Fallback", 1.2}})
+
+		fallback, _, err := columnFallback(c.Fallback)
+		if err == nil && fallback != nil && fallbackDepth < FallbackMaxNestingDepth {
+			r.addColumn(*fallback, depth, fallbackDepth+1)
+		}
+
+		return
+	}
+
+	r.addFields(owner, []fieldVersion{
+		{c.SelectAction != nil, "SelectAction", 1.1},
+		{c.VerticalContentAlignment != "", "VerticalContentAlignment", 1.1},
+		{c.Visible != nil, "Visible", 1.2},
+		{c.Bleed, "Bleed", 1.2},
+		{c.MinHeight != "", "MinHeight", 1.2},
+		{c.BackgroundImage != nil, "BackgroundImage", 1.2},
+		{c.RTL != nil, "RTL", 1.5},
+	})
+
+	if c.SelectAction != nil {
+		r.addSelectAction(*c.SelectAction)
+	}
+
+	r.addElementPointers(c.Items, depth, fallbackDepth)
+}
+
+// addAction records requirements for the given Action, including those of a
+// nested Action.ShowCard Card. Only the fallback action type of an Action
+// with a fallback is evaluated.
+func (r *versionRequirements) addAction(a Action, depth int) {
+	if a.Fallback != "" {
+		r.addFields(a.Type, []fieldVersion{{true, "Fallback", 1.2}})
+		r.add(
+			actionMinCardVersion(a.Fallback),
+			ErrInvalidFieldValue,
+			fmt.Sprintf("Fallback action type %s for %s", a.Fallback, a.Type),
+		)
+
+		return
+	}
+
+	r.add(
+		actionMinCardVersion(a.Type),
+		ErrInvalidType,
+		fmt.Sprintf("action type %s (without Fallback)", a.Type),
+	)
+
+	r.addFields(a.Type, []fieldVersion{
+		{a.Style != "", "Style", 1.2},
+		{a.AssociatedInputs != "", "AssociatedInputs", 1.3},
+		{a.Tooltip != "", "Tooltip", 1.5},
+		{a.IsEnabled != nil, "IsEnabled", 1.5},
+		{a.Mode != "", "Mode", 1.5},
+	})
+
+	if a.Type == TypeActionShowCard && a.Card != nil && depth < ActionShowCardMaxNestingDepth {
+		r.addCard(*a.Card, depth+1)
+	}
+}
+
+// addSelectAction records requirements for the given ISelectAction. Only the
+// fallback action type of an ISelectAction with a fallback is evaluated.
+func (r *versionRequirements) addSelectAction(i ISelectAction) {
+	if i.Fallback != "" {
+		r.addFields(i.Type, []fieldVersion{{true, "Fallback", 1.2}})
+		r.add(
+			actionMinCardVersion(i.Fallback),
+			ErrInvalidFieldValue,
+			fmt.Sprintf("Fallback action type %s for %s", i.Fallback, i.Type),
+		)
+
+		return
+	}
+
+	r.add(
+		actionMinCardVersion(i.Type),
+		ErrInvalidType,
+		fmt.Sprintf("select action type %s (without Fallback)", i.Type),
+	)
+
+	r.addFields(i.Type, []fieldVersion{
+		{i.AssociatedInputs != "", "AssociatedInputs", 1.3},
+		{i.Tooltip != "", "Tooltip", 1.5},
+	})
+}
+
+// elementFieldVersions returns the schema version in which each version
+// specific field of the given element was introduced.
+//
+// https://adaptivecards.io/explorer/
+func elementFieldVersions(e Element) []fieldVersion {
+	return []fieldVersion{
+		{e.SelectAction != nil && e.Type != TypeElementTextRun, "SelectAction", 1.1},
+		{e.Height != "", "Height", 1.1},
+		{e.Width != "", "Width", 1.1},
+		{e.BackgroundColor != "", "BackgroundColor", 1.1},
+		{e.VerticalContentAlignment != "", "VerticalContentAlignment", 1.1},
+		{e.Visible != nil, "Visible", 1.2},
+		{e.Requires != nil, "Requires", 1.2},
+		{e.FontType != "" && e.Type == TypeElementTextBlock, "FontType", 1.2},
+		{e.Bleed, "Bleed", 1.2},
+		{e.MinHeight != "", "MinHeight", 1.2},
+		{e.BackgroundImage != nil, "BackgroundImage", 1.2},
+		{e.Style != "" && e.Type == TypeElementColumnSet, "Style", 1.2},
+		{e.Wrap && e.Type == TypeElementInputChoiceSet, "Wrap", 1.2},
+		{e.Label != "", "Label", 1.3},
+		{e.IsRequired, "IsRequired", 1.3},
+		{e.ErrorMessage != "", "ErrorMessage", 1.3},
+		{e.Regex != "", "Regex", 1.3},
+		{e.RTL != nil, "RTL", 1.5},
+		{e.Style == TextBlockStyleHeading && e.Type == TypeElementTextBlock, "Style", 1.5},
+		{e.Style == TextInputStylePassword && e.Type == TypeElementInputText, "Style", 1.5},
+		{e.Style == ChoiceInputStyleFiltered && e.Type == TypeElementInputChoiceSet, "Style", 1.5},
+		{e.CaptionSources != nil, "CaptionSources", MediaCaptionSourcesMinCardVersionRequired},
+	}
+}
@@ -0,0 +1,219 @@
+// Copyright 2024 Adam Chalkley
+//
+// https://github.com/atc0005/go-teams-notify
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package adaptivecard
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCardMinimumVersion(t *testing.T) {
+	execute, err := NewActionExecute("Approve", "approve", nil)
+	mustNoError(t, err)
+
+	withFallback := execute
+	withFallback.Fallback = TypeFallbackOptionDrop
+
+	media, err := NewMedia("Demo", NewMediaSource("video/mp4", "https://example.com/demo.mp4"))
+	mustNoError(t, err)
+
+	captioned := media
+	captioned.CaptionSources = []CaptionSource{{MIMEType: "vtt", URL: "https://example.com/en.vtt", Label: "English"}}
+
+	table := Element{Type: TypeElementTable}
+	tableWithFallback := table
+	tableWithFallback.SetFallbackDrop()
+
+	mediaWithTableFallback := media
+	mediaWithTableFallback.Fallback = &table
+
+	rtl := true
+	columnWithFallback := NewColumn()
+	columnWithFallback.Items = []*Element{&media}
+	columnWithFallback.Fallback = Column{Type: TypeColumn, RTL: &rtl}
+
+	nested := NewCard()
+	nested.Body = []Element{table}
+
+	tests := map[string]struct {
+		body    []Element
+		actions []Action
+		want    float64
+	}{
+		"text only": {
+			body: []Element{NewTextBlock("text", false)},
+			want: AdaptiveCardMinVersion,
+		},
+		"media": {
+			body: []Element{media},
+			want: 1.1,
+		},
+		"media with captions": {
+			body: []Element{captioned},
+			want: MediaCaptionSourcesMinCardVersionRequired,
+		},
+		"table": {
+			body: []Element{table},
+			want: 1.5,
+		},
+		"table with fallback": {
+			body: []Element{tableWithFallback},
+			want: 1.2,
+		},
+		"media with table fallback": {
+			body: []Element{mediaWithTableFallback},
+			want: 1.5,
+		},
+		"column with newer fallback column": {
+			body: []Element{{Type: TypeElementColumnSet, Columns: []Column{columnWithFallback}}},
+			want: 1.5,
+		},
+		"execute action": {
+			actions: []Action{execute},
+			want:    ActionExecuteMinCardVersionRequired,
+		},
+		"execute action with fallback": {
+			actions: []Action{withFallback},
+			want:    1.2,
+		},
+		"execute fallback action": {
+			actions: []Action{{Type: TypeActionOpenURL, URL: "https://example.com", Fallback: TypeFallbackActionExecute}},
+			want:    ActionExecuteMinCardVersionRequired,
+		},
+		"execute action within action set": {
+			body: []Element{{Type: TypeElementActionSet, Actions: []Action{execute}}},
+			want: ActionExecuteMinCardVersionRequired,
+		},
+		"nested show card": {
+			actions: []Action{{Type: TypeActionShowCard, Card: &nested}},
+			want:    1.5,
+		},
+	}
+
+	for name, tt := range tests {
+		name, tt := name, tt
+
+		t.Run(name, func(t *testing.T) {
+			card := NewCard()
+			card.Body = tt.body
+			card.Actions = tt.actions
+
+			assert.Equal(t, tt.want, card.MinimumVersion())
+
+			card.SetMinimumVersion()
+			assert.NoError(t, TopLevelCard{card}.Validate())
+		})
+	}
+}
+
+func TestCardVersionRequirements(t *testing.T) {
+	execute, err := NewActionExecute("Approve", "approve", nil)
+	mustNoError(t, err)
+
+	tests := map[string]struct {
+		card    func(c *Card)
+		wantErr error
+	}{
+		"execute action": {
+			card:    func(c *Card) { c.Actions = []Action{execute} },
+			wantErr: ErrInvalidType,
+		},
+		"execute select action": {
+			card: func(c *Card) {
+				column := NewColumn()
+				column.SelectAction = &ISelectAction{Type: TypeActionExecute}
+				c.Body = []Element{{Type: TypeElementColumnSet, Columns: []Column{column}}}
+			},
+			wantErr: ErrInvalidType,
+		},
+		"execute fallback action": {
+			card: func(c *Card) {
+				c.Actions = []Action{{Type: TypeActionOpenURL, URL: "https://example.com", Fallback: TypeFallbackActionExecute}}
+			},
+			wantErr: ErrInvalidFieldValue,
+		},
+		"table fallback": {
+			card: func(c *Card) {
+				media, err := NewMedia("Demo", NewMediaSource("video/mp4", "https://example.com/demo.mp4"))
+				mustNoError(t, err)
+
+				media.Fallback = Element{Type: TypeElementTable}
+				c.Body = []Element{media}
+			},
+			wantErr: ErrMissingValue,
+		},
+		"column fallback field": {
+			card: func(c *Card) {
+				rtl := true
+				column := NewColumn()
+				column.Fallback = &Column{Type: TypeColumn, RTL: &rtl}
+				c.Body = []Element{{Type: TypeElementColumnSet, Columns: []Column{column}}}
+			},
+			wantErr: ErrInvalidFieldValue,
+		},
+		"execute fallback select action": {
+			card: func(c *Card) {
+				c.Body = []Element{{
+					Type:         TypeElementContainer,
+					Items:        []Element{NewTextBlock("text", false)},
+					SelectAction: &ISelectAction{Type: TypeActionOpenURL, URL: "https://example.com", Fallback: TypeFallbackActionExecute},
+				}}
+			},
+			wantErr: ErrInvalidFieldValue,
+		},
+		"table": {
+			card:    func(c *Card) { c.Body = []Element{{Type: TypeElementTable}} },
+			wantErr: ErrMissingValue,
+		},
+		"open url action": {
+			card: func(c *Card) {
+				c.Actions = []Action{{Type: TypeActionOpenURL, URL: "https://example.com"}}
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		name, tt := name, tt
+
+		t.Run(name, func(t *testing.T) {
+			card := NewCard()
+			card.Version = "1.2"
+			card.Body = []Element{NewTextBlock("text", false)}
+			tt.card(&card)
+
+			err := TopLevelCard{card}.Validate()
+
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.True(t, errors.Is(err, tt.wantErr), "got error: %v", err)
+		})
+	}
+}
+
+func TestCardMinimumVersionFallbackCycle(t *testing.T) {
+	element := Element{Type: TypeElementTable}
+	element.Fallback = &element
+
+	column := NewColumn()
+	column.Fallback = &column
+
+	card := NewCard()
+	card.Body = []Element{
+		element,
+		{Type: TypeElementColumnSet, Columns: []Column{column}},
+	}
+
+	// Each fallback in the chain specifies a fallback of its own, so only the
+	// Fallback field itself raises the minimum version.
+	assert.Equal(t, 1.2, card.MinimumVersion())
+}
@@ -0,0 +1,158 @@
+// Copyright 2024 Adam Chalkley
+//
+// https://github.com/atc0005/go-teams-notify
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+// Command teamsfix rewrites Go source files which use the deprecated
+// MessageCard and API types from the goteamsnotify package to use the
+// replacements provided by the messagecard package and the TeamsClient type.
+//
+// Usage:
+//
+//	teamsfix [-w] [-l] path ...
+//
+// Each path may be a Go source file or a directory, which is processed
+// recursively (vendor and testdata directories are skipped). By default
+// rewritten files are written to standard output. Call sites which cannot be
+// rewritten mechanically (or whose semantics changed) are reported on
+// standard error for manual review.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	write := flag.Bool("w", false, "write result to (source) file instead of stdout")
+	list := flag.Bool("l", false, "list files whose content would be rewritten")
+
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: teamsfix [-w] [-l] path ...\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var failed bool
+	for _, root := range flag.Args() {
+		err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if info.IsDir() {
+				switch info.Name() {
+				case "vendor", "testdata":
+					if path != root {
+						return filepath.SkipDir
+					}
+				}
+				return nil
+			}
+
+			if !strings.HasSuffix(path, ".go") {
+				return nil
+			}
+
+			return processFile(path, *write, *list)
+		})
+
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "teamsfix: %v\n", err)
+			failed = true
+		}
+	}
+
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// processFile rewrites the given file, reporting the results as requested.
+func processFile(path string, write bool, list bool) error {
+	src, err := ioutil.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return err
+	}
+
+	out, result, err := rewriteSource(path, src)
+	if err != nil {
+		return err
+	}
+
+	for _, n := range result.Notes {
+		fmt.Fprintf(os.Stderr, "%s\n", n)
+	}
+
+	switch {
+	case !result.Changed:
+		if !write && !list {
+			_, err = os.Stdout.Write(src)
+		}
+		return err
+
+	case list:
+		fmt.Println(path)
+	}
+
+	if write {
+		info, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+
+		return ioutil.WriteFile(path, out, info.Mode().Perm())
+	}
+
+	if !list {
+		_, err = os.Stdout.Write(out)
+	}
+
+	return err
+}
+
+// rewriteSource parses, rewrites and formats the given Go source.
+func rewriteSource(filename string, src []byte) ([]byte, rewriteResult, error) {
+	fset := token.NewFileSet()
+
+	file, err := parser.ParseFile(fset, filename, src, parser.ParseComments)
+	if err != nil {
+		return nil, rewriteResult{}, err
+	}
+
+	result := rewriteFile(fset, file)
+	if !result.Changed {
+		return src, result, nil
+	}
+
+	ast.SortImports(fset, file)
+
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, file); err != nil {
+		return nil, rewriteResult{}, fmt.Errorf("failed to format %s: %w", filename, err)
+	}
+
+	// Reformat to normalize any spacing left behind by removed imports.
+	out, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, rewriteResult{}, fmt.Errorf("failed to format %s: %w", filename, err)
+	}
+
+	return out, result, nil
+}
@@ -0,0 +1,560 @@
+// Copyright 2024 Adam Chalkley
+//
+// https://github.com/atc0005/go-teams-notify
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// rootImportPaths are the import paths of the goteamsnotify package whose
+// deprecated identifiers are rewritten. The upstream path is included so that
+// projects which have not yet switched to this fork are also handled.
+var rootImportPaths = []string{
+	"github.com/flashcatcloud/go-teams-notify/v2",
+	"github.com/atc0005/go-teams-notify/v2",
+}
+
+// messageCardPkgName is the package name of the messagecard package.
+const messageCardPkgName string = "messagecard"
+
+// movedIdents maps deprecated identifiers in the goteamsnotify package to
+// their replacements in the messagecard package.
+var movedIdents = map[string]string{
+	// Types
+	"MessageCard":                                               "MessageCard",
+	"MessageCardSection":                                        "Section",
+	"MessageCardSectionFact":                                    "SectionFact",
+	"MessageCardSectionImage":                                   "SectionImage",
+	"MessageCardPotentialAction":                                "PotentialAction",
+	"MessageCardPotentialActionOpenURI":                         "PotentialActionOpenURI",
+	"MessageCardPotentialActionHTTPPOST":                        "PotentialActionHTTPPOST",
+	"MessageCardPotentialActionActionCard":                      "PotentialActionActionCard",
+	"MessageCardPotentialActionActionCardAction":                "PotentialActionActionCardAction",
+	"MessageCardPotentialActionInvokeAddInCommand":              "PotentialActionInvokeAddInCommand",
+	"MessageCardPotentialActionOpenURITarget":                   "PotentialActionOpenURITarget",
+	"MessageCardPotentialActionHTTPPOSTHeader":                  "PotentialActionHTTPPOSTHeader",
+	"MessageCardPotentialActionActionCardInput":                 "PotentialActionActionCardInput",
+	"MessageCardPotentialActionActionCardInputTextInput":        "PotentialActionActionCardInputTextInput",
+	"MessageCardPotentialActionActionCardInputMultichoiceInput": "PotentialActionActionCardInputMultichoiceInput",
+	"MessageCardPotentialActionActionCardInputDateInput":        "PotentialActionActionCardInputDateInput",
+
+	// Constructors
+	"NewMessageCard":                  "NewMessageCard",
+	"NewMessageCardSection":           "NewSection",
+	"NewMessageCardSectionFact":       "NewSectionFact",
+	"NewMessageCardSectionImage":      "NewSectionImage",
+	"NewMessageCardPotentialAction":   "NewPotentialAction",
+	"TryToFormatAsCodeBlock":          "TryToFormatAsCodeBlock",
+	"TryToFormatAsCodeSnippet":        "TryToFormatAsCodeSnippet",
+	"FormatAsCodeBlock":               "FormatAsCodeBlock",
+	"FormatAsCodeSnippet":             "FormatAsCodeSnippet",
+	"ConvertEOLToBreak":               "ConvertEOLToBreak",
+	"ErrPotentialActionsLimitReached": "ErrPotentialActionsLimitReached",
+
+	// Constants
+	"PotentialActionOpenURIType":                     "PotentialActionOpenURIType",
+	"PotentialActionHTTPPostType":                    "PotentialActionHTTPPostType",
+	"PotentialActionActionCardType":                  "PotentialActionActionCardType",
+	"PotentialActionInvokeAddInCommandType":          "PotentialActionInvokeAddInCommandType",
+	"PotentialActionActionCardInputTextInputType":    "PotentialActionActionCardInputTextInputType",
+	"PotentialActionActionCardInputDateInputType":    "PotentialActionActionCardInputDateInputType",
+	"PotentialActionActionCardInputMultichoiceInput": "PotentialActionActionCardInputMultichoiceInputType",
+	"PotentialActionMaxSupported":                    "PotentialActionMaxSupported",
+}
+
+// renamedIdents maps deprecated identifiers in the goteamsnotify package to
+// their replacements within the same package.
+var renamedIdents = map[string]string{
+	"NewClient": "NewTeamsClient",
+}
+
+// reviewIdents maps deprecated identifiers in the goteamsnotify package which
+// cannot be rewritten mechanically to a note describing the manual change
+// needed.
+var reviewIdents = map[string]string{
+	"API":                "replace with *goteamsnotify.TeamsClient or goteamsnotify.Sender",
+	"IsValidInput":       "replace with TeamsClient.ValidateWebhook and MessageCard.Validate",
+	"IsValidWebhookURL":  "replace with TeamsClient.ValidateWebhook",
+	"IsValidMessageCard": "replace with MessageCard.Validate",
+}
+
+// embeddingTypes maps the MessageCard types which embed other MessageCard
+// types (and so have fields whose names change when moved) to the type
+// returned by their constructor. Both the deprecated names in the
+// goteamsnotify package and the new names in the messagecard package are
+// listed as types may be rewritten before the fields using them are visited.
+var embeddingTypes = map[string]bool{
+	"MessageCardPotentialAction":                true,
+	"MessageCardPotentialActionActionCardInput": true,
+	"PotentialAction":                           true,
+	"PotentialActionActionCardInput":            true,
+}
+
+// constructorTypes maps constructors to the name of the type they return a
+// pointer to. Only constructors returning an embeddingTypes type are listed.
+var constructorTypes = map[string]string{
+	"NewMessageCardPotentialAction": "MessageCardPotentialAction",
+	"NewPotentialAction":            "PotentialAction",
+}
+
+// constructorNotes maps moved constructors whose return type changed to a
+// note describing the change.
+var constructorNotes = map[string]string{
+	"NewMessageCard":             "now returns *messagecard.MessageCard; review uses of the result",
+	"NewMessageCardSectionFact":  "now returns *messagecard.SectionFact; review uses of the result",
+	"NewMessageCardSectionImage": "now returns *messagecard.SectionImage; review uses of the result",
+}
+
+// rewriteResult describes the changes applied to a file.
+type rewriteResult struct {
+	// Changed indicates whether the file was modified.
+	Changed bool
+
+	// Notes is a collection of call sites needing manual review.
+	Notes []string
+}
+
+// rewriteFile rewrites references to deprecated goteamsnotify identifiers in
+// the given file to their replacements, updating imports as needed.
+func rewriteFile(fset *token.FileSet, file *ast.File) rewriteResult {
+	var result rewriteResult
+
+	rootSpec, rootPath := findImport(file, rootImportPaths)
+	if rootSpec == nil {
+		return result
+	}
+
+	rootName := importName(rootSpec, "goteamsnotify")
+	mcName := messageCardPkgName
+	mcPath := rootPath + "/" + messageCardPkgName
+
+	if spec, _ := findImport(file, []string{mcPath}); spec != nil {
+		mcName = importName(spec, messageCardPkgName)
+	}
+
+	note := func(pos token.Pos, format string, args ...interface{}) {
+		result.Notes = append(
+			result.Notes,
+			fmt.Sprintf("%s: %s", fset.Position(pos), fmt.Sprintf(format, args...)),
+		)
+	}
+
+	pkgNames := []string{rootName, mcName}
+
+	// elidedTypes records the element type of composite literals whose type
+	// is elided within an enclosing slice, array or map literal.
+	elidedTypes := make(map[*ast.CompositeLit]ast.Expr)
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.SelectorExpr:
+			pkg, ok := node.X.(*ast.Ident)
+			if !ok || pkg.Name != rootName || pkg.Obj != nil {
+				// Rename embedded legacy field names used in selectors
+				// (e.g., action.MessageCardPotentialActionOpenURI.Targets),
+				// but only where the value is known to be one of the
+				// MessageCard types.
+				name := node.Sel.Name
+				newName, ok := movedIdents[name]
+				if !ok || newName == name || !strings.HasPrefix(name, "MessageCard") {
+					return true
+				}
+
+				t := typeExpr(node.X)
+				switch {
+				case t == nil:
+					note(node.Pos(), "unable to determine type of value for field %s; if it is a MessageCard type rename the field to %s",
+						name, newName)
+
+				case isEmbeddingType(t, pkgNames):
+					node.Sel.Name = newName
+					result.Changed = true
+				}
+
+				return true
+			}
+
+			name := node.Sel.Name
+			switch {
+			case movedIdents[name] != "":
+				if msg, ok := constructorNotes[name]; ok {
+					note(node.Pos(), "%s.%s %s", mcName, movedIdents[name], msg)
+				}
+				pkg.Name = mcName
+				node.Sel.Name = movedIdents[name]
+				result.Changed = true
+
+			case renamedIdents[name] != "":
+				note(node.Pos(), "%s.%s replaced with %s.%s; the returned client accepts any supported message type",
+					rootName, name, rootName, renamedIdents[name])
+				node.Sel.Name = renamedIdents[name]
+				result.Changed = true
+
+			case reviewIdents[name] != "":
+				note(node.Pos(), "%s.%s is deprecated: %s", rootName, name, reviewIdents[name])
+			}
+
+		// Rename embedded legacy field names used as keys in composite
+		// literals of the MessageCard types.
+		case *ast.CompositeLit:
+			t := node.Type
+			if t == nil {
+				t = elidedTypes[node]
+			}
+
+			recordElidedTypes(node, t, elidedTypes)
+
+			if !isEmbeddingType(t, pkgNames) {
+				return true
+			}
+
+			for _, elt := range node.Elts {
+				kv, ok := elt.(*ast.KeyValueExpr)
+				if !ok {
+					continue
+				}
+
+				key, ok := kv.Key.(*ast.Ident)
+				if !ok {
+					continue
+				}
+
+				if newName, ok := movedIdents[key.Name]; ok && newName != key.Name && strings.HasPrefix(key.Name, "MessageCard") {
+					key.Name = newName
+					result.Changed = true
+				}
+			}
+		}
+
+		return true
+	})
+
+	if !result.Changed {
+		return result
+	}
+
+	if usesPackage(file, mcName) {
+		addImport(file, mcName, mcPath)
+	}
+
+	if !usesPackage(file, rootName) {
+		removeImport(file, rootSpec)
+	}
+
+	sort.Strings(result.Notes)
+
+	return result
+}
+
+// recordElidedTypes records the element type of composite literals with an
+// elided type within the given slice, array or map composite literal of the
+// given type.
+func recordElidedTypes(lit *ast.CompositeLit, t ast.Expr, elidedTypes map[*ast.CompositeLit]ast.Expr) {
+	var elemType ast.Expr
+
+	switch t := t.(type) {
+	case *ast.ArrayType:
+		elemType = t.Elt
+	case *ast.MapType:
+		elemType = t.Value
+	default:
+		return
+	}
+
+	// Elements of a []*T literal may be written as {...} instead of &T{...}.
+	if star, ok := elemType.(*ast.StarExpr); ok {
+		elemType = star.X
+	}
+
+	for _, elt := range lit.Elts {
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			elt = kv.Value
+		}
+
+		if elem, ok := elt.(*ast.CompositeLit); ok && elem.Type == nil {
+			elidedTypes[elem] = elemType
+		}
+	}
+}
+
+// typeExpr returns the type expression for the given value expression where
+// it can be determined from the syntax of the file alone, otherwise nil.
+// Types are determined for variables and parameters declared with an
+// explicit type or assigned a composite literal or the result of a
+// MessageCard constructor, and for values indexed from or ranged over such
+// variables.
+func typeExpr(expr ast.Expr) ast.Expr {
+	switch e := expr.(type) {
+	case *ast.ParenExpr:
+		return typeExpr(e.X)
+
+	case *ast.StarExpr:
+		// Dereference of a pointer.
+		if t, ok := typeExpr(e.X).(*ast.StarExpr); ok {
+			return t.X
+		}
+
+	case *ast.UnaryExpr:
+		if e.Op == token.AND {
+			if t := typeExpr(e.X); t != nil {
+				return &ast.StarExpr{X: t}
+			}
+		}
+
+	case *ast.CompositeLit:
+		return e.Type
+
+	case *ast.IndexExpr:
+		t := typeExpr(e.X)
+		if star, ok := t.(*ast.StarExpr); ok {
+			t = star.X
+		}
+
+		switch t := t.(type) {
+		case *ast.ArrayType:
+			return t.Elt
+		case *ast.MapType:
+			return t.Value
+		}
+
+	case *ast.CallExpr:
+		sel, ok := e.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return nil
+		}
+
+		pkg, ok := sel.X.(*ast.Ident)
+		if !ok || pkg.Obj != nil {
+			return nil
+		}
+
+		if typeName, ok := constructorTypes[sel.Sel.Name]; ok {
+			return &ast.StarExpr{
+				X: &ast.SelectorExpr{X: ast.NewIdent(pkg.Name), Sel: ast.NewIdent(typeName)},
+			}
+		}
+
+	case *ast.Ident:
+		if e.Obj != nil {
+			return declTypeExpr(e)
+		}
+	}
+
+	return nil
+}
+
+// declTypeExpr returns the type expression for the variable or parameter
+// referred to by the given identifier, or nil if it cannot be determined.
+func declTypeExpr(ident *ast.Ident) ast.Expr {
+	switch decl := ident.Obj.Decl.(type) {
+	case *ast.Field:
+		return decl.Type
+
+	case *ast.ValueSpec:
+		if decl.Type != nil {
+			return decl.Type
+		}
+
+		for i, name := range decl.Names {
+			if name.Name == ident.Name && i < len(decl.Values) {
+				return typeExpr(decl.Values[i])
+			}
+		}
+
+	case *ast.AssignStmt:
+		for i, lhs := range decl.Lhs {
+			name, ok := lhs.(*ast.Ident)
+			if !ok || name.Name != ident.Name {
+				continue
+			}
+
+			// The parser records range clause variables as an assignment
+			// from a unary range expression. Only the value (not the index
+			// or key) shares the element type.
+			if r, ok := decl.Rhs[0].(*ast.UnaryExpr); ok && r.Op == token.RANGE {
+				if i == 1 {
+					return typeExpr(&ast.IndexExpr{X: r.X})
+				}
+
+				return nil
+			}
+
+			switch {
+			case len(decl.Rhs) == len(decl.Lhs):
+				return typeExpr(decl.Rhs[i])
+
+			// The first result of a constructor also returning an error.
+			case i == 0 && len(decl.Rhs) == 1:
+				return typeExpr(decl.Rhs[0])
+			}
+		}
+	}
+
+	return nil
+}
+
+// isEmbeddingType reports whether the given type expression (or the type it
+// points to) refers to one of the embeddingTypes in one of the given
+// packages.
+func isEmbeddingType(t ast.Expr, pkgNames []string) bool {
+	if star, ok := t.(*ast.StarExpr); ok {
+		t = star.X
+	}
+
+	sel, ok := t.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+
+	pkg, ok := sel.X.(*ast.Ident)
+	if !ok || pkg.Obj != nil {
+		return false
+	}
+
+	for _, name := range pkgNames {
+		if pkg.Name == name {
+			return embeddingTypes[sel.Sel.Name]
+		}
+	}
+
+	return false
+}
+
+// findImport returns the first import spec matching one of the given import
+// paths along with the matched path.
+func findImport(file *ast.File, paths []string) (*ast.ImportSpec, string) {
+	for _, spec := range file.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+
+		for _, p := range paths {
+			if path == p {
+				return spec, p
+			}
+		}
+	}
+
+	return nil, ""
+}
+
+// importName returns the name used to refer to the imported package.
+func importName(spec *ast.ImportSpec, defaultName string) string {
+	if spec.Name != nil {
+		return spec.Name.Name
+	}
+
+	return defaultName
+}
+
+// usesPackage reports whether any selector expression in the file refers to
+// the given package name.
+func usesPackage(file *ast.File, name string) bool {
+	var used bool
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if pkg, ok := sel.X.(*ast.Ident); ok && pkg.Name == name && pkg.Obj == nil {
+				used = true
+			}
+		}
+		return !used
+	})
+
+	return used
+}
+
+// addImport adds an import of the given path to the file unless already
+// present.
+func addImport(file *ast.File, name string, path string) {
+	if spec, _ := findImport(file, []string{path}); spec != nil {
+		return
+	}
+
+	spec := &ast.ImportSpec{
+		Path: &ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(path)},
+	}
+	if name != messageCardPkgName {
+		spec.Name = ast.NewIdent(name)
+	}
+
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			continue
+		}
+
+		// Place the new import after the last existing import so that it is
+		// grouped with other third-party imports.
+		if len(gen.Specs) > 0 {
+			last := gen.Specs[len(gen.Specs)-1].(*ast.ImportSpec)
+			spec.Path.ValuePos = last.End()
+		}
+		if !gen.Lparen.IsValid() {
+			gen.Lparen = gen.Pos()
+			gen.Rparen = gen.End()
+		}
+
+		gen.Specs = append(gen.Specs, spec)
+		file.Imports = append(file.Imports, spec)
+
+		return
+	}
+
+	gen := &ast.GenDecl{
+		Tok:   token.IMPORT,
+		Specs: []ast.Spec{spec},
+	}
+	file.Decls = append([]ast.Decl{gen}, file.Decls...)
+	file.Imports = append(file.Imports, spec)
+}
+
+// removeImport removes the given import spec from the file.
+func removeImport(file *ast.File, target *ast.ImportSpec) {
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			continue
+		}
+
+		for i, spec := range gen.Specs {
+			if spec == target {
+				gen.Specs = append(gen.Specs[:i], gen.Specs[i+1:]...)
+
+				// Collapse a declaration left with a single import.
+				if len(gen.Specs) == 1 {
+					gen.Lparen = token.NoPos
+				}
+				break
+			}
+		}
+	}
+
+	for i, spec := range file.Imports {
+		if spec == target {
+			file.Imports = append(file.Imports[:i], file.Imports[i+1:]...)
+			break
+		}
+	}
+
+	// Drop import declarations left empty.
+	decls := file.Decls[:0]
+	for _, decl := range file.Decls {
+		if gen, ok := decl.(*ast.GenDecl); ok && gen.Tok == token.IMPORT && len(gen.Specs) == 0 {
+			continue
+		}
+		decls = append(decls, decl)
+	}
+	file.Decls = decls
+}
@@ -0,0 +1,196 @@
+// Copyright 2024 Adam Chalkley
+//
+// https://github.com/atc0005/go-teams-notify
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRewriteSource(t *testing.T) {
+	src := `package main
+
+import (
+	"context"
+
+	goteamsnotify "github.com/flashcatcloud/go-teams-notify/v2"
+)
+
+func main() {
+	mstClient := goteamsnotify.NewClient()
+
+	msgCard := goteamsnotify.NewMessageCard()
+	msgCard.Text = "Hello World"
+
+	section := goteamsnotify.NewMessageCardSection()
+	_ = section.AddFact(goteamsnotify.MessageCardSectionFact{Name: "a", Value: "b"})
+
+	pa, _ := goteamsnotify.NewMessageCardPotentialAction(goteamsnotify.PotentialActionOpenURIType, "Open")
+	pa.MessageCardPotentialActionOpenURI.Targets = []goteamsnotify.MessageCardPotentialActionOpenURITarget{
+		{OS: "default", URI: "https://example.com"},
+	}
+
+	_ = mstClient.SendWithContext(context.Background(), "https://example.webhook.office.com", msgCard)
+}
+`
+
+	want := `package main
+
+import (
+	"context"
+
+	goteamsnotify "github.com/flashcatcloud/go-teams-notify/v2"
+	"github.com/flashcatcloud/go-teams-notify/v2/messagecard"
+)
+
+func main() {
+	mstClient := goteamsnotify.NewTeamsClient()
+
+	msgCard := messagecard.NewMessageCard()
+	msgCard.Text = "Hello World"
+
+	section := messagecard.NewSection()
+	_ = section.AddFact(messagecard.SectionFact{Name: "a", Value: "b"})
+
+	pa, _ := messagecard.NewPotentialAction(messagecard.PotentialActionOpenURIType, "Open")
+	pa.PotentialActionOpenURI.Targets = []messagecard.PotentialActionOpenURITarget{
+		{OS: "default", URI: "https://example.com"},
+	}
+
+	_ = mstClient.SendWithContext(context.Background(), "https://example.webhook.office.com", msgCard)
+}
+`
+
+	out, result, err := rewriteSource("main.go", []byte(src))
+	assert.NoError(t, err)
+	assert.True(t, result.Changed)
+	assert.Equal(t, want, string(out))
+	assert.Len(t, result.Notes, 2)
+}
+
+func TestRewriteSourceRemovesUnusedImport(t *testing.T) {
+	src := `package main
+
+import goteamsnotify "github.com/atc0005/go-teams-notify/v2"
+
+var card goteamsnotify.MessageCard
+`
+
+	want := `package main
+
+import "github.com/atc0005/go-teams-notify/v2/messagecard"
+
+var card messagecard.MessageCard
+`
+
+	out, result, err := rewriteSource("main.go", []byte(src))
+	assert.NoError(t, err)
+	assert.True(t, result.Changed)
+	assert.Equal(t, want, string(out))
+}
+
+func TestRewriteSourceEmbeddedFields(t *testing.T) {
+	src := `package main
+
+import goteamsnotify "github.com/flashcatcloud/go-teams-notify/v2"
+
+func targets(pa *goteamsnotify.MessageCardPotentialAction) {
+	pa.MessageCardPotentialActionOpenURI.Targets = nil
+}
+
+func main() {
+	actions := []goteamsnotify.MessageCardPotentialAction{
+		{MessageCardPotentialActionOpenURI: goteamsnotify.MessageCardPotentialActionOpenURI{}},
+	}
+
+	for i, action := range actions {
+		_ = action.MessageCardPotentialActionOpenURI.Targets
+		targets(&actions[i])
+	}
+
+	pa := &goteamsnotify.MessageCardPotentialAction{}
+	_ = pa.MessageCardPotentialActionHTTPPOST.Body
+}
+`
+
+	want := `package main
+
+import "github.com/flashcatcloud/go-teams-notify/v2/messagecard"
+
+func targets(pa *messagecard.PotentialAction) {
+	pa.PotentialActionOpenURI.Targets = nil
+}
+
+func main() {
+	actions := []messagecard.PotentialAction{
+		{PotentialActionOpenURI: messagecard.PotentialActionOpenURI{}},
+	}
+
+	for i, action := range actions {
+		_ = action.PotentialActionOpenURI.Targets
+		targets(&actions[i])
+	}
+
+	pa := &messagecard.PotentialAction{}
+	_ = pa.PotentialActionHTTPPOST.Body
+}
+`
+
+	out, result, err := rewriteSource("main.go", []byte(src))
+	assert.NoError(t, err)
+	assert.True(t, result.Changed)
+	assert.Equal(t, want, string(out))
+	assert.Empty(t, result.Notes)
+}
+
+func TestRewriteSourceIgnoresOtherTypes(t *testing.T) {
+	src := `package main
+
+import goteamsnotify "github.com/flashcatcloud/go-teams-notify/v2"
+
+type config struct {
+	MessageCard        string
+	MessageCardSection string
+}
+
+func main() {
+	cfg := config{MessageCard: "a", MessageCardSection: "b"}
+	_ = cfg.MessageCardSection
+	_ = cfg.MessageCard
+
+	_ = goteamsnotify.NewTeamsClient()
+}
+`
+
+	out, result, err := rewriteSource("main.go", []byte(src))
+	assert.NoError(t, err)
+	assert.False(t, result.Changed)
+	assert.Equal(t, src, string(out))
+	assert.Empty(t, result.Notes)
+}
+
+func TestRewriteSourceNotesUnknownTypes(t *testing.T) {
+	src := `package main
+
+import goteamsnotify "github.com/flashcatcloud/go-teams-notify/v2"
+
+func main() {
+	card := goteamsnotify.NewMessageCard()
+	card.PotentialActions[0].MessageCardPotentialActionOpenURI.Targets = nil
+}
+`
+
+	out, result, err := rewriteSource("main.go", []byte(src))
+	assert.NoError(t, err)
+	assert.True(t, result.Changed)
+	assert.Contains(t, string(out), "card.PotentialActions[0].MessageCardPotentialActionOpenURI.Targets")
+	if assert.Len(t, result.Notes, 2) {
+		assert.Contains(t, result.Notes[1], "unable to determine type of value for field MessageCardPotentialActionOpenURI")
+	}
+}
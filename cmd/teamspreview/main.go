@@ -0,0 +1,58 @@
+// Copyright 2024 Adam Chalkley
+//
+// https://github.com/atc0005/go-teams-notify
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+// Command teamspreview serves an HTML preview of Microsoft Teams message
+// payloads stored as JSON files, allowing card layouts to be iterated on
+// without sending them to a Teams channel.
+//
+// Usage:
+//
+//	teamspreview [-addr host:port] [-theme light|dark] file.json ...
+//
+// Each file may contain an adaptivecard.Message, a bare Adaptive Card or a
+// messagecard.MessageCard payload. The rendering approximates the Microsoft
+// Teams look and is not pixel perfect. Validation errors and warnings for
+// each payload are displayed above the preview, and the page is reloaded
+// automatically when the file changes.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+)
+
+func main() {
+	addr := flag.String("addr", "localhost:8080", "address to listen on")
+	theme := flag.String("theme", themeLight, "default theme (light or dark)")
+
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: teamspreview [-addr host:port] [-theme light|dark] file.json ...\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	srv, err := newServer(flag.Args(), *theme)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "teamspreview: %v\n", err)
+		os.Exit(2)
+	}
+
+	log.Printf("serving preview of %d file(s) at http://%s/", flag.NArg(), *addr)
+
+	if err := http.ListenAndServe(*addr, srv); err != nil {
+		fmt.Fprintf(os.Stderr, "teamspreview: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,315 @@
+// Copyright 2024 Adam Chalkley
+//
+// https://github.com/atc0005/go-teams-notify
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"html/template"
+	"io/ioutil"
+	"strings"
+
+	"github.com/flashcatcloud/go-teams-notify/v2/adaptivecard"
+	"github.com/flashcatcloud/go-teams-notify/v2/internal/urlsafe"
+	"github.com/flashcatcloud/go-teams-notify/v2/messagecard"
+)
+
+// Payload kinds supported by the preview.
+const (
+	kindMessage      string = "Adaptive Card message"
+	kindAdaptiveCard string = "Adaptive Card"
+	kindMessageCard  string = "MessageCard"
+)
+
+// errUnsupportedPayload indicates that a file does not contain a supported
+// payload type.
+var errUnsupportedPayload = errors.New("unsupported payload type")
+
+// preview is the result of loading and rendering a payload file.
+type preview struct {
+	// Path is the path to the payload file.
+	Path string
+
+	// Kind describes the type of payload found in the file.
+	Kind string
+
+	// Cards holds the rendered HTML for each card within the payload.
+	Cards []template.HTML
+
+	// Errors holds any errors encountered while loading or validating the
+	// payload.
+	Errors []string
+
+	// Warnings holds non-fatal issues reported for the payload (e.g.,
+	// unknown properties).
+	Warnings []string
+}
+
+// payloadType is used to determine the type of payload within a file.
+type payloadType struct {
+	Type   string `json:"type"`
+	AtType string `json:"@type"`
+}
+
+// loadPreview reads and renders the payload file at the given path. Errors
+// are recorded in the returned preview so that they can be displayed inline.
+func loadPreview(path string) preview {
+	p := preview{Path: path}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		p.Errors = append(p.Errors, err.Error())
+		return p
+	}
+
+	var pt payloadType
+	if err := json.Unmarshal(data, &pt); err != nil {
+		p.Errors = append(p.Errors, fmt.Sprintf("invalid JSON: %v", err))
+		return p
+	}
+
+	switch {
+	case pt.Type == adaptivecard.TypeMessage:
+		p.Kind = kindMessage
+		p.loadMessage(data)
+
+	case pt.Type == adaptivecard.TypeAdaptiveCard:
+		p.Kind = kindAdaptiveCard
+		p.loadCard(data)
+
+	case strings.EqualFold(pt.AtType, "MessageCard"):
+		p.Kind = kindMessageCard
+		p.loadMessageCard(data)
+
+	default:
+		p.Errors = append(p.Errors, fmt.Sprintf(
+			"type %q, @type %q: %v",
+			pt.Type,
+			pt.AtType,
+			errUnsupportedPayload,
+		))
+	}
+
+	return p
+}
+
+// loadMessage renders each card attached to an adaptivecard.Message.
+func (p *preview) loadMessage(data []byte) {
+	msg, issues, err := adaptivecard.ParseMessage(data, adaptivecard.ParseModeLenient)
+	if err != nil {
+		p.Errors = append(p.Errors, err.Error())
+		return
+	}
+
+	p.addIssues(issues)
+
+	if err := msg.Validate(); err != nil {
+		p.Errors = append(p.Errors, err.Error())
+	}
+	p.Warnings = append(p.Warnings, msg.Warnings()...)
+
+	for _, attachment := range msg.Attachments {
+		p.Cards = append(p.Cards, template.HTML(attachment.Content.HTML()))
+	}
+}
+
+// loadCard renders a bare Adaptive Card.
+func (p *preview) loadCard(data []byte) {
+	card, issues, err := adaptivecard.ParseCard(data, adaptivecard.ParseModeLenient)
+	if err != nil {
+		p.Errors = append(p.Errors, err.Error())
+		return
+	}
+
+	p.addIssues(issues)
+
+	if err := (adaptivecard.TopLevelCard{Card: card}).Validate(); err != nil {
+		p.Errors = append(p.Errors, err.Error())
+	}
+	p.Warnings = append(p.Warnings, card.Warnings()...)
+
+	p.Cards = append(p.Cards, template.HTML(card.HTML()))
+}
+
+// loadMessageCard renders a messagecard.MessageCard.
+func (p *preview) loadMessageCard(data []byte) {
+	var mc messagecard.MessageCard
+	if err := json.Unmarshal(data, &mc); err != nil {
+		p.Errors = append(p.Errors, err.Error())
+		return
+	}
+
+	if err := mc.Validate(); err != nil {
+		p.Errors = append(p.Errors, err.Error())
+	}
+
+	p.Cards = append(p.Cards, renderMessageCard(mc))
+}
+
+// addIssues records the given parse issues as warnings.
+func (p *preview) addIssues(issues adaptivecard.ParseIssues) {
+	for _, issue := range issues {
+		p.Warnings = append(p.Warnings, issue.String())
+	}
+}
+
+// renderMessageCard renders a MessageCard as HTML. All text is escaped.
+func renderMessageCard(mc messagecard.MessageCard) template.HTML {
+	var b strings.Builder
+
+	color := strings.TrimPrefix(mc.ThemeColor, "#")
+	if !isHexColor(color) {
+		color = ""
+	}
+
+	b.WriteString(`<div class="messagecard"`)
+	if color != "" {
+		fmt.Fprintf(&b, ` style="border-left-color: #%s"`, color)
+	}
+	b.WriteString(">\n")
+
+	if mc.Title != "" {
+		fmt.Fprintf(&b, "<h2>%s</h2>\n", messageCardText(mc.Title))
+	}
+
+	if mc.Text != "" {
+		fmt.Fprintf(&b, "<p>%s</p>\n", messageCardText(mc.Text))
+	}
+
+	for _, section := range mc.Sections {
+		if section != nil {
+			renderMessageCardSection(&b, *section)
+		}
+	}
+
+	renderPotentialActions(&b, mc.PotentialActions)
+
+	b.WriteString("</div>")
+
+	return template.HTML(b.String())
+}
+
+// renderMessageCardSection renders a MessageCard Section.
+func renderMessageCardSection(b *strings.Builder, s messagecard.Section) {
+	class := "section"
+	if s.StartGroup {
+		class += " start-group"
+	}
+	fmt.Fprintf(b, "<div class=%q>\n", class)
+
+	if s.ActivityTitle != "" || s.ActivitySubtitle != "" || s.ActivityText != "" {
+		b.WriteString(`<div class="activity">` + "\n")
+		if urlsafe.Image(s.ActivityImage) {
+			fmt.Fprintf(b, "<img class=\"activity-image\" src=\"%s\" alt=\"\">\n", html.EscapeString(s.ActivityImage))
+		}
+		b.WriteString("<div>\n")
+		if s.ActivityTitle != "" {
+			fmt.Fprintf(b, "<div class=\"activity-title\">%s</div>\n", messageCardText(s.ActivityTitle))
+		}
+		if s.ActivitySubtitle != "" {
+			fmt.Fprintf(b, "<div class=\"activity-subtitle\">%s</div>\n", messageCardText(s.ActivitySubtitle))
+		}
+		if s.ActivityText != "" {
+			fmt.Fprintf(b, "<div>%s</div>\n", messageCardText(s.ActivityText))
+		}
+		b.WriteString("</div>\n</div>\n")
+	}
+
+	if s.HeroImage != nil && urlsafe.Image(s.HeroImage.Image) {
+		fmt.Fprintf(b, "<img src=\"%s\" alt=\"%s\">\n", html.EscapeString(s.HeroImage.Image), html.EscapeString(s.HeroImage.Title))
+	}
+
+	if s.Title != "" {
+		fmt.Fprintf(b, "<h3>%s</h3>\n", messageCardText(s.Title))
+	}
+
+	if s.Text != "" {
+		fmt.Fprintf(b, "<p>%s</p>\n", messageCardText(s.Text))
+	}
+
+	if len(s.Facts) > 0 {
+		b.WriteString(`<table class="factset">` + "\n")
+		for _, fact := range s.Facts {
+			fmt.Fprintf(b, "<tr><th>%s</th><td>%s</td></tr>\n", messageCardText(fact.Name), messageCardText(fact.Value))
+		}
+		b.WriteString("</table>\n")
+	}
+
+	for _, image := range s.Images {
+		if image != nil && urlsafe.Image(image.Image) {
+			fmt.Fprintf(b, "<img src=\"%s\" alt=\"%s\">\n", html.EscapeString(image.Image), html.EscapeString(image.Title))
+		}
+	}
+
+	renderPotentialActions(b, s.PotentialActions)
+
+	b.WriteString("</div>\n")
+}
+
+// renderPotentialActions renders OpenUri actions as links and other action
+// types as plain text, as they cannot be performed from the preview.
+func renderPotentialActions(b *strings.Builder, actions []*messagecard.PotentialAction) {
+	if len(actions) == 0 {
+		return
+	}
+
+	b.WriteString(`<p class="actions">`)
+	written := false
+	for _, action := range actions {
+		if action == nil {
+			continue
+		}
+
+		if written {
+			b.WriteString(" | ")
+		}
+		written = true
+
+		uri := ""
+		for _, target := range action.Targets {
+			if target.OS == "default" || uri == "" {
+				uri = target.URI
+			}
+		}
+
+		if urlsafe.Link(uri) {
+			fmt.Fprintf(b, "<a href=\"%s\">%s</a>", html.EscapeString(uri), html.EscapeString(action.Name))
+			continue
+		}
+
+		fmt.Fprintf(b, "<span title=\"%s\">%s</span>", html.EscapeString(action.Type), html.EscapeString(action.Name))
+	}
+	b.WriteString("</p>\n")
+}
+
+// messageCardText escapes MessageCard text for display. Line breaks
+// (including the <br> tags added by messagecard.ConvertEOLToBreak) are
+// preserved.
+func messageCardText(text string) string {
+	text = strings.NewReplacer("<br>", "\n", "<br/>", "\n", "<br />", "\n").Replace(text)
+
+	return strings.ReplaceAll(html.EscapeString(text), "\n", "<br>")
+}
+
+// isHexColor indicates whether the given value is a 3 or 6 digit hex color
+// value without a leading # character.
+func isHexColor(value string) bool {
+	if len(value) != 3 && len(value) != 6 {
+		return false
+	}
+
+	for _, r := range strings.ToLower(value) {
+		if !strings.ContainsRune("0123456789abcdef", r) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,197 @@
+// Copyright 2024 Adam Chalkley
+//
+// https://github.com/atc0005/go-teams-notify
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package main
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/flashcatcloud/go-teams-notify/v2/messagecard"
+	"github.com/stretchr/testify/assert"
+)
+
+const messageJSON = `{
+	"type": "message",
+	"attachments": [{
+		"contentType": "application/vnd.microsoft.card.adaptive",
+		"content": {
+			"type": "AdaptiveCard",
+			"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+			"version": "1.5",
+			"body": [
+				{"type": "TextBlock", "text": "Disk usage high <script>", "size": "large"},
+				{"type": "Container", "style": "attention", "items": [{"type": "TextBlock", "text": "db01"}]}
+			]
+		}
+	}]
+}`
+
+const invalidMessageJSON = `{
+	"type": "message",
+	"attachments": [{
+		"contentType": "application/vnd.microsoft.card.adaptive",
+		"content": {
+			"type": "AdaptiveCard",
+			"version": "1.5",
+			"body": [{"type": "TextBlock", "text": "hi", "color": "pink", "colour": "red"}]
+		}
+	}]
+}`
+
+const messageCardJSON = `{
+	"@type": "MessageCard",
+	"@context": "https://schema.org/extensions",
+	"summary": "Build failed",
+	"title": "Build failed",
+	"themeColor": "#C4314B",
+	"sections": [{"activityTitle": "CI", "facts": [{"name": "Branch", "value": "main"}]}],
+	"potentialAction": [{"@type": "OpenUri", "name": "View", "targets": [{"os": "default", "uri": "https://example.com/build/1"}]}]
+}`
+
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir, err := ioutil.TempDir("", "teamspreview")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	for name, content := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return dir
+}
+
+func TestLoadPreview(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"message.json":     messageJSON,
+		"invalid.json":     invalidMessageJSON,
+		"messagecard.json": messageCardJSON,
+		"unknown.json":     `{"type": "Carousel"}`,
+		"broken.json":      `{`,
+	})
+
+	p := loadPreview(filepath.Join(dir, "message.json"))
+	assert.Equal(t, kindMessage, p.Kind)
+	assert.Empty(t, p.Errors)
+	if assert.Len(t, p.Cards, 1) {
+		assert.Contains(t, string(p.Cards[0]), "<h3>Disk usage high &lt;script&gt;</h3>")
+		assert.Contains(t, string(p.Cards[0]), `class="container style-attention"`)
+	}
+
+	p = loadPreview(filepath.Join(dir, "invalid.json"))
+	assert.Len(t, p.Cards, 1)
+	if assert.Len(t, p.Errors, 1) {
+		assert.Contains(t, p.Errors[0], "pink")
+	}
+	if assert.Len(t, p.Warnings, 1) {
+		assert.Contains(t, p.Warnings[0], "colour")
+	}
+
+	p = loadPreview(filepath.Join(dir, "messagecard.json"))
+	assert.Equal(t, kindMessageCard, p.Kind)
+	assert.Empty(t, p.Errors)
+	if assert.Len(t, p.Cards, 1) {
+		card := string(p.Cards[0])
+		assert.Contains(t, card, "border-left-color: #C4314B")
+		assert.Contains(t, card, `<a href="https://example.com/build/1">View</a>`)
+		assert.Contains(t, card, "<tr><th>Branch</th><td>main</td></tr>")
+	}
+
+	p = loadPreview(filepath.Join(dir, "unknown.json"))
+	assert.Empty(t, p.Cards)
+	assert.Len(t, p.Errors, 1)
+
+	p = loadPreview(filepath.Join(dir, "broken.json"))
+	assert.Empty(t, p.Cards)
+	assert.Len(t, p.Errors, 1)
+}
+
+func TestRenderPotentialActions(t *testing.T) {
+	tests := map[string]struct {
+		actions []*messagecard.PotentialAction
+		want    string
+	}{
+		"nil first action": {
+			actions: []*messagecard.PotentialAction{nil, openURIAction("View", "https://example.com")},
+			want:    `<p class="actions"><a href="https://example.com">View</a></p>` + "\n",
+		},
+		"nil middle action": {
+			actions: []*messagecard.PotentialAction{
+				openURIAction("View", "https://example.com"),
+				nil,
+				openURIAction("Run", "javascript:alert(1)"),
+			},
+			want: `<p class="actions"><a href="https://example.com">View</a> | ` +
+				`<span title="OpenUri">Run</span></p>` + "\n",
+		},
+		"no actions": {},
+	}
+
+	for name, tt := range tests {
+		name, tt := name, tt
+
+		t.Run(name, func(t *testing.T) {
+			var b strings.Builder
+			renderPotentialActions(&b, tt.actions)
+
+			assert.Equal(t, tt.want, b.String())
+		})
+	}
+}
+
+func openURIAction(name string, uri string) *messagecard.PotentialAction {
+	action := messagecard.PotentialAction{
+		Type: messagecard.PotentialActionOpenURIType,
+		Name: name,
+	}
+	action.Targets = []messagecard.PotentialActionOpenURITarget{{OS: "default", URI: uri}}
+
+	return &action
+}
+
+func TestServer(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"message.json":     messageJSON,
+		"messagecard.json": messageCardJSON,
+	})
+
+	_, err := newServer([]string{filepath.Join(dir, "message.json")}, "blue")
+	assert.Error(t, err)
+
+	_, err = newServer([]string{filepath.Join(dir, "missing.json")}, themeLight)
+	assert.Error(t, err)
+
+	srv, err := newServer(
+		[]string{filepath.Join(dir, "message.json"), filepath.Join(dir, "messagecard.json")},
+		themeLight,
+	)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/?file=1&theme=dark", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `data-theme="dark"`)
+	assert.Contains(t, rec.Body.String(), `<div class="messagecard"`)
+	assert.Contains(t, rec.Body.String(), `/events?file=1`)
+
+	rec = httptest.NewRecorder()
+	srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/?file=2", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
@@ -0,0 +1,247 @@
+// Copyright 2024 Adam Chalkley
+//
+// https://github.com/atc0005/go-teams-notify
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package main
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// Supported themes.
+const (
+	themeLight string = "light"
+	themeDark  string = "dark"
+)
+
+// pollInterval is how often payload files are checked for changes.
+const pollInterval = 500 * time.Millisecond
+
+// server serves previews of a fixed set of payload files.
+type server struct {
+	files []string
+	theme string
+	mux   *http.ServeMux
+}
+
+// pageData is passed to the page template.
+type pageData struct {
+	Theme   string
+	Files   []string
+	Index   int
+	Preview preview
+}
+
+// newServer returns a server for the given payload files using the given
+// default theme.
+func newServer(files []string, theme string) (*server, error) {
+	if theme != themeLight && theme != themeDark {
+		return nil, fmt.Errorf("invalid theme %q; expected %q or %q", theme, themeLight, themeDark)
+	}
+
+	for _, file := range files {
+		if _, err := os.Stat(file); err != nil {
+			return nil, err
+		}
+	}
+
+	s := &server{
+		files: files,
+		theme: theme,
+		mux:   http.NewServeMux(),
+	}
+
+	s.mux.HandleFunc("/", s.handlePreview)
+	s.mux.HandleFunc("/events", s.handleEvents)
+
+	return s, nil
+}
+
+// ServeHTTP implements http.Handler.
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// fileIndex returns the index of the file requested using the file query
+// parameter, defaulting to the first file.
+func (s *server) fileIndex(r *http.Request) (int, bool) {
+	value := r.URL.Query().Get("file")
+	if value == "" {
+		return 0, true
+	}
+
+	i, err := strconv.Atoi(value)
+	if err != nil || i < 0 || i >= len(s.files) {
+		return 0, false
+	}
+
+	return i, true
+}
+
+// handlePreview renders the preview page for the requested file.
+func (s *server) handlePreview(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+
+	i, ok := s.fileIndex(r)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	theme := r.URL.Query().Get("theme")
+	if theme != themeLight && theme != themeDark {
+		theme = s.theme
+	}
+
+	data := pageData{
+		Theme:   theme,
+		Files:   s.files,
+		Index:   i,
+		Preview: loadPreview(s.files[i]),
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := pageTemplate.Execute(w, data); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// handleEvents streams a server-sent event whenever the requested file is
+// modified, prompting the page to reload.
+func (s *server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	i, ok := s.fileIndex(r)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	last := modTime(s.files[i])
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+
+		case <-ticker.C:
+			current := modTime(s.files[i])
+			if current.Equal(last) {
+				continue
+			}
+			last = current
+
+			fmt.Fprint(w, "data: reload\n\n")
+			flusher.Flush()
+		}
+	}
+}
+
+// modTime returns the modification time of the given file or the zero value
+// if the file cannot be accessed.
+func modTime(path string) time.Time {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return info.ModTime()
+}
+
+// pageTemplate is the template used to render the preview page. The styling
+// approximates the look of cards in Microsoft Teams.
+var pageTemplate = template.Must(template.New("page").Funcs(template.FuncMap{
+	"base": filepath.Base,
+}).Parse(`<!DOCTYPE html>
+<html lang="en" data-theme="{{.Theme}}">
+<head>
+<meta charset="utf-8">
+<title>{{base .Preview.Path}} - teamspreview</title>
+<style>
+:root {
+	--page: #f5f5f5; --card: #ffffff; --text: #242424; --subtle: #616161;
+	--border: #e0e0e0; --accent: #5b5fc7; --emphasis: #f0f0f0;
+	--good: #e7f2da; --attention: #fdf3f4; --warning: #fbf6d9; --accent-bg: #ebf3fc;
+	--error: #c4314b; --warn: #835c00;
+}
+[data-theme="dark"] {
+	--page: #1f1f1f; --card: #292929; --text: #ffffff; --subtle: #adadad;
+	--border: #3d3d3d; --accent: #7f85f5; --emphasis: #333333;
+	--good: #0d2e0d; --attention: #3e1f25; --warning: #463100; --accent-bg: #0e2a4b;
+	--error: #f9526b; --warn: #f2c661;
+}
+body { margin: 0; background: var(--page); color: var(--text); font: 14px/1.43 "Segoe UI", system-ui, sans-serif; }
+header { display: flex; gap: 1em; align-items: center; padding: 8px 16px; border-bottom: 1px solid var(--border); }
+header nav { flex: 1; }
+a { color: var(--accent); }
+main { max-width: 720px; margin: 24px auto; padding: 0 16px; }
+.issues { margin: 0 0 16px; padding: 8px 12px 8px 32px; border-radius: 4px; background: var(--card); }
+.errors { color: var(--error); border-left: 4px solid var(--error); }
+.warnings { color: var(--warn); border-left: 4px solid var(--warn); }
+.adaptivecard, .messagecard { background: var(--card); border: 1px solid var(--border); border-radius: 4px; padding: 16px; margin-bottom: 16px; box-shadow: 0 1px 2px rgba(0,0,0,.14); }
+.messagecard { border-left: 4px solid var(--accent); }
+.container { padding: 8px; margin: 8px 0; }
+.style-emphasis { background: var(--emphasis); }
+.style-good { background: var(--good); }
+.style-attention { background: var(--attention); }
+.style-warning { background: var(--warning); }
+.style-accent { background: var(--accent-bg); }
+h2, h3 { margin: 0 0 8px; font-weight: 600; }
+p { margin: 0 0 8px; }
+pre { margin: 0 0 8px; white-space: pre-wrap; font: 13px Consolas, monospace; }
+img { max-width: 100%; }
+table { border-collapse: collapse; margin: 0 0 8px; }
+th, td { text-align: left; vertical-align: top; padding: 2px 12px 2px 0; }
+table:not(.factset) th, table:not(.factset) td { border: 1px solid var(--border); padding: 4px 8px; }
+.factset th { font-weight: 600; }
+.actions a, .actions span { display: inline-block; margin-top: 8px; }
+details summary { cursor: pointer; color: var(--accent); }
+.activity { display: flex; gap: 12px; margin-bottom: 8px; }
+.activity-image { width: 40px; height: 40px; border-radius: 50%; }
+.activity-title { font-weight: 600; }
+.activity-subtitle { color: var(--subtle); }
+.section.start-group { border-top: 1px solid var(--border); padding-top: 8px; }
+</style>
+</head>
+<body>
+<header>
+<nav>{{$index := .Index}}{{$theme := .Theme}}{{range $i, $file := .Files}}{{if eq $i $index}}<strong>{{base $file}}</strong>{{else}}<a href="/?file={{$i}}&amp;theme={{$theme}}">{{base $file}}</a>{{end}} {{end}}</nav>
+<span>{{.Preview.Kind}}</span>
+{{if eq .Theme "dark"}}<a href="/?file={{.Index}}&amp;theme=light">Light theme</a>{{else}}<a href="/?file={{.Index}}&amp;theme=dark">Dark theme</a>{{end}}
+</header>
+<main>
+{{with .Preview.Errors}}<ul class="issues errors">{{range .}}<li>{{.}}</li>{{end}}</ul>{{end}}
+{{with .Preview.Warnings}}<ul class="issues warnings">{{range .}}<li>{{.}}</li>{{end}}</ul>{{end}}
+{{range .Preview.Cards}}{{.}}
+{{end}}
+</main>
+<script>
+new EventSource("/events?file={{.Index}}").onmessage = function () { location.reload(); };
+</script>
+</body>
+</html>
+`))
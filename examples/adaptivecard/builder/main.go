@@ -0,0 +1,97 @@
+// Copyright 2024 Adam Chalkley
+//
+// https://github.com/atc0005/go-teams-notify
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+/*
+This example uses the fluent card builder to assemble a notification with a
+title, summary text, facts, a table, a toggleable details section, a button
+and a user mention. Errors are accumulated by the builder and reported once
+along with any validation errors.
+
+While this example aims to showcase one or more specific features it may not
+illustrate overall best practices.
+
+Of note:
+
+- default timeout
+- package-level logging is disabled by default
+- validation of known webhook URL prefixes is *enabled*
+
+See https://docs.microsoft.com/en-us/adaptive-cards/authoring-cards/text-features
+for the list of supported Adaptive Card text formatting options.
+*/
+package main
+
+import (
+	"log"
+	"os"
+
+	goteamsnotify "github.com/flashcatcloud/go-teams-notify/v2"
+	"github.com/flashcatcloud/go-teams-notify/v2/adaptivecard"
+)
+
+func main() {
+
+	// Initialize a new Microsoft Teams client.
+	mstClient := goteamsnotify.NewTeamsClient()
+
+	// Set webhook url.
+	//
+	// NOTE: This is for illustration purposes only. Best practice is to NOT
+	// hardcode credentials of any kind.
+	webhookUrl := "https://outlook.office.com/webhook/YOUR_WEBHOOK_URL_OF_TEAMS_CHANNEL"
+
+	// Allow specifying webhook URL via environment variable, fall-back to
+	// hard-coded value in this example file.
+	expectedEnvVar := "WEBHOOK_URL"
+	envWebhookURL := os.Getenv(expectedEnvVar)
+	switch {
+	case envWebhookURL != "":
+		log.Printf(
+			"Using webhook URL %q from environment variable %q\n\n",
+			envWebhookURL,
+			expectedEnvVar,
+		)
+		webhookUrl = envWebhookURL
+	default:
+		log.Println(expectedEnvVar, "environment variable not set.")
+		log.Printf("Using hardcoded value %q as fallback\n\n", webhookUrl)
+	}
+
+	// Assemble the card and wrap it in a new Message.
+	msg, err := adaptivecard.Build().
+		Title("Disk usage high").
+		Text("Usage on **db01** exceeds the configured threshold.").
+		Facts(
+			adaptivecard.Fact{Title: "Host", Value: "db01"},
+			adaptivecard.Fact{Title: "Threshold", Value: "95%"},
+		).
+		Table([][]string{
+			{"Mount", "Size", "Used"},
+			{"/", "50G", "61%"},
+			{"/var", "200G", "97%"},
+		}).
+		Toggle(
+			"Show details",
+			"detailsContainer",
+			adaptivecard.NewTextBlock("Largest directory: /var/log (120G)", true),
+		).
+		Button("Open dashboard", "https://example.com/dashboard").
+		MentionUser("John Doe", "john.doe@example.com").
+		FullWidth().
+		Message()
+	if err != nil {
+		log.Printf("failed to build message: %v", err)
+		os.Exit(1)
+	}
+
+	// Send the message with default timeout/retry settings.
+	if err := mstClient.Send(webhookUrl, msg); err != nil {
+		log.Printf("failed to send message: %v", err)
+		os.Exit(1)
+	}
+
+}
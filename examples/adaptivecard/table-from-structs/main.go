@@ -0,0 +1,111 @@
+// Copyright 2024 Adam Chalkley
+//
+// https://github.com/atc0005/go-teams-notify
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+/*
+This example uses NewTableFromStructs to create a table from a slice of
+structs. Struct tags control the column headers, order and formatting,
+failed checks are highlighted using a row style and rows beyond the limit are
+split across multiple tables.
+
+While this example aims to showcase one or more specific features it may not
+illustrate overall best practices.
+
+Of note:
+
+- default timeout
+- package-level logging is disabled by default
+- validation of known webhook URL prefixes is *enabled*
+
+See https://docs.microsoft.com/en-us/adaptive-cards/authoring-cards/text-features
+for the list of supported Adaptive Card text formatting options.
+*/
+package main
+
+import (
+	"log"
+	"os"
+	"time"
+
+	goteamsnotify "github.com/flashcatcloud/go-teams-notify/v2"
+	"github.com/flashcatcloud/go-teams-notify/v2/adaptivecard"
+)
+
+// checkResult is the result of a monitoring check.
+type checkResult struct {
+	Name     string        `table:"Check"`
+	Host     string        `table:"Host"`
+	Duration time.Duration `table:"Took,align=right"`
+	Value    float64       `table:"Value,format=%.1f"`
+	Failed   bool          `table:"-"`
+}
+
+func main() {
+
+	// Initialize a new Microsoft Teams client.
+	mstClient := goteamsnotify.NewTeamsClient()
+
+	// Set webhook url.
+	//
+	// NOTE: This is for illustration purposes only. Best practice is to NOT
+	// hardcode credentials of any kind.
+	webhookUrl := "https://outlook.office.com/webhook/YOUR_WEBHOOK_URL_OF_TEAMS_CHANNEL"
+
+	// Allow specifying webhook URL via environment variable, fall-back to
+	// hard-coded value in this example file.
+	expectedEnvVar := "WEBHOOK_URL"
+	envWebhookURL := os.Getenv(expectedEnvVar)
+	switch {
+	case envWebhookURL != "":
+		log.Printf(
+			"Using webhook URL %q from environment variable %q\n\n",
+			envWebhookURL,
+			expectedEnvVar,
+		)
+		webhookUrl = envWebhookURL
+	default:
+		log.Println(expectedEnvVar, "environment variable not set.")
+		log.Printf("Using hardcoded value %q as fallback\n\n", webhookUrl)
+	}
+
+	results := []checkResult{
+		{Name: "disk", Host: "db01", Duration: 120 * time.Millisecond, Value: 97.25, Failed: true},
+		{Name: "cpu", Host: "db01", Duration: 80 * time.Millisecond, Value: 41.5},
+		{Name: "memory", Host: "web01", Duration: 95 * time.Millisecond, Value: 63},
+	}
+
+	tables, err := adaptivecard.NewTableFromStructs(results, adaptivecard.TableOptions{
+		MaxRows:  2,
+		Overflow: adaptivecard.TableOverflowPaginate,
+		RowStyle: func(_ int, row interface{}) string {
+			if row.(checkResult).Failed {
+				return adaptivecard.ContainerStyleAttention
+			}
+			return ""
+		},
+	})
+	if err != nil {
+		log.Printf("failed to create table: %v", err)
+		os.Exit(1)
+	}
+
+	// Assemble the card and wrap it in a new Message.
+	msg, err := adaptivecard.Build().
+		Title("Check results").
+		Element(tables...).
+		Message()
+	if err != nil {
+		log.Printf("failed to build message: %v", err)
+		os.Exit(1)
+	}
+
+	// Send the message with default timeout/retry settings.
+	if err := mstClient.Send(webhookUrl, msg); err != nil {
+		log.Printf("failed to send message: %v", err)
+		os.Exit(1)
+	}
+
+}
@@ -0,0 +1,13 @@
+// Copyright 2024 Adam Chalkley
+//
+// https://github.com/atc0005/go-teams-notify
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+/*
+Package urlsafe provides checks for URLs included in rendered HTML (e.g., the
+HTML preview of a Card). Only URLs using an allowed scheme are considered
+safe; other URLs (e.g., javascript: URLs) are expected to be dropped.
+*/
+package urlsafe
@@ -0,0 +1,40 @@
+// Copyright 2024 Adam Chalkley
+//
+// https://github.com/atc0005/go-teams-notify
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package urlsafe
+
+import "strings"
+
+// Link indicates whether the given URL is safe to use as a link target in
+// rendered HTML. The http, https and mailto schemes are considered safe.
+func Link(url string) bool {
+	lower := normalize(url)
+
+	return hasWebScheme(lower) || strings.HasPrefix(lower, "mailto:")
+}
+
+// Image indicates whether the given URL is safe to use as an image source in
+// rendered HTML. The http and https schemes and image data URIs are
+// considered safe.
+func Image(url string) bool {
+	lower := normalize(url)
+
+	return hasWebScheme(lower) || strings.HasPrefix(lower, "data:image/")
+}
+
+// normalize returns the given URL in lowercase with surrounding whitespace
+// removed.
+func normalize(url string) string {
+	return strings.ToLower(strings.TrimSpace(url))
+}
+
+// hasWebScheme indicates whether the given normalized URL uses the http or
+// https scheme.
+func hasWebScheme(lower string) bool {
+	return strings.HasPrefix(lower, "https://") ||
+		strings.HasPrefix(lower, "http://")
+}
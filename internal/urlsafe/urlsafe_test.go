@@ -0,0 +1,40 @@
+// Copyright 2024 Adam Chalkley
+//
+// https://github.com/atc0005/go-teams-notify
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package urlsafe
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLinkAndImage(t *testing.T) {
+	tests := map[string]struct {
+		url       string
+		wantLink  bool
+		wantImage bool
+	}{
+		"https":          {url: "https://example.com", wantLink: true, wantImage: true},
+		"http uppercase": {url: " HTTP://example.com", wantLink: true, wantImage: true},
+		"mailto":         {url: "mailto:ops@example.com", wantLink: true},
+		"image data URI": {url: "data:image/png;base64,iVBORw0KGgo=", wantImage: true},
+		"html data URI":  {url: "data:text/html;base64,PHNjcmlwdD4="},
+		"javascript":     {url: "JavaScript:alert(1)"},
+		"relative":       {url: "/path"},
+		"empty":          {},
+	}
+
+	for name, tt := range tests {
+		name, tt := name, tt
+
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.wantLink, Link(tt.url))
+			assert.Equal(t, tt.wantImage, Image(tt.url))
+		})
+	}
+}
@@ -9,6 +9,7 @@ package validator
 
 import (
 	"fmt"
+	"reflect"
 
 	goteamsnotify "github.com/flashcatcloud/go-teams-notify/v2"
 )
@@ -354,7 +355,7 @@ func (v *Validator) NotEmptyCollection(fieldValueDesc string, typeDesc string, b
 	if v.err != nil {
 		return false
 	}
-	if len(items) == 0 {
+	if collectionLen(items) == 0 {
 		switch {
 		case baseErr != nil:
 			v.err = fmt.Errorf(
@@ -436,7 +437,7 @@ func (v *Validator) NotEmptyCollectionIfFieldValNotEmpty(
 	case v.err != nil:
 		return false
 
-	case fieldVal != "" && len(items) == 0:
+	case fieldVal != "" && collectionLen(items) == 0:
 		switch {
 		case baseErr != nil:
 			v.err = fmt.Errorf(
@@ -493,3 +494,19 @@ func (v *Validator) Error() string {
 func (v *Validator) Err() error {
 	return v.err
 }
+
+// collectionLen returns the number of values in the given items collection.
+// Callers commonly pass a single slice (e.g., a []Fact field) as the items
+// collection; the length of that slice is used in that case.
+func collectionLen(items []interface{}) int {
+	if len(items) != 1 || items[0] == nil {
+		return len(items)
+	}
+
+	switch rv := reflect.ValueOf(items[0]); rv.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return rv.Len()
+	default:
+		return len(items)
+	}
+}
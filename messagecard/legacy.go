@@ -0,0 +1,309 @@
+// Copyright 2024 Adam Chalkley
+//
+// https://github.com/atc0005/go-teams-notify
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package messagecard
+
+import (
+	goteamsnotify "github.com/flashcatcloud/go-teams-notify/v2"
+)
+
+// The functions in this file convert between the deprecated MessageCard type
+// family provided by the goteamsnotify package and the types provided by this
+// package. They are intended to aid migration away from the deprecated types
+// where values cross API boundaries which cannot be updated at the same time.
+//
+// See also the teamsfix command which rewrites call sites automatically.
+
+// FromLegacyMessageCard converts a deprecated goteamsnotify.MessageCard value
+// to a new MessageCard. Any ValidateFunc is carried over, but a prepared
+// payload is not; call Prepare on the new MessageCard as needed.
+func FromLegacyMessageCard(legacy goteamsnotify.MessageCard) *MessageCard {
+	mc := MessageCard{
+		Type:         legacy.Type,
+		Context:      legacy.Context,
+		Summary:      legacy.Summary,
+		Title:        legacy.Title,
+		Text:         legacy.Text,
+		ThemeColor:   legacy.ThemeColor,
+		ValidateFunc: legacy.ValidateFunc,
+	}
+
+	for _, s := range legacy.Sections {
+		if s == nil {
+			continue
+		}
+		mc.Sections = append(mc.Sections, FromLegacySection(*s))
+	}
+
+	mc.PotentialActions = fromLegacyPotentialActions(legacy.PotentialActions)
+
+	return &mc
+}
+
+// ToLegacyMessageCard converts a MessageCard to the deprecated
+// goteamsnotify.MessageCard type. Any ValidateFunc is carried over, but a
+// prepared payload is not. A nil MessageCard results in a zero value.
+func ToLegacyMessageCard(mc *MessageCard) goteamsnotify.MessageCard {
+	if mc == nil {
+		return goteamsnotify.MessageCard{}
+	}
+
+	legacy := goteamsnotify.MessageCard{
+		Type:         mc.Type,
+		Context:      mc.Context,
+		Summary:      mc.Summary,
+		Title:        mc.Title,
+		Text:         mc.Text,
+		ThemeColor:   mc.ThemeColor,
+		ValidateFunc: mc.ValidateFunc,
+	}
+
+	for _, s := range mc.Sections {
+		if s == nil {
+			continue
+		}
+		legacy.Sections = append(legacy.Sections, ToLegacySection(*s))
+	}
+
+	legacy.PotentialActions = toLegacyPotentialActions(mc.PotentialActions)
+
+	return legacy
+}
+
+// FromLegacySection converts a deprecated goteamsnotify.MessageCardSection
+// value to a new Section.
+func FromLegacySection(legacy goteamsnotify.MessageCardSection) *Section {
+	s := Section{
+		Title:            legacy.Title,
+		Text:             legacy.Text,
+		ActivityImage:    legacy.ActivityImage,
+		ActivityTitle:    legacy.ActivityTitle,
+		ActivitySubtitle: legacy.ActivitySubtitle,
+		ActivityText:     legacy.ActivityText,
+		Markdown:         legacy.Markdown,
+		StartGroup:       legacy.StartGroup,
+	}
+
+	if legacy.HeroImage != nil {
+		heroImage := SectionImage(*legacy.HeroImage)
+		s.HeroImage = &heroImage
+	}
+
+	for _, f := range legacy.Facts {
+		s.Facts = append(s.Facts, SectionFact(f))
+	}
+
+	for _, img := range legacy.Images {
+		if img == nil {
+			continue
+		}
+		image := SectionImage(*img)
+		s.Images = append(s.Images, &image)
+	}
+
+	s.PotentialActions = fromLegacyPotentialActions(legacy.PotentialActions)
+
+	return &s
+}
+
+// ToLegacySection converts a Section to the deprecated
+// goteamsnotify.MessageCardSection type.
+func ToLegacySection(s Section) *goteamsnotify.MessageCardSection {
+	legacy := goteamsnotify.MessageCardSection{
+		Title:            s.Title,
+		Text:             s.Text,
+		ActivityImage:    s.ActivityImage,
+		ActivityTitle:    s.ActivityTitle,
+		ActivitySubtitle: s.ActivitySubtitle,
+		ActivityText:     s.ActivityText,
+		Markdown:         s.Markdown,
+		StartGroup:       s.StartGroup,
+	}
+
+	if s.HeroImage != nil {
+		heroImage := goteamsnotify.MessageCardSectionImage(*s.HeroImage)
+		legacy.HeroImage = &heroImage
+	}
+
+	for _, f := range s.Facts {
+		legacy.Facts = append(legacy.Facts, goteamsnotify.MessageCardSectionFact(f))
+	}
+
+	for _, img := range s.Images {
+		if img == nil {
+			continue
+		}
+		image := goteamsnotify.MessageCardSectionImage(*img)
+		legacy.Images = append(legacy.Images, &image)
+	}
+
+	legacy.PotentialActions = toLegacyPotentialActions(s.PotentialActions)
+
+	return &legacy
+}
+
+// FromLegacyPotentialAction converts a deprecated
+// goteamsnotify.MessageCardPotentialAction value to a new PotentialAction.
+func FromLegacyPotentialAction(legacy goteamsnotify.MessageCardPotentialAction) *PotentialAction {
+	pa := PotentialAction{
+		Type:                    legacy.Type,
+		Name:                    legacy.Name,
+		PotentialActionOpenURI:  fromLegacyOpenURI(legacy.MessageCardPotentialActionOpenURI),
+		PotentialActionHTTPPOST: fromLegacyHTTPPOST(legacy.MessageCardPotentialActionHTTPPOST),
+		PotentialActionInvokeAddInCommand: PotentialActionInvokeAddInCommand(
+			legacy.MessageCardPotentialActionInvokeAddInCommand,
+		),
+	}
+
+	for _, input := range legacy.Inputs {
+		pa.Inputs = append(pa.Inputs, PotentialActionActionCardInput{
+			Type:       input.Type,
+			ID:         input.ID,
+			Title:      input.Title,
+			Value:      input.Value,
+			IsRequired: input.IsRequired,
+			PotentialActionActionCardInputMultichoiceInput: PotentialActionActionCardInputMultichoiceInput(
+				input.MessageCardPotentialActionActionCardInputMultichoiceInput,
+			),
+			PotentialActionActionCardInputTextInput: PotentialActionActionCardInputTextInput(
+				input.MessageCardPotentialActionActionCardInputTextInput,
+			),
+			PotentialActionActionCardInputDateInput: PotentialActionActionCardInputDateInput(
+				input.MessageCardPotentialActionActionCardInputDateInput,
+			),
+		})
+	}
+
+	for _, action := range legacy.Actions {
+		pa.Actions = append(pa.Actions, PotentialActionActionCardAction{
+			Type:                    action.Type,
+			Name:                    action.Name,
+			PotentialActionOpenURI:  fromLegacyOpenURI(action.MessageCardPotentialActionOpenURI),
+			PotentialActionHTTPPOST: fromLegacyHTTPPOST(action.MessageCardPotentialActionHTTPPOST),
+		})
+	}
+
+	return &pa
+}
+
+// ToLegacyPotentialAction converts a PotentialAction to the deprecated
+// goteamsnotify.MessageCardPotentialAction type.
+func ToLegacyPotentialAction(pa PotentialAction) *goteamsnotify.MessageCardPotentialAction {
+	legacy := goteamsnotify.MessageCardPotentialAction{
+		Type:                               pa.Type,
+		Name:                               pa.Name,
+		MessageCardPotentialActionOpenURI:  toLegacyOpenURI(pa.PotentialActionOpenURI),
+		MessageCardPotentialActionHTTPPOST: toLegacyHTTPPOST(pa.PotentialActionHTTPPOST),
+		MessageCardPotentialActionInvokeAddInCommand: goteamsnotify.MessageCardPotentialActionInvokeAddInCommand(
+			pa.PotentialActionInvokeAddInCommand,
+		),
+	}
+
+	for _, input := range pa.Inputs {
+		legacy.Inputs = append(legacy.Inputs, goteamsnotify.MessageCardPotentialActionActionCardInput{
+			Type:       input.Type,
+			ID:         input.ID,
+			Title:      input.Title,
+			Value:      input.Value,
+			IsRequired: input.IsRequired,
+			MessageCardPotentialActionActionCardInputMultichoiceInput: goteamsnotify.MessageCardPotentialActionActionCardInputMultichoiceInput(
+				input.PotentialActionActionCardInputMultichoiceInput,
+			),
+			MessageCardPotentialActionActionCardInputTextInput: goteamsnotify.MessageCardPotentialActionActionCardInputTextInput(
+				input.PotentialActionActionCardInputTextInput,
+			),
+			MessageCardPotentialActionActionCardInputDateInput: goteamsnotify.MessageCardPotentialActionActionCardInputDateInput(
+				input.PotentialActionActionCardInputDateInput,
+			),
+		})
+	}
+
+	for _, action := range pa.Actions {
+		legacy.Actions = append(legacy.Actions, goteamsnotify.MessageCardPotentialActionActionCardAction{
+			Type:                               action.Type,
+			Name:                               action.Name,
+			MessageCardPotentialActionOpenURI:  toLegacyOpenURI(action.PotentialActionOpenURI),
+			MessageCardPotentialActionHTTPPOST: toLegacyHTTPPOST(action.PotentialActionHTTPPOST),
+		})
+	}
+
+	return &legacy
+}
+
+// fromLegacyPotentialActions converts a collection of deprecated
+// goteamsnotify.MessageCardPotentialAction values, skipping nil values.
+func fromLegacyPotentialActions(legacy []*goteamsnotify.MessageCardPotentialAction) []*PotentialAction {
+	var actions []*PotentialAction
+	for _, pa := range legacy {
+		if pa == nil {
+			continue
+		}
+		actions = append(actions, FromLegacyPotentialAction(*pa))
+	}
+
+	return actions
+}
+
+// toLegacyPotentialActions converts a collection of PotentialAction values
+// to the deprecated type, skipping nil values.
+func toLegacyPotentialActions(actions []*PotentialAction) []*goteamsnotify.MessageCardPotentialAction {
+	var legacy []*goteamsnotify.MessageCardPotentialAction
+	for _, pa := range actions {
+		if pa == nil {
+			continue
+		}
+		legacy = append(legacy, ToLegacyPotentialAction(*pa))
+	}
+
+	return legacy
+}
+
+func fromLegacyOpenURI(legacy goteamsnotify.MessageCardPotentialActionOpenURI) PotentialActionOpenURI {
+	var openURI PotentialActionOpenURI
+	for _, target := range legacy.Targets {
+		openURI.Targets = append(openURI.Targets, PotentialActionOpenURITarget(target))
+	}
+
+	return openURI
+}
+
+func toLegacyOpenURI(openURI PotentialActionOpenURI) goteamsnotify.MessageCardPotentialActionOpenURI {
+	var legacy goteamsnotify.MessageCardPotentialActionOpenURI
+	for _, target := range openURI.Targets {
+		legacy.Targets = append(legacy.Targets, goteamsnotify.MessageCardPotentialActionOpenURITarget(target))
+	}
+
+	return legacy
+}
+
+func fromLegacyHTTPPOST(legacy goteamsnotify.MessageCardPotentialActionHTTPPOST) PotentialActionHTTPPOST {
+	httpPOST := PotentialActionHTTPPOST{
+		Target:          legacy.Target,
+		Body:            legacy.Body,
+		BodyContentType: legacy.BodyContentType,
+	}
+
+	for _, header := range legacy.Headers {
+		httpPOST.Headers = append(httpPOST.Headers, PotentialActionHTTPPOSTHeader(header))
+	}
+
+	return httpPOST
+}
+
+func toLegacyHTTPPOST(httpPOST PotentialActionHTTPPOST) goteamsnotify.MessageCardPotentialActionHTTPPOST {
+	legacy := goteamsnotify.MessageCardPotentialActionHTTPPOST{
+		Target:          httpPOST.Target,
+		Body:            httpPOST.Body,
+		BodyContentType: httpPOST.BodyContentType,
+	}
+
+	for _, header := range httpPOST.Headers {
+		legacy.Headers = append(legacy.Headers, goteamsnotify.MessageCardPotentialActionHTTPPOSTHeader(header))
+	}
+
+	return legacy
+}
@@ -0,0 +1,155 @@
+// Copyright 2024 Adam Chalkley
+//
+// https://github.com/atc0005/go-teams-notify
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package messagecard
+
+import (
+	"encoding/json"
+	"testing"
+
+	goteamsnotify "github.com/flashcatcloud/go-teams-notify/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func newLegacyMessageCard() goteamsnotify.MessageCard {
+	openURI := goteamsnotify.MessageCardPotentialAction{
+		Type: PotentialActionOpenURIType,
+		Name: "Open",
+		MessageCardPotentialActionOpenURI: goteamsnotify.MessageCardPotentialActionOpenURI{
+			Targets: []goteamsnotify.MessageCardPotentialActionOpenURITarget{
+				{OS: "default", URI: "https://example.com"},
+			},
+		},
+	}
+
+	httpPOST := goteamsnotify.MessageCardPotentialAction{
+		Type: PotentialActionHTTPPostType,
+		Name: "Acknowledge",
+		MessageCardPotentialActionHTTPPOST: goteamsnotify.MessageCardPotentialActionHTTPPOST{
+			Target:          "https://example.com/ack",
+			Headers:         []goteamsnotify.MessageCardPotentialActionHTTPPOSTHeader{{Name: "X-Id", Value: "1"}},
+			Body:            `{"ack":true}`,
+			BodyContentType: "application/json",
+		},
+	}
+
+	actionCard := goteamsnotify.MessageCardPotentialAction{
+		Type: PotentialActionActionCardType,
+		Name: "Comment",
+	}
+	actionCard.Inputs = []goteamsnotify.MessageCardPotentialActionActionCardInput{
+		{
+			Type:       PotentialActionActionCardInputTextInputType,
+			ID:         "comment",
+			Title:      "Comment",
+			IsRequired: true,
+			MessageCardPotentialActionActionCardInputTextInput: goteamsnotify.MessageCardPotentialActionActionCardInputTextInput{
+				MaxLength:   100,
+				IsMultiline: true,
+			},
+		},
+		{
+			Type: PotentialActionActionCardInputDateInputType,
+			ID:   "due",
+			MessageCardPotentialActionActionCardInputDateInput: goteamsnotify.MessageCardPotentialActionActionCardInputDateInput{
+				IncludeTime: true,
+			},
+		},
+	}
+	actionCard.Actions = []goteamsnotify.MessageCardPotentialActionActionCardAction{
+		{
+			Type: PotentialActionHTTPPostType,
+			Name: "Save",
+			MessageCardPotentialActionHTTPPOST: goteamsnotify.MessageCardPotentialActionHTTPPOST{
+				Target: "https://example.com/comment",
+			},
+		},
+	}
+
+	return goteamsnotify.MessageCard{
+		Type:       "MessageCard",
+		Context:    "https://schema.org/extensions",
+		Summary:    "Build failed",
+		Title:      "Build failed",
+		Text:       "Build **#42** failed",
+		ThemeColor: "#C4314B",
+		Sections: []*goteamsnotify.MessageCardSection{
+			{
+				Title:         "Details",
+				ActivityTitle: "CI",
+				Markdown:      true,
+				StartGroup:    true,
+				HeroImage:     &goteamsnotify.MessageCardSectionImage{Image: "https://example.com/hero.png", Title: "Hero"},
+				Facts:         []goteamsnotify.MessageCardSectionFact{{Name: "Branch", Value: "main"}},
+				Images:        []*goteamsnotify.MessageCardSectionImage{{Image: "https://example.com/a.png"}},
+				PotentialActions: []*goteamsnotify.MessageCardPotentialAction{
+					&openURI,
+				},
+			},
+		},
+		PotentialActions: []*goteamsnotify.MessageCardPotentialAction{
+			&httpPOST,
+			&actionCard,
+		},
+	}
+}
+
+func TestFromLegacyMessageCard(t *testing.T) {
+	legacy := newLegacyMessageCard()
+
+	mc := FromLegacyMessageCard(legacy)
+
+	// The converted card encodes to the same payload.
+	want, err := json.Marshal(legacy)
+	assert.NoError(t, err)
+
+	got, err := json.Marshal(mc)
+	assert.NoError(t, err)
+
+	assert.JSONEq(t, string(want), string(got))
+
+	if assert.Len(t, mc.Sections, 1) && assert.Len(t, mc.Sections[0].PotentialActions, 1) {
+		assert.Equal(t, "https://example.com", mc.Sections[0].PotentialActions[0].PotentialActionOpenURI.Targets[0].URI)
+	}
+
+	if assert.Len(t, mc.PotentialActions, 2) {
+		assert.Equal(t, 100, mc.PotentialActions[1].Inputs[0].MaxLength)
+		assert.True(t, mc.PotentialActions[1].Inputs[1].IncludeTime)
+	}
+}
+
+func TestLegacyMessageCardRoundTrip(t *testing.T) {
+	legacy := newLegacyMessageCard()
+
+	assert.Equal(t, legacy, ToLegacyMessageCard(FromLegacyMessageCard(legacy)))
+}
+
+func TestLegacyConversionsSkipNilValues(t *testing.T) {
+	legacy := goteamsnotify.MessageCard{
+		Sections:         []*goteamsnotify.MessageCardSection{nil, {Title: "a"}},
+		PotentialActions: []*goteamsnotify.MessageCardPotentialAction{nil},
+	}
+
+	mc := FromLegacyMessageCard(legacy)
+	assert.Len(t, mc.Sections, 1)
+	assert.Empty(t, mc.PotentialActions)
+
+	mc.Sections = append(mc.Sections, nil)
+	assert.Len(t, ToLegacyMessageCard(mc).Sections, 1)
+
+	assert.Equal(t, goteamsnotify.MessageCard{}, ToLegacyMessageCard(nil))
+}
+
+func TestLegacyConversionsKeepValidateFunc(t *testing.T) {
+	legacy := goteamsnotify.MessageCard{
+		ValidateFunc: func() error { return nil },
+	}
+
+	mc := FromLegacyMessageCard(legacy)
+	assert.NotNil(t, mc.ValidateFunc)
+	assert.NotNil(t, ToLegacyMessageCard(mc).ValidateFunc)
+}
@@ -9,6 +9,7 @@
 package goteamsnotify
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -91,6 +92,47 @@ var ErrWebhookURLUnexpectedPrefix = ErrWebhookURLUnexpected
 // unsuccessful.
 var ErrInvalidWebhookURLResponseText = errors.New("invalid webhook URL response text")
 
+// Response headers used by Microsoft Teams (and the Office 365 services
+// fronting it) to identify a request. These values are recorded in a
+// DeliveryResult when present and are useful when opening a support case
+// regarding a specific message submission.
+const (
+	ResponseHeaderRequestID       = "request-id"
+	ResponseHeaderClientRequestID = "client-request-id"
+	ResponseHeaderMSRequestID     = "x-ms-request-id"
+	ResponseHeaderCorrelationID   = "x-ms-correlation-request-id"
+)
+
+// DeliveryResult records the details of a message submission to a Microsoft
+// Teams channel. A DeliveryResult is returned for both successful and failed
+// submissions; fields are populated as far as the submission progressed.
+type DeliveryResult struct {
+	// Attempts is the number of submission attempts made, including the
+	// initial attempt.
+	Attempts int
+
+	// Latency is the total time spent submitting the message, including any
+	// retry delays.
+	Latency time.Duration
+
+	// StatusCode is the HTTP status code from the final submission attempt.
+	// This value is zero if no response was received.
+	StatusCode int
+
+	// ResponseBody is the response text from the final submission attempt.
+	ResponseBody string
+
+	// PayloadBytes is the size in bytes of the prepared message payload
+	// submitted to the remote endpoint.
+	PayloadBytes int
+
+	// RequestIDs is a collection of request identifier header values (keyed
+	// by canonical header name) returned by the remote endpoint for the
+	// final submission attempt. See the ResponseHeader* constants for the
+	// headers that are recorded.
+	RequestIDs map[string]string
+}
+
 // API is the legacy interface representing a client used to submit messages
 // to a Microsoft Teams channel.
 type API interface {
@@ -103,6 +145,8 @@ type API interface {
 }
 
 // MessageSender describes the behavior of a baseline Microsoft Teams client.
+// See the Sender interface for an interface that may be implemented by
+// client code.
 //
 // An unexported method is used to prevent client code from implementing this
 // interface in order to support future changes (and not violate backwards
@@ -118,26 +162,52 @@ type MessageSender interface {
 	private()
 }
 
-// messagePreparer is a message type that supports marshaling its fields
+// MessagePreparer is a message type that supports marshaling its fields
 // as preparation for delivery to an endpoint.
-type messagePreparer interface {
+type MessagePreparer interface {
 	Prepare() error
 }
 
-// messageValidator is a message type that provides validation of its format.
-type messageValidator interface {
+// MessageValidator is a message type that provides validation of its format.
+type MessageValidator interface {
 	Validate() error
 }
 
-// teamsMessage is the interface shared by all supported message formats for
-// submission to a Microsoft Teams channel.
-type teamsMessage interface {
-	messagePreparer
-	messageValidator
+// TeamsMessage is the interface shared by all supported message formats for
+// submission to a Microsoft Teams channel. Client code may implement this
+// interface in order to submit message formats not provided by this library.
+//
+// Validate is called before Prepare, and Payload is only called after a
+// successful call to Prepare.
+type TeamsMessage interface {
+	MessagePreparer
+	MessageValidator
 
 	Payload() io.Reader
 }
 
+// Sender describes the behavior of a client used to submit messages to a
+// Microsoft Teams channel. Unlike MessageSender, this interface is intended
+// to be implemented by client code (e.g., test doubles or alternative
+// delivery backends) in place of TeamsClient.
+type Sender interface {
+	Send(webhookURL string, message TeamsMessage) error
+	SendWithContext(ctx context.Context, webhookURL string, message TeamsMessage) error
+	SendWithRetry(ctx context.Context, webhookURL string, message TeamsMessage, retries int, retriesDelay int) error
+}
+
+// SenderFunc is an adapter to allow the use of an ordinary function as a
+// Sender. The function is called for every message submission, regardless
+// of the Sender method used.
+type SenderFunc func(ctx context.Context, webhookURL string, message TeamsMessage) error
+
+// Add an "implements assertion" to fail the build if the Sender
+// implementations are incorrect.
+var (
+	_ Sender = (*TeamsClient)(nil)
+	_ Sender = SenderFunc(nil)
+)
+
 // teamsClient is the legacy client used for submitting messages to a
 // Microsoft Teams channel.
 type teamsClient struct {
@@ -296,7 +366,7 @@ func (c *teamsClient) Send(webhookURL string, webhookMessage MessageCard) error
 
 // Send is a wrapper function around the SendWithContext method in order to
 // provide backwards compatibility.
-func (c *TeamsClient) Send(webhookURL string, message teamsMessage) error {
+func (c *TeamsClient) Send(webhookURL string, message TeamsMessage) error {
 	// Create context that can be used to emulate existing timeout behavior.
 	ctx, cancel := context.WithTimeout(context.Background(), DefaultWebhookSendTimeout)
 	defer cancel()
@@ -316,7 +386,7 @@ func (c *teamsClient) SendWithContext(ctx context.Context, webhookURL string, we
 // SendWithContext submits a given message to a Microsoft Teams channel using
 // the provided webhook URL. The http client request honors the cancellation
 // or timeout of the provided context.
-func (c *TeamsClient) SendWithContext(ctx context.Context, webhookURL string, message teamsMessage) error {
+func (c *TeamsClient) SendWithContext(ctx context.Context, webhookURL string, message TeamsMessage) error {
 	return sendWithContext(ctx, c, webhookURL, message)
 }
 
@@ -332,10 +402,80 @@ func (c *teamsClient) SendWithRetry(ctx context.Context, webhookURL string, webh
 // SendWithRetry provides message retry support when submitting messages to a
 // Microsoft Teams channel. The caller is responsible for providing the
 // desired context timeout, the number of retries and retries delay.
-func (c *TeamsClient) SendWithRetry(ctx context.Context, webhookURL string, message teamsMessage, retries int, retriesDelay int) error {
+func (c *TeamsClient) SendWithRetry(ctx context.Context, webhookURL string, message TeamsMessage, retries int, retriesDelay int) error {
 	return sendWithRetry(ctx, c, webhookURL, message, retries, retriesDelay)
 }
 
+// SendWithResult submits a given message to a Microsoft Teams channel using
+// the provided webhook URL. The http client request honors the cancellation
+// or timeout of the provided context.
+//
+// A DeliveryResult is returned for both successful and failed submissions
+// and may be used as a record of delivery.
+func (c *TeamsClient) SendWithResult(ctx context.Context, webhookURL string, message TeamsMessage) (*DeliveryResult, error) {
+	result := DeliveryResult{}
+	start := time.Now()
+
+	err := sendWithResult(ctx, c, webhookURL, message, &result)
+	result.Attempts = 1
+	result.Latency = time.Since(start)
+
+	return &result, err
+}
+
+// SendWithRetryResult provides message retry support when submitting
+// messages to a Microsoft Teams channel. The caller is responsible for
+// providing the desired context timeout, the number of retries and retries
+// delay.
+//
+// A DeliveryResult is returned for both successful and failed submissions
+// and may be used as a record of delivery. The recorded response details are
+// from the final submission attempt.
+func (c *TeamsClient) SendWithRetryResult(ctx context.Context, webhookURL string, message TeamsMessage, retries int, retriesDelay int) (*DeliveryResult, error) {
+	result := DeliveryResult{}
+	err := sendWithRetryResult(ctx, c, webhookURL, message, retries, retriesDelay, &result)
+
+	return &result, err
+}
+
+// Send calls f using a context with the default send timeout applied.
+func (f SenderFunc) Send(webhookURL string, message TeamsMessage) error {
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultWebhookSendTimeout)
+	defer cancel()
+
+	return f(ctx, webhookURL, message)
+}
+
+// SendWithContext calls f using the provided context.
+func (f SenderFunc) SendWithContext(ctx context.Context, webhookURL string, message TeamsMessage) error {
+	return f(ctx, webhookURL, message)
+}
+
+// SendWithRetry calls f up to the specified number of retries (in addition
+// to the initial attempt) until it succeeds or the provided context is
+// cancelled, applying the given retries delay between attempts.
+func (f SenderFunc) SendWithRetry(ctx context.Context, webhookURL string, message TeamsMessage, retries int, retriesDelay int) error {
+	var result error
+
+	for attempt := 1; attempt <= 1+retries; attempt++ {
+		if result = f(ctx, webhookURL, message); result == nil {
+			return nil
+		}
+
+		if ctx.Err() != nil {
+			return fmt.Errorf(
+				"context cancelled or expired: %v; aborting message submission: %w",
+				ctx.Err().Error(),
+				result,
+			)
+		}
+
+		time.Sleep(time.Duration(retriesDelay) * time.Second)
+	}
+
+	return result
+}
+
 // SkipWebhookURLValidationOnSend allows the caller to optionally disable
 // webhook URL validation.
 //
@@ -368,7 +508,8 @@ func prepareRequest(ctx context.Context, userAgent string, webhookURL string, pr
 }
 
 // processResponse is a helper function responsible for validating a response
-// from an endpoint after submitting a message.
+// from an endpoint after submitting a message. The response text is returned
+// along with any validation error.
 func processResponse(response *http.Response) (string, error) {
 	// Get the response body, then convert to string for use with extended
 	// error messages
@@ -391,7 +532,7 @@ func processResponse(response *http.Response) (string, error) {
 
 		logger.Println(err)
 
-		return "", err
+		return responseString, err
 
 	// Microsoft Teams developers have indicated that a 200 status code is
 	// insufficient to confirm that a message was successfully submitted.
@@ -411,7 +552,7 @@ func processResponse(response *http.Response) (string, error) {
 
 		logger.Println(err)
 
-		return "", err
+		return responseString, err
 
 	default:
 		return responseString, nil
@@ -469,7 +610,15 @@ func (c *TeamsClient) ValidateWebhook(webhookURL string) error {
 // sendWithContext submits a given message to a Microsoft Teams channel using
 // the provided webhook URL and client. The http client request honors the
 // cancellation or timeout of the provided context.
-func sendWithContext(ctx context.Context, client MessageSender, webhookURL string, message teamsMessage) error {
+func sendWithContext(ctx context.Context, client MessageSender, webhookURL string, message TeamsMessage) error {
+	return sendWithResult(ctx, client, webhookURL, message, &DeliveryResult{})
+}
+
+// sendWithResult submits a given message to a Microsoft Teams channel using
+// the provided webhook URL and client, recording response details in the
+// given DeliveryResult. The http client request honors the cancellation or
+// timeout of the provided context.
+func sendWithResult(ctx context.Context, client MessageSender, webhookURL string, message TeamsMessage, result *DeliveryResult) error {
 	logger.Printf("sendWithContext: Webhook message received: %#v\n", message)
 
 	if err := client.ValidateWebhook(webhookURL); err != nil {
@@ -493,7 +642,18 @@ func sendWithContext(ctx context.Context, client MessageSender, webhookURL strin
 		)
 	}
 
-	req, err := prepareRequest(ctx, client.UserAgent(), webhookURL, message.Payload())
+	// Read the prepared payload so that we can record its size before
+	// submitting it.
+	payload, err := ioutil.ReadAll(message.Payload())
+	if err != nil {
+		return fmt.Errorf(
+			"failed to read prepared message: %w",
+			err,
+		)
+	}
+	result.PayloadBytes = len(payload)
+
+	req, err := prepareRequest(ctx, client.UserAgent(), webhookURL, bytes.NewReader(payload))
 	if err != nil {
 		return fmt.Errorf(
 			"failed to prepare request: %w",
@@ -517,7 +677,11 @@ func sendWithContext(ctx context.Context, client MessageSender, webhookURL strin
 		}
 	}()
 
+	result.StatusCode = res.StatusCode
+	result.RequestIDs = requestIDs(res.Header)
+
 	responseText, err := processResponse(res)
+	result.ResponseBody = responseText
 	if err != nil {
 		return fmt.Errorf(
 			"failed to process response: %w",
@@ -530,20 +694,56 @@ func sendWithContext(ctx context.Context, client MessageSender, webhookURL strin
 	return nil
 }
 
+// requestIDs collects known request identifier header values from the given
+// response headers.
+func requestIDs(header http.Header) map[string]string {
+	ids := make(map[string]string)
+
+	for _, name := range []string{
+		ResponseHeaderRequestID,
+		ResponseHeaderClientRequestID,
+		ResponseHeaderMSRequestID,
+		ResponseHeaderCorrelationID,
+	} {
+		if val := header.Get(name); val != "" {
+			ids[http.CanonicalHeaderKey(name)] = val
+		}
+	}
+
+	return ids
+}
+
 // sendWithRetry provides message retry support when submitting messages to a
 // Microsoft Teams channel. The caller is responsible for providing the
 // desired context timeout, the number of retries and retries delay.
-func sendWithRetry(ctx context.Context, client MessageSender, webhookURL string, message teamsMessage, retries int, retriesDelay int) error {
+func sendWithRetry(ctx context.Context, client MessageSender, webhookURL string, message TeamsMessage, retries int, retriesDelay int) error {
+	return sendWithRetryResult(ctx, client, webhookURL, message, retries, retriesDelay, &DeliveryResult{})
+}
+
+// sendWithRetryResult provides message retry support when submitting
+// messages to a Microsoft Teams channel, recording details of the submission
+// in the given DeliveryResult. The caller is responsible for providing the
+// desired context timeout, the number of retries and retries delay.
+func sendWithRetryResult(ctx context.Context, client MessageSender, webhookURL string, message TeamsMessage, retries int, retriesDelay int, deliveryResult *DeliveryResult) error {
 	var result error
 
+	start := time.Now()
+	defer func() {
+		deliveryResult.Latency = time.Since(start)
+	}()
+
 	// initial attempt + number of specified retries
 	attemptsAllowed := 1 + retries
 
 	// attempt to send message to Microsoft Teams, retry specified number of
 	// times before giving up
 	for attempt := 1; attempt <= attemptsAllowed; attempt++ {
+		// Response details from earlier attempts are discarded; only the
+		// details from the last attempt are retained.
+		*deliveryResult = DeliveryResult{Attempts: attempt}
+
 		// the result from the last attempt is returned to the caller
-		result = sendWithContext(ctx, client, webhookURL, message)
+		result = sendWithResult(ctx, client, webhookURL, message, deliveryResult)
 
 		switch {
 		case result != nil:
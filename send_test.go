@@ -10,7 +10,10 @@ package goteamsnotify
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"errors"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
@@ -266,6 +269,127 @@ func TestTeamsClientSend(t *testing.T) {
 
 }
 
+func TestTeamsClientSendWithResult(t *testing.T) {
+	msgCard := NewMessageCard()
+	msgCard.Text = "Hello World"
+
+	client := NewTestClient(func(req *http.Request) (*http.Response, error) {
+		header := make(http.Header)
+		header.Set(ResponseHeaderRequestID, "8d3a4b5c-request")
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(bytes.NewBufferString(ExpectedWebhookURLResponseText)),
+			Header:     header,
+		}, nil
+	})
+
+	c := NewTeamsClient().SetHTTPClient(client)
+
+	result, err := c.SendWithResult(context.Background(), "https://outlook.office.com/webhook/xxx", &msgCard)
+	assert.NoError(t, err)
+
+	expectedPayload, _ := json.Marshal(msgCard)
+
+	assert.Equal(t, 1, result.Attempts)
+	assert.Equal(t, http.StatusOK, result.StatusCode)
+	assert.Equal(t, ExpectedWebhookURLResponseText, result.ResponseBody)
+	assert.Equal(t, len(expectedPayload), result.PayloadBytes)
+	assert.Equal(t, "8d3a4b5c-request", result.RequestIDs["Request-Id"])
+	assert.True(t, result.Latency > 0)
+}
+
+func TestTeamsClientSendWithRetryResult(t *testing.T) {
+	msgCard := NewMessageCard()
+	msgCard.Text = "Hello World"
+
+	var calls int
+	client := NewTestClient(func(req *http.Request) (*http.Response, error) {
+		calls++
+
+		status, body := http.StatusTooManyRequests, "throttled"
+		if calls == 2 {
+			status, body = http.StatusOK, ExpectedWebhookURLResponseText
+		}
+
+		return &http.Response{
+			StatusCode: status,
+			Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+			Header:     make(http.Header),
+		}, nil
+	})
+
+	c := NewTeamsClient().SetHTTPClient(client)
+
+	result, err := c.SendWithRetryResult(context.Background(), "https://outlook.office.com/webhook/xxx", &msgCard, 2, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.Attempts)
+	assert.Equal(t, http.StatusOK, result.StatusCode)
+	assert.Equal(t, ExpectedWebhookURLResponseText, result.ResponseBody)
+
+	// A failed submission still reports the response received.
+	calls = 10
+	result, err = c.SendWithResult(context.Background(), "https://outlook.office.com/webhook/xxx", &msgCard)
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusTooManyRequests, result.StatusCode)
+	assert.Equal(t, "throttled", result.ResponseBody)
+}
+
+// rawMessage is a minimal third-party TeamsMessage implementation.
+type rawMessage struct {
+	body    string
+	payload *bytes.Buffer
+}
+
+func (m *rawMessage) Validate() error {
+	if m.body == "" {
+		return errors.New("empty body")
+	}
+	return nil
+}
+
+func (m *rawMessage) Prepare() error {
+	m.payload = bytes.NewBufferString(m.body)
+	return nil
+}
+
+func (m *rawMessage) Payload() io.Reader {
+	return m.payload
+}
+
+func TestSenderImplementations(t *testing.T) {
+	msg := &rawMessage{body: `{"text":"Hello World"}`}
+
+	var received []TeamsMessage
+	var fake Sender = SenderFunc(func(ctx context.Context, webhookURL string, message TeamsMessage) error {
+		received = append(received, message)
+		if len(received) < 3 {
+			return errors.New("pling")
+		}
+		return nil
+	})
+
+	assert.Error(t, fake.Send("https://outlook.office.com/webhook/xxx", msg))
+	assert.NoError(t, fake.SendWithRetry(context.Background(), "https://outlook.office.com/webhook/xxx", msg, 1, 0))
+	assert.Len(t, received, 3)
+
+	var gotBody string
+	client := NewTestClient(func(req *http.Request) (*http.Response, error) {
+		data, _ := ioutil.ReadAll(req.Body)
+		gotBody = string(data)
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(bytes.NewBufferString(ExpectedWebhookURLResponseText)),
+			Header:     make(http.Header),
+		}, nil
+	})
+
+	var real Sender = NewTeamsClient().SetHTTPClient(client)
+	assert.NoError(t, real.Send("https://outlook.office.com/webhook/xxx", msg))
+	assert.Equal(t, msg.body, gotBody)
+}
+
 // helper for testing --------------------------------------------------------------------------------------------------
 
 // RoundTripFunc .
@@ -0,0 +1,379 @@
+// Copyright 2024 Adam Chalkley
+//
+// https://github.com/atc0005/go-teams-notify
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package teamstest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/flashcatcloud/go-teams-notify/v2/adaptivecard"
+	"github.com/stretchr/testify/assert"
+)
+
+// The functions below assemble the same payloads as the examples in the
+// examples/adaptivecard directory. Client configuration examples (e.g.,
+// proxy or custom-user-agent) send the same payload as the basic example and
+// are not repeated here.
+
+// mustNoError stops the test if the given error is non-nil.
+func mustNoError(t *testing.T, err error) {
+	t.Helper()
+
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+}
+
+func exampleBasic(t *testing.T) *adaptivecard.Message {
+	msgText := "Here are some examples of formatted stuff like " +
+		"\n * this list itself  \n * **bold** \n * *italic* \n * ***bolditalic***"
+
+	msg, err := adaptivecard.NewSimpleMessage(msgText, "Hello world", true)
+	mustNoError(t, err)
+
+	return msg
+}
+
+func exampleActions(t *testing.T) *adaptivecard.Message {
+	card, err := adaptivecard.NewTextBlockCard("Simple message with OpenURL action", "Hello World", true)
+	mustNoError(t, err)
+
+	urlAction, err := adaptivecard.NewActionOpenURL("https://github.com/atc0005/go-teams-notify", "Project Homepage")
+	mustNoError(t, err)
+	mustNoError(t, card.AddAction(true, urlAction))
+
+	msg, err := adaptivecard.NewMessageFromCard(card)
+	mustNoError(t, err)
+
+	return msg
+}
+
+func exampleTableManuallyCreated(t *testing.T) *adaptivecard.Message {
+	hide := false
+
+	textCell := func(text string) adaptivecard.TableCell {
+		return adaptivecard.TableCell{
+			Type: adaptivecard.TypeTableCell,
+			Items: []*adaptivecard.Element{
+				{Type: adaptivecard.TypeElementTextBlock, Wrap: true, Text: text},
+			},
+		}
+	}
+
+	textRow := func(texts ...string) adaptivecard.TableRow {
+		row := adaptivecard.TableRow{Type: adaptivecard.TypeTableRow}
+		for _, text := range texts {
+			row.Cells = append(row.Cells, textCell(text))
+		}
+
+		return row
+	}
+
+	column := func(horizontal string, vertical string) adaptivecard.Column {
+		return adaptivecard.Column{
+			Type:                           adaptivecard.TypeTableColumnDefinition,
+			Width:                          1,
+			HorizontalCellContentAlignment: horizontal,
+			VerticalCellContentAlignment:   vertical,
+		}
+	}
+
+	card := adaptivecard.NewCard()
+	card.Version = fmt.Sprintf(adaptivecard.AdaptiveCardVersionTmpl, adaptivecard.AdaptiveCardMaxVersion)
+	card.Body = []adaptivecard.Element{
+		{
+			Type:              adaptivecard.TypeElementTable,
+			GridStyle:         adaptivecard.ContainerStyleAccent,
+			ShowGridLines:     &hide,
+			FirstRowAsHeaders: &hide,
+			Columns: []adaptivecard.Column{
+				column(adaptivecard.HorizontalAlignmentLeft, adaptivecard.VerticalAlignmentBottom),
+				column(adaptivecard.HorizontalAlignmentCenter, adaptivecard.VerticalAlignmentCenter),
+				column(adaptivecard.HorizontalAlignmentRight, adaptivecard.VerticalAlignmentBottom),
+			},
+			Rows: []adaptivecard.TableRow{
+				textRow("Column 1 header", "Column 2 header", "Column 3 header"),
+				textRow("Table cell test!", "Table cell test!", "Table cell test!"),
+				textRow("Table cell test!", "Table cell test!", "Table cell test!"),
+			},
+		},
+	}
+
+	msg := &adaptivecard.Message{Type: adaptivecard.TypeMessage}
+	mustNoError(t, msg.Attach(card))
+
+	return msg
+}
+
+func exampleTableUnorderedGrid(t *testing.T) *adaptivecard.Message {
+	items := make([]interface{}, 0, 11)
+	for i := 1; i <= 11; i++ {
+		items = append(items, i)
+	}
+
+	tableCells, err := adaptivecard.NewTableCellsWithTextBlock(items)
+	mustNoError(t, err)
+
+	card := adaptivecard.NewCard()
+	for _, perRow := range []int{2, 4, 6, 8, 10} {
+		table, err := adaptivecard.NewTableWithGridFromTableCells(tableCells, perRow)
+		mustNoError(t, err)
+
+		table.Separator = true
+		card.Body = append(card.Body, table)
+	}
+
+	msg := &adaptivecard.Message{Type: adaptivecard.TypeMessage}
+	mustNoError(t, msg.Attach(card))
+
+	return msg
+}
+
+func exampleTableWithHeaders(t *testing.T) *adaptivecard.Message {
+	vals := [][]string{
+		{"column1", "column2", "column3"},
+		{"row 1, value 1", "row 1, value 2", "row 1, value 3"},
+		{"", "", ""},
+		{"row 3, value 1", "row 3, value 2", "row 3, value 3"},
+	}
+
+	cellsCollection := make([][]adaptivecard.TableCell, 0, len(vals))
+	for _, row := range vals {
+		items := make([]interface{}, len(row))
+		for i := range row {
+			items[i] = row[i]
+		}
+
+		tableCells, err := adaptivecard.NewTableCellsWithTextBlock(items)
+		mustNoError(t, err)
+
+		cellsCollection = append(cellsCollection, tableCells)
+	}
+
+	table, err := adaptivecard.NewTableFromTableCells(cellsCollection, 0, true, true)
+	mustNoError(t, err)
+
+	card := adaptivecard.NewCard()
+	card.Body = append(card.Body, table)
+
+	msg := &adaptivecard.Message{Type: adaptivecard.TypeMessage}
+	mustNoError(t, msg.Attach(card))
+
+	return msg
+}
+
+func exampleToggleVisibilitySingleButton(t *testing.T) *adaptivecard.Message {
+	card := adaptivecard.NewCard()
+
+	headerTextBlock := adaptivecard.NewTitleTextBlock("Press the button to show details", false)
+	detailsBlock := adaptivecard.NewHiddenTextBlock("Details text block content here", true)
+	detailsBlock.ID = "detailsBlock"
+
+	mustNoError(t, card.AddElement(true, headerTextBlock, detailsBlock))
+
+	toggleButton := adaptivecard.NewActionToggleVisibility("Toggle!")
+	mustNoError(t, toggleButton.AddTargetElement(nil, detailsBlock))
+	mustNoError(t, card.AddAction(true, toggleButton))
+
+	msg, err := adaptivecard.NewMessageFromCard(card)
+	mustNoError(t, err)
+
+	return msg
+}
+
+func exampleToggleVisibilityMultipleButtons(t *testing.T) *adaptivecard.Message {
+	card := adaptivecard.NewCard()
+
+	headerTextBlock := adaptivecard.NewTitleTextBlock("Press the buttons to toggle visibility", false)
+
+	toggleTargets := make([]adaptivecard.Element, 0, 3)
+	for _, id := range []string{"textBlock1", "textBlock2", "textBlock3"} {
+		textBlock := adaptivecard.NewHiddenTextBlock("Text Block "+id[len(id)-1:], true)
+		textBlock.ID = id
+		toggleTargets = append(toggleTargets, textBlock)
+	}
+
+	mustNoError(t, card.AddElement(true, append([]adaptivecard.Element{headerTextBlock}, toggleTargets...)...))
+
+	toggleButton := adaptivecard.NewActionToggleVisibility("Toggle!")
+	mustNoError(t, toggleButton.AddTargetElement(nil, toggleTargets...))
+
+	showButton := adaptivecard.NewActionToggleVisibility("Show!")
+	mustNoError(t, showButton.AddVisibleTargetElement(toggleTargets...))
+
+	hideButton := adaptivecard.NewActionToggleVisibility("Hide!")
+	mustNoError(t, hideButton.AddHiddenTargetElement(toggleTargets...))
+
+	mustNoError(t, card.AddAction(true, toggleButton))
+	mustNoError(t, card.AddAction(false, showButton))
+	mustNoError(t, card.AddAction(false, hideButton))
+
+	msg, err := adaptivecard.NewMessageFromCard(card)
+	mustNoError(t, err)
+
+	return msg
+}
+
+func exampleToggleVisibilityContainerAction(t *testing.T) *adaptivecard.Message {
+	card := adaptivecard.NewCard()
+
+	headerTextBlock := adaptivecard.NewTitleTextBlock("Press the link text to show details", false)
+	detailsMessageBlock := adaptivecard.NewHiddenTextBlock("Details text block content here", true)
+	detailsMessageBlock.ID = "details"
+
+	mustNoError(t, card.AddElement(true, headerTextBlock, detailsMessageBlock))
+
+	showDetailsTextBlock := adaptivecard.NewTextBlock("Show details", false)
+	showDetailsTextBlock.ID = "showDetails"
+
+	hideDetailsTextBlock := adaptivecard.NewHiddenTextBlock("Hide details", false)
+	hideDetailsTextBlock.ID = "hideDetails"
+
+	showHideLinkContainer := adaptivecard.NewContainer()
+	mustNoError(t, showHideLinkContainer.AddElement(true, showDetailsTextBlock))
+	mustNoError(t, showHideLinkContainer.AddElement(false, hideDetailsTextBlock))
+
+	detailsDisplayAction := adaptivecard.NewActionToggleVisibility("")
+	mustNoError(t, detailsDisplayAction.AddTargetElement(
+		nil,
+		detailsMessageBlock,
+		showDetailsTextBlock,
+		hideDetailsTextBlock,
+	))
+
+	mustNoError(t, showHideLinkContainer.AddSelectAction(detailsDisplayAction))
+	mustNoError(t, card.AddContainer(false, showHideLinkContainer))
+
+	msg, err := adaptivecard.NewMessageFromCard(card)
+	mustNoError(t, err)
+
+	return msg
+}
+
+func exampleToggleVisibilityColumnAction(t *testing.T) *adaptivecard.Message {
+	card := adaptivecard.NewCard()
+
+	headerTextBlock := adaptivecard.NewTitleTextBlock("Column SelectAction demo", false)
+	mustNoError(t, card.AddElement(true, headerTextBlock))
+
+	showHistoryTextBlock := adaptivecard.NewTextBlock("Show history", false)
+	showHistoryTextBlock.ID = "showHistory"
+
+	hideHistoryTextBlock := adaptivecard.NewHiddenTextBlock("Hide history", false)
+	hideHistoryTextBlock.ID = "hideHistory"
+
+	historyDisplayControlColumn := adaptivecard.NewColumn()
+	historyDisplayControlColumn.Width = 1
+	historyDisplayControlColumn.VerticalCellContentAlignment = adaptivecard.VerticalAlignmentCenter
+	historyDisplayControlColumn.Items = append(
+		historyDisplayControlColumn.Items,
+		&showHistoryTextBlock,
+		&hideHistoryTextBlock,
+	)
+
+	historyContainer := adaptivecard.NewHiddenContainer()
+	historyContainer.ID = "historyContainer"
+	mustNoError(t, historyContainer.AddElement(true, adaptivecard.NewTextBlock(
+		"Event submitted by John Doe on Wed, Dec 6, 2023",
+		false,
+	)))
+	mustNoError(t, historyContainer.AddElement(false, adaptivecard.NewTextBlock(
+		"Event submitted by Harry Dresden on Wed, Dec 6, 2023",
+		false,
+	)))
+
+	historyDisplayAction := adaptivecard.NewActionToggleVisibility("")
+	mustNoError(t, historyDisplayAction.AddTargetElementID(
+		nil,
+		showHistoryTextBlock.ID,
+		hideHistoryTextBlock.ID,
+		historyContainer.ID,
+	))
+	mustNoError(t, historyDisplayControlColumn.AddSelectAction(historyDisplayAction))
+
+	historyDisplayColumnSet := adaptivecard.NewColumnSet()
+	historyDisplayColumnSet.Columns = append(historyDisplayColumnSet.Columns, historyDisplayControlColumn)
+
+	mustNoError(t, card.AddElement(false, historyDisplayColumnSet))
+	mustNoError(t, card.AddContainer(false, historyContainer))
+
+	msg, err := adaptivecard.NewMessageFromCard(card)
+	mustNoError(t, err)
+
+	return msg
+}
+
+func exampleUserMentionSingle(t *testing.T) *adaptivecard.Message {
+	msg := adaptivecard.NewMessage()
+	mustNoError(t, msg.Mention(true, "John Doe", "jdoe@example.com", "Hello there!"))
+
+	return msg
+}
+
+func exampleUserMentionMultiple(t *testing.T) *adaptivecard.Message {
+	msgText := "Here are some examples of formatted stuff like " +
+		"\n * this list itself  \n * **bold** \n * *italic* \n * ***bolditalic***"
+
+	card, err := adaptivecard.NewTextBlockCard(msgText, "Hello world", true)
+	mustNoError(t, err)
+
+	johnDoe, err := adaptivecard.NewMention("John Doe", "jdoe@example.com")
+	mustNoError(t, err)
+
+	harryDresden, err := adaptivecard.NewMention("Harry Dresden", "hdresden@example.com")
+	mustNoError(t, err)
+
+	mustNoError(t, card.AddMention(true, johnDoe, harryDresden))
+
+	msg, err := adaptivecard.NewMessageFromCard(card)
+	mustNoError(t, err)
+
+	return msg
+}
+
+func exampleUserMentionVerbose(t *testing.T) *adaptivecard.Message {
+	msg, err := adaptivecard.NewSimpleMessage("NewSimpleMessage.", "", true)
+	mustNoError(t, err)
+
+	mustNoError(t, msg.Mention(
+		false,
+		"John Doe",
+		"jdoe@example.com",
+		"with a user mention added as a second step.",
+	))
+
+	return msg
+}
+
+func TestGoldenExamplePayloads(t *testing.T) {
+	tests := map[string]func(t *testing.T) *adaptivecard.Message{
+		"basic":                              exampleBasic,
+		"actions":                            exampleActions,
+		"table-manually-created":             exampleTableManuallyCreated,
+		"table-unordered-grid":               exampleTableUnorderedGrid,
+		"table-with-headers":                 exampleTableWithHeaders,
+		"toggle-visibility-single-button":    exampleToggleVisibilitySingleButton,
+		"toggle-visibility-multiple-buttons": exampleToggleVisibilityMultipleButtons,
+		"toggle-visibility-container-action": exampleToggleVisibilityContainerAction,
+		"toggle-visibility-column-action":    exampleToggleVisibilityColumnAction,
+		"user-mention-single":                exampleUserMentionSingle,
+		"user-mention-multiple":              exampleUserMentionMultiple,
+		"user-mention-verbose":               exampleUserMentionVerbose,
+	}
+
+	for name, newMessage := range tests {
+		name, newMessage := name, newMessage
+
+		t.Run(name, func(t *testing.T) {
+			msg := newMessage(t)
+			if assert.NoError(t, msg.Validate()) {
+				AssertGolden(t, "example-"+name, msg)
+			}
+		})
+	}
+}
@@ -0,0 +1,243 @@
+// Copyright 2024 Adam Chalkley
+//
+// https://github.com/atc0005/go-teams-notify
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package teamstest
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	goteamsnotify "github.com/flashcatcloud/go-teams-notify/v2"
+)
+
+// GoldenUpdateEnvVar is the environment variable which, when set to a
+// non-empty value, causes AssertGolden to rewrite golden files instead of
+// comparing against them. The -teamstest.update flag has the same effect.
+const GoldenUpdateEnvVar string = "TEAMSTEST_UPDATE"
+
+// GoldenDir is the directory (relative to the package under test) where
+// golden files are stored.
+const GoldenDir string = "testdata"
+
+// goldenFileExt is the file extension used for golden files.
+const goldenFileExt string = ".golden.json"
+
+// updateGolden is set via the -teamstest.update flag. The flag is namespaced
+// to avoid conflicting with an -update flag registered by the package under
+// test.
+var updateGolden = flag.Bool(
+	"teamstest.update",
+	false,
+	"rewrite teamstest golden files instead of comparing against them",
+)
+
+// Normalize returns the given JSON payload in a stable format suitable for
+// comparison: object keys are sorted and the output is indented using the
+// same style as the PrettyPrint methods provided by the message types in
+// this library.
+func Normalize(payload []byte) ([]byte, error) {
+	var v interface{}
+	if err := json.Unmarshal(payload, &v); err != nil {
+		return nil, fmt.Errorf("failed to decode payload: %w", err)
+	}
+
+	// The encoding/json package sorts map keys when encoding.
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "\t")
+
+	if err := enc.Encode(v); err != nil {
+		return nil, fmt.Errorf("failed to encode payload: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// PreparedPayload prepares the given message and returns its normalized
+// payload.
+func PreparedPayload(message goteamsnotify.TeamsMessage) ([]byte, error) {
+	if err := message.Prepare(); err != nil {
+		return nil, fmt.Errorf("failed to prepare message: %w", err)
+	}
+
+	payload, err := ioutil.ReadAll(message.Payload())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read message payload: %w", err)
+	}
+
+	return Normalize(payload)
+}
+
+// AssertGolden prepares the given message and compares its normalized
+// payload to the golden file testdata/<name>.golden.json. Any differences
+// are reported as JSON paths.
+//
+// If the -teamstest.update flag is given or the TEAMSTEST_UPDATE
+// environment variable is set, the golden file is written instead.
+func AssertGolden(t testing.TB, name string, message goteamsnotify.TeamsMessage) {
+	t.Helper()
+
+	got, err := PreparedPayload(message)
+	if err != nil {
+		t.Fatalf("teamstest: %v", err)
+	}
+
+	AssertGoldenPayload(t, name, got)
+}
+
+// AssertGoldenPayload compares the given JSON payload, once normalized, to
+// the golden file testdata/<name>.golden.json. See AssertGolden for details.
+func AssertGoldenPayload(t testing.TB, name string, payload []byte) {
+	t.Helper()
+
+	got, err := Normalize(payload)
+	if err != nil {
+		t.Fatalf("teamstest: %v", err)
+	}
+
+	path := filepath.Join(GoldenDir, name+goldenFileExt)
+
+	if *updateGolden || os.Getenv(GoldenUpdateEnvVar) != "" {
+		if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+			t.Fatalf("teamstest: failed to create golden file directory: %v", err)
+		}
+
+		if err := ioutil.WriteFile(path, got, 0600); err != nil {
+			t.Fatalf("teamstest: failed to update golden file: %v", err)
+		}
+
+		return
+	}
+
+	want, err := ioutil.ReadFile(filepath.Clean(path))
+	if err != nil {
+		t.Fatalf(
+			"teamstest: failed to read golden file (run with -teamstest.update to create it): %v",
+			err,
+		)
+	}
+
+	diffs, err := Diff(want, got)
+	if err != nil {
+		t.Fatalf("teamstest: %v", err)
+	}
+
+	if len(diffs) > 0 {
+		t.Errorf(
+			"teamstest: payload does not match golden file %s:\n\t%s",
+			path,
+			strings.Join(diffs, "\n\t"),
+		)
+	}
+}
+
+// Diff compares two JSON documents structurally and returns a description
+// of each difference, prefixed by the JSON path (e.g.,
+// $.attachments[0].content.body[1].text) where it was found. An empty
+// collection is returned if the documents are equivalent.
+func Diff(want []byte, got []byte) ([]string, error) {
+	var w, g interface{}
+
+	if err := json.Unmarshal(want, &w); err != nil {
+		return nil, fmt.Errorf("failed to decode expected JSON: %w", err)
+	}
+
+	if err := json.Unmarshal(got, &g); err != nil {
+		return nil, fmt.Errorf("failed to decode actual JSON: %w", err)
+	}
+
+	var diffs []string
+	diffValues("$", w, g, &diffs)
+
+	return diffs, nil
+}
+
+// diffValues records the differences between want and got, found at the
+// given JSON path.
+func diffValues(path string, want interface{}, got interface{}, diffs *[]string) {
+	switch w := want.(type) {
+	case map[string]interface{}:
+		g, ok := got.(map[string]interface{})
+		if !ok {
+			break
+		}
+
+		keys := make([]string, 0, len(w)+len(g))
+		for k := range w {
+			keys = append(keys, k)
+		}
+		for k := range g {
+			if _, ok := w[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			wv, inWant := w[k]
+			gv, inGot := g[k]
+			childPath := path + "." + k
+
+			switch {
+			case !inGot:
+				*diffs = append(*diffs, fmt.Sprintf("%s: removed (was %s)", childPath, jsonString(wv)))
+			case !inWant:
+				*diffs = append(*diffs, fmt.Sprintf("%s: added %s", childPath, jsonString(gv)))
+			default:
+				diffValues(childPath, wv, gv, diffs)
+			}
+		}
+
+		return
+
+	case []interface{}:
+		g, ok := got.([]interface{})
+		if !ok {
+			break
+		}
+
+		for i := 0; i < len(w) || i < len(g); i++ {
+			childPath := fmt.Sprintf("%s[%d]", path, i)
+
+			switch {
+			case i >= len(g):
+				*diffs = append(*diffs, fmt.Sprintf("%s: removed (was %s)", childPath, jsonString(w[i])))
+			case i >= len(w):
+				*diffs = append(*diffs, fmt.Sprintf("%s: added %s", childPath, jsonString(g[i])))
+			default:
+				diffValues(childPath, w[i], g[i], diffs)
+			}
+		}
+
+		return
+	}
+
+	if !reflect.DeepEqual(want, got) {
+		*diffs = append(*diffs, fmt.Sprintf("%s: changed from %s to %s", path, jsonString(want), jsonString(got)))
+	}
+}
+
+// jsonString returns a compact JSON representation of the given value for
+// use in diff output.
+func jsonString(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+
+	return string(data)
+}
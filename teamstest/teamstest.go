@@ -0,0 +1,330 @@
+// Copyright 2024 Adam Chalkley
+//
+// https://github.com/atc0005/go-teams-notify
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+// Package teamstest provides a fake Microsoft Teams webhook endpoint for use
+// in tests.
+//
+// The Server type records every payload received and responds as a
+// Microsoft Teams incoming webhook would. Scripted responses may be queued
+// in order to exercise failure handling such as throttling (429 with
+// Retry-After), rejected payloads (400 "Summary or Text is required."),
+// unexpected response text or slow responses.
+package teamstest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	goteamsnotify "github.com/flashcatcloud/go-teams-notify/v2"
+	"github.com/flashcatcloud/go-teams-notify/v2/adaptivecard"
+	"github.com/flashcatcloud/go-teams-notify/v2/messagecard"
+)
+
+// WebhookPath is the URL path of the fake webhook endpoint. Requests to any
+// other path receive a 404 response.
+const WebhookPath string = "/webhookb2/teamstest/IncomingWebhook/teamstest"
+
+// ResponseTextSummaryRequired is the response text returned by Microsoft
+// Teams when a MessageCard payload is missing both the Summary and Text
+// fields.
+const ResponseTextSummaryRequired string = "Summary or Text is required."
+
+// Request is a message submission received by the Server.
+type Request struct {
+	// Header is the set of request headers received.
+	Header http.Header
+
+	// Body is the raw request body (the message payload) received.
+	Body []byte
+
+	// Received is the time the request was received.
+	Received time.Time
+}
+
+// Response is a scripted response returned by the Server.
+type Response struct {
+	// StatusCode is the HTTP status code returned. If not set,
+	// http.StatusOK is used.
+	StatusCode int
+
+	// Body is the response text returned.
+	Body string
+
+	// Header is an optional set of response headers returned.
+	Header http.Header
+
+	// Delay is applied before the response is returned.
+	Delay time.Duration
+}
+
+// Server is a fake Microsoft Teams webhook endpoint backed by an
+// httptest.Server.
+type Server struct {
+	// Server is the underlying test server. It is started by NewServer and
+	// stopped by Close.
+	*httptest.Server
+
+	mu        sync.Mutex
+	requests  []Request
+	responses []Response
+	fallback  Response
+}
+
+// NewServer starts and returns a new Server. The caller should call Close
+// when finished to shut it down.
+//
+// By default every request receives a successful response (200 status code
+// and the expected response text). Use Enqueue to script other responses.
+func NewServer() *Server {
+	s := Server{
+		fallback: OK(),
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+
+	return &s
+}
+
+// WebhookURL returns the URL of the fake webhook endpoint.
+func (s *Server) WebhookURL() string {
+	return s.URL + WebhookPath
+}
+
+// TeamsClient returns a new TeamsClient configured to submit messages to
+// this Server. Webhook URL validation is disabled for the client since the
+// Server URL does not match the expected webhook URL patterns.
+func (s *Server) TeamsClient() *goteamsnotify.TeamsClient {
+	return goteamsnotify.NewTeamsClient().
+		SetHTTPClient(s.Client()).
+		SkipWebhookURLValidationOnSend(true)
+}
+
+// Enqueue adds one or more scripted responses. Scripted responses are used
+// in order, one per request. Once exhausted, the default response is used.
+func (s *Server) Enqueue(responses ...Response) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.responses = append(s.responses, responses...)
+}
+
+// SetDefaultResponse replaces the response used once all scripted responses
+// have been used.
+func (s *Server) SetDefaultResponse(response Response) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.fallback = response
+}
+
+// Requests returns a copy of the collection of requests received.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	requests := make([]Request, len(s.requests))
+	copy(requests, s.requests)
+
+	return requests
+}
+
+// Reset discards all received requests and any unused scripted responses.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = nil
+	s.responses = nil
+}
+
+// handle records the received request and writes the next response.
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != WebhookPath {
+		http.NotFound(w, r)
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{
+		Header:   r.Header.Clone(),
+		Body:     body,
+		Received: time.Now(),
+	})
+
+	response := s.fallback
+	if len(s.responses) > 0 {
+		response = s.responses[0]
+		s.responses = s.responses[1:]
+	}
+	s.mu.Unlock()
+
+	if response.Delay > 0 {
+		select {
+		case <-time.After(response.Delay):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	for name, values := range response.Header {
+		for _, value := range values {
+			w.Header().Add(name, value)
+		}
+	}
+
+	statusCode := response.StatusCode
+	if statusCode == 0 {
+		statusCode = http.StatusOK
+	}
+
+	w.WriteHeader(statusCode)
+	_, _ = w.Write([]byte(response.Body))
+}
+
+// OK returns a Response indicating successful message submission.
+func OK() Response {
+	return Response{
+		StatusCode: http.StatusOK,
+		Body:       goteamsnotify.ExpectedWebhookURLResponseText,
+	}
+}
+
+// Throttled returns a Response indicating that the client has exceeded the
+// rate limit for the webhook. The given delay is returned as the number of
+// seconds in the Retry-After header.
+func Throttled(retryAfter time.Duration) Response {
+	header := make(http.Header)
+	header.Set("Retry-After", strconv.Itoa(int(retryAfter.Seconds())))
+
+	return Response{
+		StatusCode: http.StatusTooManyRequests,
+		Body:       "Microsoft Teams endpoint returned HTTP error 429",
+		Header:     header,
+	}
+}
+
+// SummaryRequired returns a Response emulating the rejection of a
+// MessageCard payload which is missing both the Summary and Text fields.
+func SummaryRequired() Response {
+	return Response{
+		StatusCode: http.StatusBadRequest,
+		Body:       ResponseTextSummaryRequired,
+	}
+}
+
+// UnexpectedText returns a Response with a 200 status code, but response
+// text other than the expected value. Clients are expected to treat this as
+// a failed submission.
+func UnexpectedText(text string) Response {
+	return Response{
+		StatusCode: http.StatusOK,
+		Body:       text,
+	}
+}
+
+// Delayed returns a copy of the given Response which is only returned after
+// the specified delay.
+func Delayed(response Response, delay time.Duration) Response {
+	response.Delay = delay
+
+	return response
+}
+
+// AdaptiveCard decodes the request body as an Adaptive Card Message.
+func (r Request) AdaptiveCard() (adaptivecard.Message, error) {
+	var msg adaptivecard.Message
+	if err := json.Unmarshal(r.Body, &msg); err != nil {
+		return adaptivecard.Message{}, fmt.Errorf(
+			"failed to decode request body as Adaptive Card message: %w",
+			err,
+		)
+	}
+
+	return msg, nil
+}
+
+// MessageCard decodes the request body as a MessageCard.
+func (r Request) MessageCard() (messagecard.MessageCard, error) {
+	var msg messagecard.MessageCard
+	if err := json.Unmarshal(r.Body, &msg); err != nil {
+		return messagecard.MessageCard{}, fmt.Errorf(
+			"failed to decode request body as MessageCard: %w",
+			err,
+		)
+	}
+
+	return msg, nil
+}
+
+// AssertRequestCount fails the test if the number of requests received does
+// not match the expected count.
+func (s *Server) AssertRequestCount(t testing.TB, expected int) {
+	t.Helper()
+
+	if got := len(s.Requests()); got != expected {
+		t.Errorf("teamstest: got %d requests, expected %d", got, expected)
+	}
+}
+
+// LastRequest returns the most recently received request, failing the test
+// if no requests have been received.
+func (s *Server) LastRequest(t testing.TB) Request {
+	t.Helper()
+
+	requests := s.Requests()
+	if len(requests) == 0 {
+		t.Fatalf("teamstest: no requests received")
+	}
+
+	return requests[len(requests)-1]
+}
+
+// LastAdaptiveCard returns the most recently received payload decoded as an
+// Adaptive Card Message, failing the test if no requests have been received
+// or if the payload cannot be decoded.
+func (s *Server) LastAdaptiveCard(t testing.TB) adaptivecard.Message {
+	t.Helper()
+
+	msg, err := s.LastRequest(t).AdaptiveCard()
+	if err != nil {
+		t.Fatalf("teamstest: %v", err)
+	}
+
+	return msg
+}
+
+// LastMessageCard returns the most recently received payload decoded as a
+// MessageCard, failing the test if no requests have been received or if the
+// payload cannot be decoded.
+func (s *Server) LastMessageCard(t testing.TB) messagecard.MessageCard {
+	t.Helper()
+
+	msg, err := s.LastRequest(t).MessageCard()
+	if err != nil {
+		t.Fatalf("teamstest: %v", err)
+	}
+
+	return msg
+}
@@ -0,0 +1,117 @@
+// Copyright 2024 Adam Chalkley
+//
+// https://github.com/atc0005/go-teams-notify
+//
+// Licensed under the MIT License. See LICENSE file in the project root for
+// full license information.
+
+package teamstest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	goteamsnotify "github.com/flashcatcloud/go-teams-notify/v2"
+	"github.com/flashcatcloud/go-teams-notify/v2/adaptivecard"
+	"github.com/flashcatcloud/go-teams-notify/v2/messagecard"
+)
+
+func TestServerRecordsPayloads(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	client := srv.TeamsClient()
+
+	msg, err := adaptivecard.NewSimpleMessage("Hello World", "Greeting", true)
+	assert.NoError(t, err)
+	assert.NoError(t, client.Send(srv.WebhookURL(), msg))
+
+	mc := messagecard.NewMessageCard()
+	mc.Text = "Hello World"
+	assert.NoError(t, client.Send(srv.WebhookURL(), mc))
+
+	srv.AssertRequestCount(t, 2)
+
+	requests := srv.Requests()
+	got, err := requests[0].AdaptiveCard()
+	assert.NoError(t, err)
+	assert.Equal(t, adaptivecard.TypeMessage, got.Type)
+	assert.Equal(t, "Hello World", got.Attachments[0].Content.Body[1].Text)
+	assert.Equal(t, goteamsnotify.DefaultUserAgent, requests[0].Header.Get("User-Agent"))
+
+	assert.Equal(t, "Hello World", srv.LastMessageCard(t).Text)
+}
+
+func TestServerScriptedResponses(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	client := srv.TeamsClient()
+
+	mc := messagecard.NewMessageCard()
+	mc.Text = "Hello World"
+
+	srv.Enqueue(
+		Throttled(30*time.Second),
+		SummaryRequired(),
+		UnexpectedText("0"),
+	)
+
+	result, err := client.SendWithResult(context.Background(), srv.WebhookURL(), mc)
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusTooManyRequests, result.StatusCode)
+
+	result, err = client.SendWithResult(context.Background(), srv.WebhookURL(), mc)
+	assert.Error(t, err)
+	assert.Equal(t, ResponseTextSummaryRequired, result.ResponseBody)
+
+	err = client.Send(srv.WebhookURL(), mc)
+	assert.True(t, errors.Is(err, goteamsnotify.ErrInvalidWebhookURLResponseText))
+
+	// Scripted responses are exhausted; the default response is used.
+	assert.NoError(t, client.Send(srv.WebhookURL(), mc))
+
+	srv.Enqueue(Delayed(OK(), time.Second))
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.Error(t, client.SendWithContext(ctx, srv.WebhookURL(), mc))
+
+	srv.AssertRequestCount(t, 5)
+}
+
+func TestGoldenPayloads(t *testing.T) {
+	msg, err := adaptivecard.NewSimpleMessage("Hello World", "Greeting", true)
+	assert.NoError(t, err)
+	AssertGolden(t, "adaptivecard-simple", msg)
+
+	mentionMsg, err := adaptivecard.NewMentionMessage("John Doe", "jdoe@example.com", "New release pushed!")
+	assert.NoError(t, err)
+	AssertGolden(t, "adaptivecard-mention", mentionMsg)
+
+	mc := messagecard.NewMessageCard()
+	mc.Title = "Greeting"
+	mc.Text = "Hello World"
+	AssertGolden(t, "messagecard-basic", mc)
+}
+
+func TestDiff(t *testing.T) {
+	want := []byte(`{"type":"message","attachments":[{"content":{"body":[{"text":"a"},{"text":"b"}]}}]}`)
+	got := []byte(`{"type":"message","attachments":[{"content":{"body":[{"text":"a","wrap":true}]}}],"extra":1}`)
+
+	diffs, err := Diff(want, got)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		`$.attachments[0].content.body[0].wrap: added true`,
+		`$.attachments[0].content.body[1]: removed (was {"text":"b"})`,
+		`$.extra: added 1`,
+	}, diffs)
+
+	diffs, err = Diff(want, want)
+	assert.NoError(t, err)
+	assert.Empty(t, diffs)
+}